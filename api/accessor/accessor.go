@@ -9,9 +9,11 @@ import (
 type Access interface {
 	IsAuthenticated() bool
 	IsAuthorized(string) bool
+	HasRole(string, Role) bool
 	IsAdmin() bool
 	IsSystem() bool
 	TeamNames() []string
+	UserName() string
 	CSRFToken() string
 }
 
@@ -32,6 +34,40 @@ func (a *access) IsAuthorized(team string) bool {
 	return false
 }
 
+// HasRole is true if the requester is an admin, or is on the team with at
+// least the given role. Roles are carried per team in the teamRoles claim,
+// e.g. {"main": "owner", "other-team": "viewer"}. Tokens without the claim
+// were issued without roles and are treated as owners of their team; a team
+// missing from the claim or with an unrecognized role has none.
+func (a *access) HasRole(team string, role Role) bool {
+	if a.IsAdmin() {
+		return true
+	}
+
+	if !a.IsAuthorized(team) {
+		return false
+	}
+
+	return a.teamRole(team) >= role
+}
+
+func (a *access) teamRole(team string) Role {
+	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
+		if teamRolesClaim, ok := claims["teamRoles"]; ok {
+			if teamRoles, ok := teamRolesClaim.(map[string]interface{}); ok {
+				if teamRole, ok := teamRoles[team].(string); ok {
+					if role, ok := ParseRole(teamRole); ok {
+						return role
+					}
+				}
+			}
+
+			return 0
+		}
+	}
+	return OwnerRole
+}
+
 func (a *access) IsAdmin() bool {
 	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
 		if isAdminClaim, ok := claims["isAdmin"]; ok {
@@ -63,6 +99,17 @@ func (a *access) TeamNames() []string {
 	return []string{}
 }
 
+func (a *access) UserName() string {
+	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
+		if userNameClaim, ok := claims["userName"]; ok {
+			if userName, ok := userNameClaim.(string); ok {
+				return userName
+			}
+		}
+	}
+	return ""
+}
+
 func (a *access) CSRFToken() string {
 	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
 		if csrfTokenClaim, ok := claims["csrf"]; ok {
@@ -215,6 +215,131 @@ var _ = Describe("Accessor", func() {
 		})
 	})
 
+	Describe("Has Role", func() {
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req)
+		})
+
+		Context("when request has a pipeline-operator role on the team", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"teamName":  "some-team",
+					"teamRoles": map[string]string{"some-team": "pipeline-operator"},
+				}
+			})
+			It("returns true for the role and the roles below it", func() {
+				Expect(access.HasRole("some-team", accessor.ViewerRole)).To(BeTrue())
+				Expect(access.HasRole("some-team", accessor.PipelineOperatorRole)).To(BeTrue())
+			})
+			It("returns false for the roles above it", func() {
+				Expect(access.HasRole("some-team", accessor.MemberRole)).To(BeFalse())
+				Expect(access.HasRole("some-team", accessor.OwnerRole)).To(BeFalse())
+			})
+			It("returns false for other teams", func() {
+				Expect(access.HasRole("other-team", accessor.ViewerRole)).To(BeFalse())
+			})
+		})
+
+		Context("when request has a viewer role on the team", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"teamName":  "some-team",
+					"teamRoles": map[string]string{"some-team": "viewer"},
+				}
+			})
+			It("returns true only for viewer", func() {
+				Expect(access.HasRole("some-team", accessor.ViewerRole)).To(BeTrue())
+				Expect(access.HasRole("some-team", accessor.PipelineOperatorRole)).To(BeFalse())
+			})
+		})
+
+		Context("when request has different roles on different teams", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"teamName": "some-team",
+					"teamRoles": map[string]string{
+						"some-team":  "viewer",
+						"other-team": "owner",
+					},
+				}
+			})
+			It("uses the role on the requested team", func() {
+				Expect(access.HasRole("some-team", accessor.ViewerRole)).To(BeTrue())
+				Expect(access.HasRole("some-team", accessor.OwnerRole)).To(BeFalse())
+			})
+		})
+
+		Context("when request has roles on other teams only", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"teamName":  "some-team",
+					"teamRoles": map[string]string{"other-team": "owner"},
+				}
+			})
+			It("returns false", func() {
+				Expect(access.HasRole("some-team", accessor.ViewerRole)).To(BeFalse())
+			})
+		})
+
+		Context("when request has an unknown role on the team", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"teamName":  "some-team",
+					"teamRoles": map[string]string{"some-team": "bogus"},
+				}
+			})
+			It("returns false", func() {
+				Expect(access.HasRole("some-team", accessor.ViewerRole)).To(BeFalse())
+			})
+		})
+
+		Context("when request has team roles claim set to nil", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"teamName": "some-team", "teamRoles": nil}
+			})
+			It("returns false", func() {
+				Expect(access.HasRole("some-team", accessor.ViewerRole)).To(BeFalse())
+			})
+		})
+
+		Context("when request does not have team roles claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"teamName": "some-team"}
+			})
+			It("returns true for every role", func() {
+				Expect(access.HasRole("some-team", accessor.OwnerRole)).To(BeTrue())
+			})
+		})
+
+		Context("when request does not have team name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"teamRoles": map[string]string{"some-team": "owner"}}
+			})
+			It("returns false", func() {
+				Expect(access.HasRole("some-team", accessor.ViewerRole)).To(BeFalse())
+			})
+		})
+
+		Context("when request is from an admin", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"teamName":  "main",
+					"isAdmin":   true,
+					"teamRoles": map[string]string{"main": "viewer"},
+				}
+			})
+			It("returns true for every role on every team", func() {
+				Expect(access.HasRole("main", accessor.OwnerRole)).To(BeTrue())
+				Expect(access.HasRole("some-team", accessor.OwnerRole)).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("Get CSRF Token", func() {
 		JustBeforeEach(func() {
 			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
@@ -260,6 +385,41 @@ var _ = Describe("Accessor", func() {
 		})
 	})
 
+	Describe("Get User Name", func() {
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req)
+		})
+
+		Context("when request has user name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"userName": "some-user"}
+			})
+			It("returns the user name", func() {
+				Expect(access.UserName()).To(Equal("some-user"))
+			})
+		})
+		Context("when request has user name claim set to nil", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"userName": nil}
+			})
+			It("returns empty", func() {
+				Expect(access.UserName()).To(BeEmpty())
+			})
+		})
+		Context("when request does not have user name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{}
+			})
+			It("returns empty", func() {
+				Expect(access.UserName()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Get Team Names", func() {
 		JustBeforeEach(func() {
 			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
@@ -28,6 +28,18 @@ type FakeAccess struct {
 	isAuthorizedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	HasRoleStub        func(string, accessor.Role) bool
+	hasRoleMutex       sync.RWMutex
+	hasRoleArgsForCall []struct {
+		arg1 string
+		arg2 accessor.Role
+	}
+	hasRoleReturns struct {
+		result1 bool
+	}
+	hasRoleReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsAdminStub        func() bool
 	isAdminMutex       sync.RWMutex
 	isAdminArgsForCall []struct{}
@@ -55,6 +67,15 @@ type FakeAccess struct {
 	teamNamesReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	UserNameStub        func() string
+	userNameMutex       sync.RWMutex
+	userNameArgsForCall []struct{}
+	userNameReturns     struct {
+		result1 string
+	}
+	userNameReturnsOnCall map[int]struct {
+		result1 string
+	}
 	CSRFTokenStub        func() string
 	cSRFTokenMutex       sync.RWMutex
 	cSRFTokenArgsForCall []struct{}
@@ -156,6 +177,55 @@ func (fake *FakeAccess) IsAuthorizedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeAccess) HasRole(arg1 string, arg2 accessor.Role) bool {
+	fake.hasRoleMutex.Lock()
+	ret, specificReturn := fake.hasRoleReturnsOnCall[len(fake.hasRoleArgsForCall)]
+	fake.hasRoleArgsForCall = append(fake.hasRoleArgsForCall, struct {
+		arg1 string
+		arg2 accessor.Role
+	}{arg1, arg2})
+	fake.recordInvocation("HasRole", []interface{}{arg1, arg2})
+	fake.hasRoleMutex.Unlock()
+	if fake.HasRoleStub != nil {
+		return fake.HasRoleStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.hasRoleReturns.result1
+}
+
+func (fake *FakeAccess) HasRoleCallCount() int {
+	fake.hasRoleMutex.RLock()
+	defer fake.hasRoleMutex.RUnlock()
+	return len(fake.hasRoleArgsForCall)
+}
+
+func (fake *FakeAccess) HasRoleArgsForCall(i int) (string, accessor.Role) {
+	fake.hasRoleMutex.RLock()
+	defer fake.hasRoleMutex.RUnlock()
+	return fake.hasRoleArgsForCall[i].arg1, fake.hasRoleArgsForCall[i].arg2
+}
+
+func (fake *FakeAccess) HasRoleReturns(result1 bool) {
+	fake.HasRoleStub = nil
+	fake.hasRoleReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeAccess) HasRoleReturnsOnCall(i int, result1 bool) {
+	fake.HasRoleStub = nil
+	if fake.hasRoleReturnsOnCall == nil {
+		fake.hasRoleReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.hasRoleReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeAccess) IsAdmin() bool {
 	fake.isAdminMutex.Lock()
 	ret, specificReturn := fake.isAdminReturnsOnCall[len(fake.isAdminArgsForCall)]
@@ -276,6 +346,46 @@ func (fake *FakeAccess) TeamNamesReturnsOnCall(i int, result1 []string) {
 	}{result1}
 }
 
+func (fake *FakeAccess) UserName() string {
+	fake.userNameMutex.Lock()
+	ret, specificReturn := fake.userNameReturnsOnCall[len(fake.userNameArgsForCall)]
+	fake.userNameArgsForCall = append(fake.userNameArgsForCall, struct{}{})
+	fake.recordInvocation("UserName", []interface{}{})
+	fake.userNameMutex.Unlock()
+	if fake.UserNameStub != nil {
+		return fake.UserNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.userNameReturns.result1
+}
+
+func (fake *FakeAccess) UserNameCallCount() int {
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
+	return len(fake.userNameArgsForCall)
+}
+
+func (fake *FakeAccess) UserNameReturns(result1 string) {
+	fake.UserNameStub = nil
+	fake.userNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAccess) UserNameReturnsOnCall(i int, result1 string) {
+	fake.UserNameStub = nil
+	if fake.userNameReturnsOnCall == nil {
+		fake.userNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.userNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeAccess) CSRFToken() string {
 	fake.cSRFTokenMutex.Lock()
 	ret, specificReturn := fake.cSRFTokenReturnsOnCall[len(fake.cSRFTokenArgsForCall)]
@@ -323,12 +433,16 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.isAuthenticatedMutex.RUnlock()
 	fake.isAuthorizedMutex.RLock()
 	defer fake.isAuthorizedMutex.RUnlock()
+	fake.hasRoleMutex.RLock()
+	defer fake.hasRoleMutex.RUnlock()
 	fake.isAdminMutex.RLock()
 	defer fake.isAdminMutex.RUnlock()
 	fake.isSystemMutex.RLock()
 	defer fake.isSystemMutex.RUnlock()
 	fake.teamNamesMutex.RLock()
 	defer fake.teamNamesMutex.RUnlock()
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
 	fake.cSRFTokenMutex.RLock()
 	defer fake.cSRFTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
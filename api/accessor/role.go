@@ -0,0 +1,34 @@
+package accessor
+
+// Role is a team member's level of access within their team. Each role grants
+// everything the roles below it do.
+type Role int
+
+const (
+	ViewerRole Role = iota + 1
+	PipelineOperatorRole
+	MemberRole
+	OwnerRole
+)
+
+var roleNames = map[Role]string{
+	ViewerRole:           "viewer",
+	PipelineOperatorRole: "pipeline-operator",
+	MemberRole:           "member",
+	OwnerRole:            "owner",
+}
+
+// ParseRole returns the role with the given name, as carried in the token.
+func ParseRole(name string) (Role, bool) {
+	for role, roleName := range roleNames {
+		if roleName == name {
+			return role, true
+		}
+	}
+
+	return 0, false
+}
+
+func (role Role) String() string {
+	return roleNames[role]
+}
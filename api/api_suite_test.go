@@ -49,6 +49,7 @@ var (
 	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
+	dbAuditEventFactory     *dbfakes.FakeAuditEventFactory
 	dbTeam                  *dbfakes.FakeTeam
 	fakeSchedulerFactory    *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
@@ -93,6 +94,7 @@ var _ = BeforeEach(func() {
 	dbPipelineFactory = new(dbfakes.FakePipelineFactory)
 	dbJobFactory = new(dbfakes.FakeJobFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
+	dbAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -172,6 +174,7 @@ var _ = BeforeEach(func() {
 		fakeVolumeFactory,
 		fakeContainerRepository,
 		dbBuildFactory,
+		dbAuditEventFactory,
 
 		peerURL,
 		constructedEventHandler.Construct,
@@ -195,6 +198,7 @@ var _ = BeforeEach(func() {
 		"4.5.6",
 		fakeVariablesFactory,
 		interceptTimeoutFactory,
+		time.Hour,
 	)
 
 	Expect(err).NotTo(HaveOccurred())
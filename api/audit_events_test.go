@@ -0,0 +1,182 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Events API", func() {
+	var fakeaccess *accessorfakes.FakeAccess
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/audit_events", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/audit_events" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not list audit events", func() {
+				Expect(dbAuditEventFactory.AuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not list audit events", func() {
+				Expect(dbAuditEventFactory.AuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+
+				dbAuditEventFactory.AuditEventsReturns([]db.AuditEvent{
+					{
+						ID:       2,
+						Time:     time.Unix(200, 0),
+						UserName: "some-user",
+						TeamName: "some-team",
+						Route:    "PausePipeline",
+						Target: map[string]string{
+							"team_name":     "some-team",
+							"pipeline_name": "some-pipeline",
+						},
+						Status: http.StatusOK,
+					},
+					{
+						ID:       1,
+						Time:     time.Unix(100, 0),
+						UserName: "some-admin",
+						Route:    "SetTeam",
+						Target:   map[string]string{},
+						Status:   http.StatusForbidden,
+					},
+				}, db.Pagination{}, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the audit events", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 2,
+						"time": 200,
+						"user_name": "some-user",
+						"team_name": "some-team",
+						"route": "PausePipeline",
+						"target": {
+							"team_name": "some-team",
+							"pipeline_name": "some-pipeline"
+						},
+						"status": 200
+					},
+					{
+						"id": 1,
+						"time": 100,
+						"user_name": "some-admin",
+						"team_name": "",
+						"route": "SetTeam",
+						"target": {},
+						"status": 403
+					}
+				]`))
+			})
+
+			Context("when no pagination params are given", func() {
+				It("uses the default limit", func() {
+					Expect(dbAuditEventFactory.AuditEventsCallCount()).To(Equal(1))
+					Expect(dbAuditEventFactory.AuditEventsArgsForCall(0)).To(Equal(db.Page{Limit: 100}))
+				})
+			})
+
+			Context("when pagination params are given", func() {
+				BeforeEach(func() {
+					queryParams = "?since=5&limit=2"
+				})
+
+				It("passes them through", func() {
+					Expect(dbAuditEventFactory.AuditEventsArgsForCall(0)).To(Equal(db.Page{Since: 5, Limit: 2}))
+				})
+			})
+
+			Context("when next/previous pages are available", func() {
+				BeforeEach(func() {
+					dbAuditEventFactory.AuditEventsReturns(nil, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("returns Link headers per rfc5988", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/audit_events?until=4&limit=2>; rel="previous"`, externalURL),
+						fmt.Sprintf(`<%s/api/v1/audit_events?since=3&limit=2>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when listing the audit events fails", func() {
+				BeforeEach(func() {
+					dbAuditEventFactory.AuditEventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package auditserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	externalURL string
+
+	auditEventFactory db.AuditEventFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	auditEventFactory db.AuditEventFactory,
+) *Server {
+	return &Server{
+		logger:            logger,
+		externalURL:       externalURL,
+		auditEventFactory: auditEventFactory,
+	}
+}
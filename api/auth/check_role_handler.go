@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/db"
+)
+
+type checkRoleHandler struct {
+	handler  http.Handler
+	role     accessor.Role
+	rejector Rejector
+}
+
+// CheckRoleHandler rejects members of the team owning the requested resource
+// unless they have at least the given role. Requests from anyone else are
+// left to the handlers deciding whether they may access the team at all.
+//
+// The team is determined by the route's team name, or else by the build or
+// worker found by an outer handler.
+func CheckRoleHandler(
+	handler http.Handler,
+	role accessor.Role,
+	rejector Rejector,
+) http.Handler {
+	return checkRoleHandler{
+		handler:  handler,
+		role:     role,
+		rejector: rejector,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	acc := accessor.GetAccessor(r)
+
+	teamName := requestedTeamName(r)
+
+	if teamName != "" && acc.IsAuthorized(teamName) && !acc.HasRole(teamName, h.role) {
+		h.rejector.Unauthorized(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
+
+func requestedTeamName(r *http.Request) string {
+	if teamName := r.URL.Query().Get(":team_name"); teamName != "" {
+		return teamName
+	}
+
+	if build, ok := r.Context().Value(BuildContextKey).(db.Build); ok {
+		return build.TeamName()
+	}
+
+	if worker, ok := r.Context().Value(WorkerContextKey).(db.Worker); ok {
+		return worker.TeamName()
+	}
+
+	return ""
+}
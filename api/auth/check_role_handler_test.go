@@ -0,0 +1,210 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/api/auth/authfakes"
+	"github.com/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRoleHandler", func() {
+	var (
+		fakeAccessor *accessorfakes.FakeAccessFactory
+		fakeaccess   *accessorfakes.FakeAccess
+		fakeRejector *authfakes.FakeRejector
+
+		outerHandler func(http.Handler) http.Handler
+
+		server *httptest.Server
+		client *http.Client
+
+		request  *http.Request
+		response *http.Response
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeAccessor = new(accessorfakes.FakeAccessFactory)
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.UnauthorizedStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusUnauthorized)
+		}
+
+		fakeAccessor.CreateReturns(fakeaccess)
+
+		outerHandler = func(handler http.Handler) http.Handler {
+			return handler
+		}
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+
+		var err error
+		request, err = http.NewRequest("PUT", "/", bytes.NewBufferString("hello"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(accessor.NewHandler(outerHandler(auth.CheckRoleHandler(
+			simpleHandler,
+			accessor.MemberRole,
+			fakeRejector,
+		)), fakeAccessor))
+
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.URL.Scheme = serverURL.Scheme
+		request.URL.Host = serverURL.Host
+
+		response, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	itProxiesToTheHandler := func() {
+		It("returns 200", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("proxies to the handler", func() {
+			responseBody, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBody)).To(Equal("simple hello"))
+		})
+	}
+
+	Context("when the request is for a team", func() {
+		BeforeEach(func() {
+			urlValues := url.Values{":team_name": []string{"some-team"}}
+			request.URL.RawQuery = urlValues.Encode()
+		})
+
+		Context("when the requester is on the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("with at least the required role", func() {
+				BeforeEach(func() {
+					fakeaccess.HasRoleReturns(true)
+				})
+
+				itProxiesToTheHandler()
+
+				It("checks the role on the requested team", func() {
+					Expect(fakeaccess.HasRoleCallCount()).To(Equal(1))
+					teamName, role := fakeaccess.HasRoleArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(role).To(Equal(accessor.MemberRole))
+				})
+			})
+
+			Context("without the required role", func() {
+				BeforeEach(func() {
+					fakeaccess.HasRoleReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("nope\n"))
+				})
+			})
+		})
+
+		Context("when the requester is not on the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			itProxiesToTheHandler()
+
+			It("does not check the role", func() {
+				Expect(fakeaccess.HasRoleCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when an outer handler found the build being requested", func() {
+		BeforeEach(func() {
+			fakeBuild := new(dbfakes.FakeBuild)
+			fakeBuild.TeamNameReturns("some-build-team")
+
+			outerHandler = func(handler http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctx := context.WithValue(r.Context(), auth.BuildContextKey, fakeBuild)
+					handler.ServeHTTP(w, r.WithContext(ctx))
+				})
+			}
+
+			fakeaccess.IsAuthorizedReturns(true)
+			fakeaccess.HasRoleReturns(false)
+		})
+
+		It("checks the role on the build's team", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			teamName, _ := fakeaccess.HasRoleArgsForCall(0)
+			Expect(teamName).To(Equal("some-build-team"))
+		})
+	})
+
+	Context("when an outer handler found the worker being requested", func() {
+		BeforeEach(func() {
+			fakeWorker := new(dbfakes.FakeWorker)
+			fakeWorker.TeamNameReturns("some-worker-team")
+
+			outerHandler = func(handler http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctx := context.WithValue(r.Context(), auth.WorkerContextKey, fakeWorker)
+					handler.ServeHTTP(w, r.WithContext(ctx))
+				})
+			}
+
+			fakeaccess.IsAuthorizedReturns(true)
+			fakeaccess.HasRoleReturns(false)
+		})
+
+		It("checks the role on the worker's team", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			teamName, _ := fakeaccess.HasRoleArgsForCall(0)
+			Expect(teamName).To(Equal("some-worker-team"))
+		})
+	})
+
+	Context("when the request is not for any team", func() {
+		BeforeEach(func() {
+			fakeaccess.IsAuthorizedReturns(true)
+			fakeaccess.HasRoleReturns(false)
+		})
+
+		itProxiesToTheHandler()
+	})
+})
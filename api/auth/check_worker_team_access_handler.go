@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/concourse/atc/api/accessor"
@@ -72,5 +73,6 @@ func (h checkWorkerTeamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 
-	h.delegateHandler.ServeHTTP(w, r)
+	ctx := context.WithValue(r.Context(), WorkerContextKey, worker)
+	h.delegateHandler.ServeHTTP(w, r.WithContext(ctx))
 }
@@ -2,6 +2,7 @@ package auth
 
 const BuildContextKey = "build"
 const PipelineContextKey = "pipeline"
+const WorkerContextKey = "worker"
 
 const TokenTypeBearer = "Bearer"
 
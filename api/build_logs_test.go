@@ -0,0 +1,408 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+)
+
+var _ = Describe("Build Logs API", func() {
+	var (
+		fakeaccess   *accessorfakes.FakeAccess
+		fakePipeline *dbfakes.FakePipeline
+		fakeJob      *dbfakes.FakeJob
+		results      []db.BuildLogSearchResult
+	)
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.NameReturns("some-pipeline")
+		fakePipeline.TeamNameReturns("some-team")
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.IDReturns(1)
+
+		dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+		dbTeam.PipelineReturns(fakePipeline, true, nil)
+		fakePipeline.JobReturns(fakeJob, true, nil)
+
+		build := new(dbfakes.FakeBuild)
+		build.IDReturns(4)
+		build.NameReturns("2")
+		build.JobNameReturns("some-job")
+		build.PipelineNameReturns("some-pipeline")
+		build.TeamNameReturns("some-team")
+		build.StatusReturns(db.BuildStatusFailed)
+		build.StartTimeReturns(time.Unix(1, 0))
+		build.EndTimeReturns(time.Unix(100, 0))
+
+		results = []db.BuildLogSearchResult{
+			{
+				Build: build,
+				Matches: []db.BuildLogMatch{
+					{
+						Origin: event.Origin{ID: "some-step", Source: event.OriginSourceStderr},
+						Line:   3,
+						Text:   "connection reset by peer",
+						Before: []string{"dialing"},
+						After:  []string{"retrying"},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/build_logs", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = "?q=connection+reset"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/build_logs" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeam.SearchBuildLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeam.SearchBuildLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when no query is given", func() {
+				BeforeEach(func() {
+					queryParams = "?q=+"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the time range is invalid", func() {
+				BeforeEach(func() {
+					queryParams = "?q=reset&from=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when too much context is requested", func() {
+				BeforeEach(func() {
+					queryParams = "?q=reset&context=100"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when only the query is given", func() {
+				It("searches with the default context and page", func() {
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(Equal(1))
+
+					search, page := dbTeam.SearchBuildLogsArgsForCall(0)
+					Expect(search).To(Equal(db.BuildLogSearch{
+						Query:   "connection reset",
+						Context: 2,
+					}))
+					Expect(page).To(Equal(db.Page{Limit: 100}))
+				})
+			})
+
+			Context("when all the params are given", func() {
+				BeforeEach(func() {
+					queryParams = "?q=reset&from=10&to=20&context=0&since=2&until=3&limit=8"
+				})
+
+				It("passes them through", func() {
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(Equal(1))
+
+					search, page := dbTeam.SearchBuildLogsArgsForCall(0)
+					Expect(search).To(Equal(db.BuildLogSearch{
+						Query:   "reset",
+						From:    time.Unix(10, 0),
+						To:      time.Unix(20, 0),
+						Context: 0,
+					}))
+					Expect(page).To(Equal(db.Page{Since: 2, Until: 3, Limit: 8}))
+				})
+			})
+
+			Context("when searching succeeds", func() {
+				BeforeEach(func() {
+					dbTeam.SearchBuildLogsReturns(results, db.Pagination{}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the matches of each build", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"build": {
+								"id": 4,
+								"name": "2",
+								"job_name": "some-job",
+								"status": "failed",
+								"api_url": "/api/v1/builds/4",
+								"pipeline_name": "some-pipeline",
+								"team_name": "some-team",
+								"start_time": 1,
+								"end_time": 100
+							},
+							"matches": [
+								{
+									"origin": {"id": "some-step", "source": "stderr"},
+									"line": 3,
+									"text": "connection reset by peer",
+									"before": ["dialing"],
+									"after": ["retrying"]
+								}
+							]
+						}
+					]`))
+				})
+
+				Context("when next/previous pages are available", func() {
+					BeforeEach(func() {
+						dbTeam.SearchBuildLogsReturns(results, db.Pagination{
+							Previous: &db.Page{Until: 4, Limit: 2},
+							Next:     &db.Page{Since: 4, Limit: 2},
+						}, nil)
+					})
+
+					It("returns Link headers which keep the search", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/teams/some-team/build_logs?limit=2&q=connection+reset&until=4>; rel="previous"`, externalURL),
+							fmt.Sprintf(`<%s/api/v1/teams/some-team/build_logs?limit=2&q=connection+reset&since=4>; rel="next"`, externalURL),
+						}))
+					})
+				})
+			})
+
+			Context("when searching fails", func() {
+				BeforeEach(func() {
+					dbTeam.SearchBuildLogsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/build_logs", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/build_logs?q=reset")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakePipeline.SearchBuildLogsReturns(results, db.Pagination{}, nil)
+			})
+
+			It("searches every job of the pipeline", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(1))
+
+				search, _ := fakePipeline.SearchBuildLogsArgsForCall(0)
+				Expect(search.JobIDs).To(BeNil())
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+
+					publicJob := new(dbfakes.FakeJob)
+					publicJob.IDReturns(1)
+					publicJob.ConfigReturns(atc.JobConfig{Public: true})
+
+					privateJob := new(dbfakes.FakeJob)
+					privateJob.IDReturns(2)
+
+					fakePipeline.JobsReturns(db.Jobs{publicJob, privateJob}, nil)
+				})
+
+				It("only searches the public jobs", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(1))
+
+					search, _ := fakePipeline.SearchBuildLogsArgsForCall(0)
+					Expect(search.JobIDs).To(Equal([]int{1}))
+				})
+
+				Context("when getting the jobs fails", func() {
+					BeforeEach(func() {
+						fakePipeline.JobsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/build_logs", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/build_logs?q=reset")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeJob.SearchBuildLogsReturns(results, db.Pagination{}, nil)
+			})
+
+			It("searches the job", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+				Expect(fakeJob.SearchBuildLogsCallCount()).To(Equal(1))
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized and the pipeline is public", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+				fakePipeline.PublicReturns(true)
+			})
+
+			Context("and the job is public", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{Public: true})
+				})
+
+				It("searches the job", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeJob.SearchBuildLogsCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("and the job is private", func() {
+				Context("when authenticated", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthenticatedReturns(true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(fakeJob.SearchBuildLogsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when not authenticated", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthenticatedReturns(false)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						Expect(fakeJob.SearchBuildLogsCallCount()).To(Equal(0))
+					})
+				})
+			})
+		})
+	})
+})
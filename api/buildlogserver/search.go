@@ -0,0 +1,232 @@
+package buildlogserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	queryParam   = "q"
+	contextParam = "context"
+
+	defaultContext = 2
+	maxContext     = 10
+)
+
+func (s *Server) SearchTeamBuildLogs(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("search-team-build-logs")
+
+		search, page, err := parseSearch(r)
+		if err != nil {
+			logger.Info("invalid-search", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		results, pagination, err := team.SearchBuildLogs(search, page)
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.respond(logger, w, r, results, pagination)
+	})
+}
+
+func (s *Server) SearchPipelineBuildLogs(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("search-pipeline-build-logs")
+
+		search, page, err := parseSearch(r)
+		if err != nil {
+			logger.Info("invalid-search", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		// only the builds of public jobs can be read from a public pipeline
+		if !acc.IsAuthorized(pipeline.TeamName()) {
+			jobs, err := pipeline.Jobs()
+			if err != nil {
+				logger.Error("failed-to-get-jobs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			search.JobIDs = []int{}
+			for _, job := range jobs {
+				if job.Config().Public {
+					search.JobIDs = append(search.JobIDs, job.ID())
+				}
+			}
+		}
+
+		results, pagination, err := pipeline.SearchBuildLogs(search, page)
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.respond(logger, w, r, results, pagination)
+	})
+}
+
+func (s *Server) SearchJobBuildLogs(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("search-job-build-logs")
+
+		jobName := r.FormValue(":job_name")
+
+		search, page, err := parseSearch(r)
+		if err != nil {
+			logger.Info("invalid-search", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		if !acc.IsAuthorized(pipeline.TeamName()) && !job.Config().Public {
+			if acc.IsAuthenticated() {
+				s.rejector.Forbidden(w, r)
+				return
+			}
+
+			s.rejector.Unauthorized(w, r)
+			return
+		}
+
+		results, pagination, err := job.SearchBuildLogs(search, page)
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.respond(logger, w, r, results, pagination)
+	})
+}
+
+func parseSearch(r *http.Request) (db.BuildLogSearch, db.Page, error) {
+	search := db.BuildLogSearch{
+		Query:   strings.TrimSpace(r.FormValue(queryParam)),
+		Context: defaultContext,
+	}
+
+	if search.Query == "" {
+		return db.BuildLogSearch{}, db.Page{}, errors.New("missing search query")
+	}
+
+	if urlFrom := r.FormValue(atc.PaginationQueryFrom); urlFrom != "" {
+		from, err := strconv.ParseInt(urlFrom, 10, 64)
+		if err != nil {
+			return db.BuildLogSearch{}, db.Page{}, fmt.Errorf("invalid from: %s", err)
+		}
+
+		search.From = time.Unix(from, 0)
+	}
+
+	if urlTo := r.FormValue(atc.PaginationQueryTo); urlTo != "" {
+		to, err := strconv.ParseInt(urlTo, 10, 64)
+		if err != nil {
+			return db.BuildLogSearch{}, db.Page{}, fmt.Errorf("invalid to: %s", err)
+		}
+
+		search.To = time.Unix(to, 0)
+	}
+
+	if urlContext := r.FormValue(contextParam); urlContext != "" {
+		context, err := strconv.Atoi(urlContext)
+		if err != nil || context < 0 || context > maxContext {
+			return db.BuildLogSearch{}, db.Page{}, fmt.Errorf("context must be between 0 and %d", maxContext)
+		}
+
+		search.Context = context
+	}
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	return search, db.Page{Until: until, Since: since, Limit: limit}, nil
+}
+
+func (s *Server) respond(logger lager.Logger, w http.ResponseWriter, r *http.Request, results []db.BuildLogSearchResult, pagination db.Pagination) {
+	if pagination.Next != nil {
+		s.addLink(w, r, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addLink(w, r, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+	}
+
+	presented := make([]atc.BuildLogSearchResult, len(results))
+	for i, result := range results {
+		presented[i] = present.BuildLogSearchResult(result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-results", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// addLink keeps the search parameters of the request, replacing only the
+// page boundary.
+func (s *Server) addLink(w http.ResponseWriter, r *http.Request, boundary string, id int, limit int, rel string) {
+	query := r.URL.Query()
+	for param := range query {
+		if strings.HasPrefix(param, ":") {
+			query.Del(param)
+		}
+	}
+
+	query.Del(atc.PaginationQuerySince)
+	query.Del(atc.PaginationQueryUntil)
+	query.Set(boundary, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s%s?%s>; rel="%s"`,
+		s.externalURL,
+		r.URL.Path,
+		query.Encode(),
+		rel,
+	))
+}
@@ -0,0 +1,23 @@
+package buildlogserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/auth"
+)
+
+type Server struct {
+	logger      lager.Logger
+	rejector    auth.Rejector
+	externalURL string
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+) *Server {
+	return &Server{
+		logger:      logger,
+		rejector:    auth.UnauthorizedRejector{},
+		externalURL: externalURL,
+	}
+}
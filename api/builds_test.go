@@ -27,6 +27,7 @@ var _ = Describe("Builds API", func() {
 
 	BeforeEach(func() {
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 	})
 
 	JustBeforeEach(func() {
@@ -39,6 +40,7 @@ var _ = Describe("Builds API", func() {
 
 		BeforeEach(func() {
 			fakeaccess = new(accessorfakes.FakeAccess)
+			fakeaccess.HasRoleReturns(true)
 			plan = atc.Plan{
 				Task: &atc.TaskPlan{
 					Config: &atc.TaskConfig{
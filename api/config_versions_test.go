@@ -0,0 +1,348 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config Versions API", func() {
+	var (
+		requestGenerator *rata.RequestGenerator
+		fakeaccess       *accessorfakes.FakeAccess
+
+		oldConfig     atc.Config
+		currentConfig atc.Config
+
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		requestGenerator = rata.NewRequestGenerator(server.URL, atc.Routes)
+
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
+		fakeaccess.UserNameReturns("some-user")
+
+		oldConfig = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		}
+
+		currentConfig = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job", Public: true},
+			},
+		}
+
+		fakePipeline.NameReturns("a-pipeline")
+		fakePipeline.ConfigVersionReturns(2)
+
+		fakePipeline.ConfigVersionsReturns([]db.PipelineConfig{
+			{Version: 2, Config: currentConfig, Author: "some-user", CreatedAt: time.Unix(200, 0)},
+			{Version: 1, Config: oldConfig, Author: "some-other-user", CreatedAt: time.Unix(100, 0)},
+		}, nil)
+
+		fakePipeline.ConfigAtVersionStub = func(version db.ConfigVersion) (db.PipelineConfig, bool, error) {
+			switch version {
+			case 1:
+				return db.PipelineConfig{Version: 1, Config: oldConfig, Author: "some-other-user", CreatedAt: time.Unix(100, 0)}, true, nil
+			case 2:
+				return db.PipelineConfig{Version: 2, Config: currentConfig, Author: "some-user", CreatedAt: time.Unix(200, 0)}, true, nil
+			default:
+				return db.PipelineConfig{}, false, nil
+			}
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	do := func(route string, params rata.Params, configVersion string) {
+		req, err := requestGenerator.CreateRequest(route, params, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		if configVersion != "" {
+			req.Header.Set(atc.ConfigVersionHeader, configVersion)
+		}
+
+		response, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", func() {
+		JustBeforeEach(func() {
+			do(atc.ListConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, "")
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			It("looks up the pipeline in the team", func() {
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+				Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("a-pipeline"))
+			})
+
+			It("returns every version without its config", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"version": 2, "author": "some-user", "created_at": 200},
+					{"version": 1, "author": "some-other-user", "created_at": 100}
+				]`))
+			})
+
+			Context("when the pipeline is not found", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when listing the versions fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigVersionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", func() {
+		var configVersion string
+
+		BeforeEach(func() {
+			configVersion = "1"
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			do(atc.GetConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": configVersion,
+			}, "")
+		})
+
+		It("returns the version with its config", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(`{
+				"version": 1,
+				"author": "some-other-user",
+				"created_at": 100,
+				"config": {
+					"groups": null,
+					"resources": null,
+					"resource_types": null,
+					"jobs": [{"name": "some-job"}]
+				}
+			}`))
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				configVersion = "3"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the version is not a number", func() {
+			BeforeEach(func() {
+				configVersion = "nope"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/diff", func() {
+		var from string
+
+		BeforeEach(func() {
+			from = ""
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.DiffConfigVersions, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": "1",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if from != "" {
+				req.URL.RawQuery = "from=" + from
+			}
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("diffs the current version against the requested one", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(`[
+				{
+					"section": "jobs",
+					"name": "some-job",
+					"type": "changed",
+					"before": {"name": "some-job", "public": true},
+					"after": {"name": "some-job"}
+				}
+			]`))
+		})
+
+		Context("when diffing against the same version", func() {
+			BeforeEach(func() {
+				from = "1"
+			})
+
+			It("returns no changes", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[]`))
+			})
+		})
+
+		Context("when the version to diff from does not exist", func() {
+			BeforeEach(func() {
+				from = "3"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/restore", func() {
+		BeforeEach(func() {
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			do(atc.RestoreConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": "1",
+			}, "2")
+		})
+
+		It("returns 200", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("saves the old config as the newest version, checking the current version", func() {
+			Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+			name, config, from, pausedState, author := dbTeam.SavePipelineArgsForCall(0)
+			Expect(name).To(Equal("a-pipeline"))
+			Expect(config).To(Equal(oldConfig))
+			Expect(from).To(Equal(db.ConfigVersion(2)))
+			Expect(pausedState).To(Equal(db.PipelineNoChange))
+			Expect(author).To(Equal("some-user"))
+		})
+
+		Context("when the old config is no longer valid", func() {
+			BeforeEach(func() {
+				oldConfig = atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "some-job"},
+						{Name: "some-job"},
+					},
+				}
+			})
+
+			It("returns 400 with the errors", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(ContainSubstring("errors"))
+			})
+
+			It("does not save it", func() {
+				Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the pipeline has changed since", func() {
+			BeforeEach(func() {
+				dbTeam.SavePipelineReturns(nil, false, db.ErrConfigComparisonFailed)
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not a member of the team", func() {
+			BeforeEach(func() {
+				fakeaccess.HasRoleReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not save anything", func() {
+				Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+			})
+		})
+	})
+})
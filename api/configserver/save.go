@@ -1,7 +1,6 @@
 package configserver
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,12 +9,11 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/http"
-	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
-	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
 )
@@ -24,26 +22,10 @@ var (
 	ErrStatusUnsupportedMediaType = errors.New("content-type is not supported")
 	ErrCannotParseContentType     = errors.New("content-type header could not be parsed")
 	ErrMalformedRequestPayload    = errors.New("data in body could not be decoded")
-	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
 )
 
-type ExtraKeysError struct {
-	extraKeys []string
-}
-
-func (eke ExtraKeysError) Error() string {
-	msg := &bytes.Buffer{}
-
-	fmt.Fprintln(msg, "unknown/extra keys:")
-	for _, unusedKey := range eke.extraKeys {
-		fmt.Fprintf(msg, "  - %s\n", unusedKey)
-	}
-
-	return msg.String()
-}
-
 type SaveConfigResponse struct {
 	Errors   []string      `json:"errors,omitempty"`
 	Warnings []atc.Warning `json:"warnings,omitempty"`
@@ -52,14 +34,11 @@ type SaveConfigResponse struct {
 func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	session := s.logger.Session("set-config")
 
-	var version db.ConfigVersion
-	if configVersionStr := r.Header.Get(atc.ConfigVersionHeader); len(configVersionStr) != 0 {
-		_, err := fmt.Sscanf(configVersionStr, "%d", &version)
-		if err != nil {
-			session.Error("malformed-config-version", err)
-			s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
-			return
-		}
+	version, err := configVersionFromHeader(r)
+	if err != nil {
+		session.Error("malformed-config-version", err)
+		s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+		return
 	}
 
 	config, pausedState, err := saveConfigRequestUnmarshaler(r)
@@ -74,10 +53,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		s.handleBadRequest(w, []string{"malformed config"}, session)
 		return
-	case ErrFailedToConstructDecoder:
-		session.Error("failed-to-construct-decoder", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
 	case ErrCouldNotDecode:
 		session.Error("could-not-decode", err)
 		s.handleBadRequest(w, []string{"failed to decode config"}, session)
@@ -88,7 +63,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	default:
 		if err != nil {
-			if eke, ok := err.(ExtraKeysError); ok {
+			if eke, ok := err.(atc.ExtraKeysError); ok {
 				s.handleBadRequest(w, []string{eke.Error()}, session)
 			} else {
 				session.Error("unexpected-error", err)
@@ -124,7 +99,9 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	acc := accessor.GetAccessor(r)
+
+	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState, acc.UserName())
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -143,6 +120,18 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+func configVersionFromHeader(r *http.Request) (db.ConfigVersion, error) {
+	var version db.ConfigVersion
+	if configVersionStr := r.Header.Get(atc.ConfigVersionHeader); len(configVersionStr) != 0 {
+		_, err := fmt.Sscanf(configVersionStr, "%d", &version)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return version, nil
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, SaveConfigResponse{
@@ -241,36 +230,13 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 		return atc.Config{}, db.PipelineNoChange, err
 	}
 
-	var config atc.Config
-	var md mapstructure.Metadata
-	msConfig := &mapstructure.DecoderConfig{
-		Metadata:         &md,
-		Result:           &config,
-		WeaklyTypedInput: true,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			atc.SanitizeDecodeHook,
-			atc.VersionConfigDecodeHook,
-		),
-	}
-
-	decoder, err := mapstructure.NewDecoder(msConfig)
+	config, err := atc.DecodeConfig(configStructure)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
-	}
-
-	if err := decoder.Decode(configStructure); err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
-	}
-
-	nestedUnused := []string{}
-	for _, unused := range md.Unused {
-		if strings.Contains(unused, ".") {
-			nestedUnused = append(nestedUnused, unused)
+		if eke, ok := err.(atc.ExtraKeysError); ok {
+			return atc.Config{}, db.PipelineNoChange, eke
 		}
-	}
 
-	if len(nestedUnused) != 0 {
-		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: nestedUnused}
+		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
 	}
 
 	return config, pausedState, nil
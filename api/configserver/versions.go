@@ -0,0 +1,211 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-versions")
+
+	_, pipeline, found := s.findPipeline(w, r, logger)
+	if !found {
+		return
+	}
+
+	configs, err := pipeline.ConfigVersions()
+	if err != nil {
+		logger.Error("failed-to-get-config-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := make([]atc.PipelineConfigVersion, len(configs))
+	for i, config := range configs {
+		presented[i] = present.PipelineConfigVersion(config)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-config-versions", err)
+	}
+}
+
+func (s *Server) GetConfigVersion(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-version")
+
+	_, pipeline, found := s.findPipeline(w, r, logger)
+	if !found {
+		return
+	}
+
+	config, found := s.findConfigVersion(w, pipeline, rata.Param(r, "config_version"), logger)
+	if !found {
+		return
+	}
+
+	presented := present.PipelineConfigVersion(config)
+	presented.Config = &config.Config
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-config-version", err)
+	}
+}
+
+// DiffConfigVersions lists the changes going from the version given by the
+// 'from' query parameter to the requested version. When 'from' is omitted it
+// defaults to the pipeline's current version, describing what a restore
+// would change.
+func (s *Server) DiffConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("diff-config-versions")
+
+	_, pipeline, found := s.findPipeline(w, r, logger)
+	if !found {
+		return
+	}
+
+	to, found := s.findConfigVersion(w, pipeline, rata.Param(r, "config_version"), logger)
+	if !found {
+		return
+	}
+
+	fromVersion := r.URL.Query().Get("from")
+	if fromVersion == "" {
+		fromVersion = strconv.Itoa(int(pipeline.ConfigVersion()))
+	}
+
+	from, found := s.findConfigVersion(w, pipeline, fromVersion, logger)
+	if !found {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(atc.DiffConfigs(from.Config, to.Config))
+	if err != nil {
+		logger.Error("failed-to-encode-config-diff", err)
+	}
+}
+
+// RestoreConfigVersion saves an earlier version of the pipeline's config as
+// its newest version. Like SaveConfig, the current version must be given in
+// the config version header, and the config must still be valid.
+func (s *Server) RestoreConfigVersion(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("restore-config-version")
+
+	version, err := configVersionFromHeader(r)
+	if err != nil {
+		session.Error("malformed-config-version", err)
+		s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+		return
+	}
+
+	team, pipeline, found := s.findPipeline(w, r, session)
+	if !found {
+		return
+	}
+
+	config, found := s.findConfigVersion(w, pipeline, rata.Param(r, "config_version"), session)
+	if !found {
+		return
+	}
+
+	warnings, errorMessages := config.Config.Validate()
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config")
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	session.Info("restoring", lager.Data{"version": config.Version})
+
+	acc := accessor.GetAccessor(r)
+
+	_, _, err = team.SavePipeline(pipeline.Name(), config.Config, version, db.PipelineNoChange, acc.UserName())
+	if err != nil {
+		session.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
+	session.Info("restored")
+
+	w.WriteHeader(http.StatusOK)
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+}
+
+func (s *Server) findPipeline(w http.ResponseWriter, r *http.Request, logger lager.Logger) (db.Team, db.Pipeline, bool) {
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, nil, false
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, nil, false
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	return team, pipeline, true
+}
+
+func (s *Server) findConfigVersion(w http.ResponseWriter, pipeline db.Pipeline, versionStr string, logger lager.Logger) (db.PipelineConfig, bool) {
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		logger.Debug("malformed-config-version", lager.Data{"version": versionStr})
+		w.WriteHeader(http.StatusBadRequest)
+		return db.PipelineConfig{}, false
+	}
+
+	config, found, err := pipeline.ConfigAtVersion(db.ConfigVersion(version))
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return db.PipelineConfig{}, false
+	}
+
+	if !found {
+		logger.Debug("config-version-not-found", lager.Data{"version": version})
+		w.WriteHeader(http.StatusNotFound)
+		return db.PipelineConfig{}, false
+	}
+
+	return config, true
+}
@@ -43,6 +43,7 @@ var _ = Describe("Containers API", func() {
 
 	BeforeEach(func() {
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 		fakeContainer1 = new(dbfakes.FakeContainer)
 		fakeContainer1.HandleReturns("some-handle")
 		fakeContainer1.WorkerNameReturns("some-worker-name")
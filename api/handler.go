@@ -9,6 +9,8 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auditserver"
+	"github.com/concourse/atc/api/buildlogserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
 	"github.com/concourse/atc/api/configserver"
@@ -21,6 +23,7 @@ import (
 	"github.com/concourse/atc/api/resourceserver"
 	"github.com/concourse/atc/api/resourceserver/versionserver"
 	"github.com/concourse/atc/api/teamserver"
+	"github.com/concourse/atc/api/varserver"
 	"github.com/concourse/atc/api/volumeserver"
 	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/creds"
@@ -47,6 +50,7 @@ func NewHandler(
 	volumeFactory db.VolumeFactory,
 	containerRepository db.ContainerRepository,
 	dbBuildFactory db.BuildFactory,
+	dbAuditEventFactory db.AuditEventFactory,
 
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
@@ -70,6 +74,7 @@ func NewHandler(
 	workerVersion string,
 	variablesFactory creds.VariablesFactory,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
+	workerDrainTimeout time.Duration,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -87,19 +92,27 @@ func NewHandler(
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL, engine)
 	configServer := configserver.NewServer(logger, dbTeamFactory)
-	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, workerProvider)
+	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, dbBuildFactory, workerProvider, workerDrainTimeout)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 	containerServer := containerserver.NewServer(logger, workerClient, variablesFactory, interceptTimeoutFactory)
 	volumesServer := volumeserver.NewServer(logger, volumeFactory)
 	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	varServer := varserver.NewServer(logger)
 	infoServer := infoserver.NewServer(logger, version, workerVersion)
 	legacyServer := legacyserver.NewServer(logger)
+	auditServer := auditserver.NewServer(logger, externalURL, dbAuditEventFactory)
+	buildLogServer := buildlogserver.NewServer(logger, externalURL)
 
 	handlers := map[string]http.Handler{
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
 		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
 
+		atc.ListConfigVersions:   http.HandlerFunc(configServer.ListConfigVersions),
+		atc.GetConfigVersion:     http.HandlerFunc(configServer.GetConfigVersion),
+		atc.DiffConfigVersions:   http.HandlerFunc(configServer.DiffConfigVersions),
+		atc.RestoreConfigVersion: http.HandlerFunc(configServer.RestoreConfigVersion),
+
 		atc.ListBuilds:              http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:             teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
 		atc.GetBuild:                buildHandlerFactory.HandlerFor(buildServer.GetBuild),
@@ -123,6 +136,8 @@ func NewHandler(
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
 
+		atc.ExplainJobScheduling: pipelineHandlerFactory.HandlerFor(jobServer.ExplainJobScheduling),
+
 		atc.ListAllPipelines:    http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:       http.HandlerFunc(pipelineServer.ListPipelines),
 		atc.GetPipeline:         pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
@@ -150,17 +165,20 @@ func NewHandler(
 		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
+		atc.UnpinResource:                 pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
 
-		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
-		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
-		atc.LandWorker:      http.HandlerFunc(workerServer.LandWorker),
-		atc.RetireWorker:    http.HandlerFunc(workerServer.RetireWorker),
-		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
-		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
-		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
+		atc.ListWorkers:          http.HandlerFunc(workerServer.ListWorkers),
+		atc.RegisterWorker:       http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:           http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:         http.HandlerFunc(workerServer.RetireWorker),
+		atc.GetWorkerDrainStatus: http.HandlerFunc(workerServer.GetWorkerDrainStatus),
+		atc.PruneWorker:          http.HandlerFunc(workerServer.PruneWorker),
+		atc.HeartbeatWorker:      http.HandlerFunc(workerServer.HeartbeatWorker),
+		atc.DeleteWorker:         http.HandlerFunc(workerServer.DeleteWorker),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -183,6 +201,16 @@ func NewHandler(
 		atc.RenameTeam:     http.HandlerFunc(teamServer.RenameTeam),
 		atc.DestroyTeam:    http.HandlerFunc(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: http.HandlerFunc(teamServer.ListTeamBuilds),
+
+		atc.SearchTeamBuildLogs:     teamHandlerFactory.HandlerFor(buildLogServer.SearchTeamBuildLogs),
+		atc.SearchPipelineBuildLogs: pipelineHandlerFactory.HandlerFor(buildLogServer.SearchPipelineBuildLogs),
+		atc.SearchJobBuildLogs:      pipelineHandlerFactory.HandlerFor(buildLogServer.SearchJobBuildLogs),
+
+		atc.ListTeamVars:  teamHandlerFactory.HandlerFor(varServer.ListVars),
+		atc.SetTeamVar:    teamHandlerFactory.HandlerFor(varServer.SetVar),
+		atc.DeleteTeamVar: teamHandlerFactory.HandlerFor(varServer.DeleteVar),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
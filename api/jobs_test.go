@@ -30,6 +30,7 @@ var _ = Describe("Jobs API", func() {
 	BeforeEach(func() {
 		fakeJob = new(dbfakes.FakeJob)
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 		fakePipeline = new(dbfakes.FakePipeline)
 		dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
 		dbTeam.PipelineReturns(fakePipeline, true, nil)
@@ -1734,6 +1735,157 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling_explanation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/scheduling_explanation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when it contains the requested job", func() {
+				var (
+					fakeJob       *dbfakes.FakeJob
+					fakeScheduler *schedulerfakes.FakeBuildScheduler
+				)
+
+				BeforeEach(func() {
+					fakeJob = new(dbfakes.FakeJob)
+					fakeJob.NameReturns("some-job")
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+				})
+
+				Context("when the scheduling can be explained", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainSchedulingReturns(atc.JobSchedulingExplanation{
+							PausedPipeline:   atc.BuildPreparationStatusNotBlocking,
+							PausedJob:        atc.BuildPreparationStatusBlocking,
+							MaxRunningBuilds: atc.BuildPreparationStatusNotBlocking,
+							SerialGroups:     atc.BuildPreparationStatusNotBlocking,
+							InputsSatisfied:  atc.BuildPreparationStatusBlocking,
+							Inputs: []atc.InputExplanation{
+								{
+									Name:     "some-input",
+									Resource: "some-resource",
+									Passed:   []string{"job-a"},
+									Status:   atc.BuildPreparationStatusBlocking,
+									Reason:   "no versions satisfy passed constraints",
+									Versions: []atc.VersionExplanation{
+										{
+											ID:         1,
+											Version:    atc.Version{"some": "version"},
+											Considered: true,
+											NotPassed:  []string{"job-a"},
+										},
+									},
+								},
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns Content-Type 'application/json'", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					})
+
+					It("explained the scheduling of the job with the pipeline's scheduler", func() {
+						actualPipeline, actualExternalURL, actualVariables := fakeSchedulerFactory.BuildSchedulerArgsForCall(0)
+						Expect(actualPipeline.Name()).To(Equal(fakePipeline.Name()))
+						Expect(actualExternalURL).To(Equal(externalURL))
+						Expect(actualVariables).To(Equal(variables))
+
+						_, actualJob := fakeScheduler.ExplainSchedulingArgsForCall(0)
+						Expect(actualJob.Name()).To(Equal("some-job"))
+					})
+
+					It("returns the explanation", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"paused_pipeline": "not_blocking",
+							"paused_job": "blocking",
+							"max_running_builds": "not_blocking",
+							"serial_groups": "not_blocking",
+							"inputs_satisfied": "blocking",
+							"inputs": [
+								{
+									"name": "some-input",
+									"resource": "some-resource",
+									"passed": ["job-a"],
+									"status": "blocking",
+									"reason": "no versions satisfy passed constraints",
+									"versions": [
+										{
+											"id": 1,
+											"version": {"some": "version"},
+											"considered": true,
+											"not_passed": ["job-a"]
+										}
+									]
+								}
+							]
+						}`))
+					})
+				})
+
+				Context("when explaining the scheduling fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainSchedulingReturns(atc.JobSchedulingExplanation{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when it does not contain the requested job", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the job fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -0,0 +1,47 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ExplainJobScheduling(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("explain-job-scheduling")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		variables := s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name())
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, variables)
+
+		explanation, err := scheduler.ExplainScheduling(logger, job)
+		if err != nil {
+			logger.Error("failed-to-explain-scheduling", err, lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(explanation)
+		if err != nil {
+			logger.Error("failed-to-encode-scheduling-explanation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
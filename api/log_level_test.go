@@ -23,6 +23,7 @@ var _ = Describe("Log Level API", func() {
 
 		BeforeEach(func() {
 			fakeaccess = new(accessorfakes.FakeAccess)
+			fakeaccess.HasRoleReturns(true)
 			logLevelPayload = ""
 		})
 
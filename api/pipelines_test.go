@@ -16,6 +16,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
@@ -37,6 +38,7 @@ var _ = Describe("Pipelines API", func() {
 		dbPipeline = new(dbfakes.FakePipeline)
 		fakeTeam = new(dbfakes.FakeTeam)
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 		publicPipeline = new(dbfakes.FakePipeline)
 
 		publicPipeline.IDReturns(1)
@@ -809,6 +811,26 @@ var _ = Describe("Pipelines API", func() {
 					Expect(dbPipeline.DestroyCallCount()).To(Equal(1))
 				})
 
+				It("requires the member role on the team", func() {
+					teamName, role := fakeaccess.HasRoleArgsForCall(0)
+					Expect(teamName).To(Equal("a-team"))
+					Expect(role).To(Equal(accessor.MemberRole))
+				})
+
+				Context("when the requester's role is too low", func() {
+					BeforeEach(func() {
+						fakeaccess.HasRoleReturns(false)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+
+					It("does not delete the pipeline", func() {
+						Expect(dbPipeline.DestroyCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when an error occurs destroying the pipeline", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
@@ -889,6 +911,36 @@ var _ = Describe("Pipelines API", func() {
 					})
 				})
 
+				Context("when the requester is a pipeline operator", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						fakeaccess.HasRoleStub = func(teamName string, role accessor.Role) bool {
+							return role <= accessor.PipelineOperatorRole
+						}
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when the requester is a viewer", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						fakeaccess.HasRoleStub = func(teamName string, role accessor.Role) bool {
+							return role <= accessor.ViewerRole
+						}
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+
+					It("does not pause the pipeline", func() {
+						Expect(dbPipeline.PauseCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when pausing the pipeline fails", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:       event.ID,
+		Time:     event.Time.Unix(),
+		UserName: event.UserName,
+		TeamName: event.TeamName,
+		Route:    event.Route,
+		Target:   event.Target,
+		Status:   event.Status,
+	}
+}
@@ -0,0 +1,27 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildLogSearchResult(result db.BuildLogSearchResult) atc.BuildLogSearchResult {
+	matches := make([]atc.BuildLogMatch, len(result.Matches))
+	for i, match := range result.Matches {
+		matches[i] = atc.BuildLogMatch{
+			Origin: atc.BuildLogOrigin{
+				ID:     string(match.Origin.ID),
+				Source: string(match.Origin.Source),
+			},
+			Line:   match.Line,
+			Text:   match.Text,
+			Before: match.Before,
+			After:  match.After,
+		}
+	}
+
+	return atc.BuildLogSearchResult{
+		Build:   Build(result.Build),
+		Matches: matches,
+	}
+}
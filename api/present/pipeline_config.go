@@ -0,0 +1,14 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func PipelineConfigVersion(config db.PipelineConfig) atc.PipelineConfigVersion {
+	return atc.PipelineConfigVersion{
+		Version:   int(config.Version),
+		Author:    config.Author,
+		CreatedAt: config.CreatedAt.Unix(),
+	}
+}
@@ -31,6 +31,10 @@ func Resource(resource db.Resource, groups atc.GroupConfigs, showCheckError bool
 
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
+
+		PinnedVersion: resource.PinnedVersion(),
+		PinnedBy:      resource.PinnedBy(),
+		PinComment:    resource.PinComment(),
 	}
 
 	if !resource.LastChecked().IsZero() {
@@ -6,8 +6,15 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	atcTeam := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 	}
+
+	if team.MaxContainers() != 0 {
+		maxContainers := team.MaxContainers()
+		atcTeam.MaxContainers = &maxContainers
+	}
+
+	return atcTeam
 }
@@ -20,19 +20,22 @@ func Worker(workerInfo db.Worker) atc.Worker {
 	}
 
 	return atc.Worker{
-		GardenAddr:       gardenAddr,
-		BaggageclaimURL:  baggageclaimURL,
-		HTTPProxyURL:     workerInfo.HTTPProxyURL(),
-		HTTPSProxyURL:    workerInfo.HTTPSProxyURL(),
-		NoProxy:          workerInfo.NoProxy(),
-		ActiveContainers: workerInfo.ActiveContainers(),
-		ResourceTypes:    workerInfo.ResourceTypes(),
-		Platform:         workerInfo.Platform(),
-		Tags:             workerInfo.Tags(),
-		Name:             workerInfo.Name(),
-		Team:             workerInfo.TeamName(),
-		State:            string(workerInfo.State()),
-		StartTime:        workerInfo.StartTime(),
-		Version:          version,
+		GardenAddr:         gardenAddr,
+		BaggageclaimURL:    baggageclaimURL,
+		HTTPProxyURL:       workerInfo.HTTPProxyURL(),
+		HTTPSProxyURL:      workerInfo.HTTPSProxyURL(),
+		NoProxy:            workerInfo.NoProxy(),
+		ActiveContainers:   workerInfo.ActiveContainers(),
+		ActiveVolumes:      workerInfo.ActiveVolumes(),
+		MaxBuildContainers: workerInfo.MaxBuildContainers(),
+		ResourceTypes:      workerInfo.ResourceTypes(),
+		Platform:           workerInfo.Platform(),
+		Tags:               workerInfo.Tags(),
+		Labels:             workerInfo.Labels(),
+		Name:               workerInfo.Name(),
+		Team:               workerInfo.TeamName(),
+		State:              string(workerInfo.State()),
+		StartTime:          workerInfo.StartTime(),
+		Version:            version,
 	}
 }
@@ -28,6 +28,8 @@ var _ = Describe("Resources API", func() {
 	)
 
 	BeforeEach(func() {
+		fakeaccess.HasRoleReturns(true)
+
 		fakePipeline = new(dbfakes.FakePipeline)
 		dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
 		dbTeam.PipelineReturns(fakePipeline, true, nil)
@@ -546,6 +548,40 @@ var _ = Describe("Resources API", func() {
 							}`))
 				})
 			})
+
+			Context("when the resource is pinned to a version", func() {
+				BeforeEach(func() {
+					resource1 := new(dbfakes.FakeResource)
+					resource1.PipelineNameReturns("a-pipeline")
+					resource1.NameReturns("resource-1")
+					resource1.TypeReturns("type-1")
+					resource1.LastCheckedReturns(time.Unix(1513364881, 0))
+					resource1.PinnedVersionIDReturns(42)
+					resource1.PinnedVersionReturns(atc.Version{"version": "v1"})
+					resource1.PinnedByReturns("some-user")
+					resource1.PinCommentReturns("broken in prod")
+
+					fakePipeline.ResourceReturns(resource1, true, nil)
+				})
+
+				It("returns the resource json with the pinned version", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"groups": [],
+								"last_checked": 1513364881,
+								"pinned_version": {"version": "v1"},
+								"pinned_by": "some-user",
+								"pin_comment": "broken in prod"
+							}`))
+				})
+			})
 		})
 	})
 
@@ -711,6 +747,87 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("resource-name")
+
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				It("injects the proper pipelineDB", func() {
+					Expect(dbTeam.PipelineCallCount()).To(Equal(1))
+					pipelineName := dbTeam.PipelineArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+				})
+
+				Context("when unpinning the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource.UnpinVersionReturns(nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when resource can not be found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when unpinning the resource fails", func() {
+					BeforeEach(func() {
+						fakeResource.UnpinVersionReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Status Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var fakeScanner *radarfakes.FakeScanner
 		var checkRequestBody atc.CheckRequestBody
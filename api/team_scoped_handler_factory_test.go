@@ -32,6 +32,7 @@ var _ = Describe("TeamScopedHandlerFactory", func() {
 
 	BeforeEach(func() {
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
 		fakeTeam = new(dbfakes.FakeTeam)
 		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
@@ -41,6 +41,7 @@ var _ = Describe("Teams API", func() {
 	BeforeEach(func() {
 		fakeTeam = new(dbfakes.FakeTeam)
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 	})
 
 	JustBeforeEach(func() {
@@ -95,6 +96,7 @@ var _ = Describe("Teams API", func() {
 
 				fakeTeamThree.IDReturns(22)
 				fakeTeamThree.NameReturns("predators")
+				fakeTeamThree.MaxContainersReturns(50)
 				fakeTeamThree.AuthReturns(map[string]*json.RawMessage{
 					"fake-provider": fakeData(`{"hello": "world"}`),
 				})
@@ -121,7 +123,8 @@ var _ = Describe("Teams API", func() {
  					},
  					{
  						"id": 22,
- 						"name": "predators"
+ 						"name": "predators",
+ 						"max_containers": 50
  					}
  				]`))
 			})
@@ -356,6 +359,40 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team is found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("does not change its container quota", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateMaxContainersCallCount()).To(BeZero())
+				})
+
+				Context("when setting a container quota", func() {
+					BeforeEach(func() {
+						maxContainers := 10
+						atcTeam = atc.Team{MaxContainers: &maxContainers}
+					})
+
+					It("updates the team's container quota", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateMaxContainersCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateMaxContainersArgsForCall(0)).To(Equal(10))
+					})
+
+					Context("when updating the quota fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateMaxContainersReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -372,6 +409,24 @@ var _ = Describe("Teams API", func() {
 					}))
 				})
 
+				Context("with a container quota", func() {
+					BeforeEach(func() {
+						maxContainers := 10
+						atcTeam = atc.Team{MaxContainers: &maxContainers}
+					})
+
+					It("creates the team with the quota", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+						maxContainers := 10
+						createdTeam := dbTeamFactory.CreateTeamArgsForCall(0)
+						Expect(createdTeam).To(Equal(atc.Team{
+							Name:          "some-team",
+							MaxContainers: &maxContainers,
+						}))
+					})
+				})
+
 				Context("when it fails to create team", func() {
 					BeforeEach(func() {
 						dbTeamFactory.CreateTeamReturns(nil, errors.New("it is never going to happen"))
@@ -392,6 +447,24 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when setting a container quota", func() {
+				BeforeEach(func() {
+					maxContainers := 10
+					atcTeam = atc.Team{MaxContainers: &maxContainers}
+
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not update the team", func() {
+					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					Expect(fakeTeam.UpdateMaxContainersCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
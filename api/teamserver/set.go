@@ -34,6 +34,12 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if atcTeam.MaxContainers != nil && !acc.IsAdmin() {
+		hLog.Info("only-admins-can-set-container-quota")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	providers := provider.GetProviders()
 
 	for providerName, config := range atcTeam.Auth {
@@ -91,6 +97,15 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if atcTeam.MaxContainers != nil {
+			err = team.UpdateMaxContainers(*atcTeam.MaxContainers)
+			if err != nil {
+				hLog.Error("failed-to-update-team-container-quota", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
 		hLog.Debug("creating team")
@@ -0,0 +1,29 @@
+package varserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) DeleteVar(team db.Team) http.Handler {
+	logger := s.logger.Session("delete-var")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		varName := r.FormValue(":var_name")
+
+		deleted, err := team.DeleteVar(varName)
+		if err != nil {
+			logger.Error("failed-to-delete-var", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
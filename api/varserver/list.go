@@ -0,0 +1,36 @@
+package varserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// ListVars returns the names of the team's vars. Values are never returned.
+func (s *Server) ListVars(team db.Team) http.Handler {
+	logger := s.logger.Session("list-vars")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		names, err := team.VarNames()
+		if err != nil {
+			logger.Error("failed-to-list-vars", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		vars := []atc.Var{}
+		for _, name := range names {
+			vars = append(vars, atc.Var{Name: name})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(vars)
+		if err != nil {
+			logger.Error("failed-to-encode-vars", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package varserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) SetVar(team db.Team) http.Handler {
+	logger := s.logger.Session("set-var")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		varName := r.FormValue(":var_name")
+
+		var request atc.SetVarRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = team.SaveVar(varName, request.Value)
+		if err != nil {
+			logger.Error("failed-to-save-var", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -22,6 +23,7 @@ var _ = Describe("Versions API", func() {
 	BeforeEach(func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 		dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
 		dbTeam.PipelineReturns(fakePipeline, true, nil)
 	})
@@ -394,6 +396,158 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var (
+			response     *http.Response
+			requestBody  string
+			versionID    string
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"comment":"broken in prod"}`
+			versionID = "42"
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/"+versionID+"/pin", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated ", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.UserNameReturns("some-user")
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when pinning the version succeeds", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(true, nil)
+					})
+
+					It("looks up the right resource", func() {
+						Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					})
+
+					It("pins the right version with the user and comment", func() {
+						Expect(fakeResource.PinVersionCallCount()).To(Equal(1))
+
+						versionID, pinnedBy, comment := fakeResource.PinVersionArgsForCall(0)
+						Expect(versionID).To(Equal(42))
+						Expect(pinnedBy).To(Equal("some-user"))
+						Expect(comment).To(Equal("broken in prod"))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					Context("when no comment is given", func() {
+						BeforeEach(func() {
+							requestBody = ""
+						})
+
+						It("pins the version without a comment", func() {
+							_, _, comment := fakeResource.PinVersionArgsForCall(0)
+							Expect(comment).To(BeEmpty())
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+				})
+
+				Context("when the version does not belong to the resource", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the resource can not be found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the version id is not a number", func() {
+					BeforeEach(func() {
+						versionID = "nope"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not pin anything", func() {
+						Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						requestBody = "{"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when pinning the version fails", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(false, errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
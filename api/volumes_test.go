@@ -21,6 +21,7 @@ var _ = Describe("Volumes API", func() {
 
 	BeforeEach(func() {
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 		fakeWorker = new(dbfakes.FakeWorker)
 		fakeWorker.NameReturns("some-worker")
 	})
@@ -23,6 +23,7 @@ var _ = Describe("Workers API", func() {
 	)
 	BeforeEach(func() {
 		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.HasRoleReturns(true)
 	})
 	JustBeforeEach(func() {
 		fakeAccessor.CreateReturns(fakeaccess)
@@ -68,6 +69,7 @@ var _ = Describe("Workers API", func() {
 					teamWorker1.GardenAddrReturns(&gardenAddr1)
 					bcURL1 := "1.2.3.4:8888"
 					teamWorker1.BaggageclaimURLReturns(&bcURL1)
+					teamWorker1.LabelsReturns(map[string]string{"arch": "arm64"})
 
 					teamWorker2 = new(dbfakes.FakeWorker)
 					gardenAddr2 := "5.6.7.8:7777"
@@ -93,6 +95,7 @@ var _ = Describe("Workers API", func() {
 						{
 							GardenAddr:      "1.2.3.4:7777",
 							BaggageclaimURL: "1.2.3.4:8888",
+							Labels:          map[string]string{"arch": "arm64"},
 						},
 						{
 							GardenAddr:      "5.6.7.8:7777",
@@ -151,6 +154,7 @@ var _ = Describe("Workers API", func() {
 				},
 				Platform: "haiku",
 				Tags:     []string{"not", "a", "limerick"},
+				Labels:   map[string]string{"arch": "arm64"},
 				Version:  "1.2.3",
 			}
 
@@ -195,6 +199,7 @@ var _ = Describe("Workers API", func() {
 					},
 					Platform: "haiku",
 					Tags:     []string{"not", "a", "limerick"},
+					Labels:   map[string]string{"arch": "arm64"},
 					Version:  "1.2.3",
 				}))
 
@@ -439,6 +444,20 @@ var _ = Describe("Workers API", func() {
 					Expect(dbWorkerFactory.SaveWorkerCallCount()).To(BeZero())
 				})
 			})
+
+			Context("when a worker label is invalid", func() {
+				BeforeEach(func() {
+					worker.Labels = map[string]string{"zone": "us east"}
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not save it", func() {
+					Expect(dbWorkerFactory.SaveWorkerCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -556,6 +575,174 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/workers/:worker_name/drain", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbfakes.FakeWorker
+			drainStart time.Time
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/"+workerName+"/drain", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			workerName = "some-worker"
+			drainStart = time.Unix(1524600000, 0)
+
+			fakeWorker = new(dbfakes.FakeWorker)
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.StateReturns(db.WorkerStateLanding)
+			fakeWorker.DrainStartedAtReturns(drainStart)
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsSystemReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the worker is draining", func() {
+			BeforeEach(func() {
+				fakeBuild := new(dbfakes.FakeBuild)
+				fakeBuild.IDReturns(42)
+				fakeBuild.NameReturns("1")
+				fakeBuild.JobNameReturns("some-job")
+				fakeBuild.PipelineNameReturns("some-pipeline")
+				fakeBuild.TeamNameReturns("some-team")
+				fakeBuild.TeamIDReturns(1)
+				fakeBuild.StatusReturns(db.BuildStatusStarted)
+
+				dbBuildFactory.GetBuildsBlockingWorkerDrainReturns([]db.Build{fakeBuild}, nil)
+
+				localContainer := new(dbfakes.FakeContainer)
+				localContainer.HandleReturns("local-handle")
+				localContainer.WorkerNameReturns(workerName)
+				localContainer.MetadataReturns(db.ContainerMetadata{Type: db.ContainerTypeTask, BuildID: 42})
+
+				remoteContainer := new(dbfakes.FakeContainer)
+				remoteContainer.HandleReturns("remote-handle")
+				remoteContainer.WorkerNameReturns("other-worker")
+
+				dbTeam.FindContainersByMetadataReturns([]db.Container{localContainer, remoteContainer}, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("looks up the builds blocking the worker", func() {
+				Expect(dbBuildFactory.GetBuildsBlockingWorkerDrainCallCount()).To(Equal(1))
+				Expect(dbBuildFactory.GetBuildsBlockingWorkerDrainArgsForCall(0)).To(Equal(workerName))
+
+				Expect(dbTeamFactory.GetByIDArgsForCall(0)).To(Equal(1))
+				Expect(dbTeam.FindContainersByMetadataArgsForCall(0)).To(Equal(db.ContainerMetadata{BuildID: 42}))
+			})
+
+			It("returns the builds and the containers on the worker, with the deadline", func() {
+				var status atc.WorkerDrainStatus
+				err := json.NewDecoder(response.Body).Decode(&status)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(status.Name).To(Equal(workerName))
+				Expect(status.State).To(Equal("landing"))
+				Expect(status.StartedAt).To(Equal(drainStart.Unix()))
+				Expect(status.Deadline).To(Equal(drainStart.Add(time.Hour).Unix()))
+
+				Expect(status.Builds).To(HaveLen(1))
+				Expect(status.Builds[0].ID).To(Equal(42))
+
+				Expect(status.Containers).To(Equal([]atc.Container{
+					{
+						ID:         "local-handle",
+						WorkerName: workerName,
+						Type:       "task",
+						BuildID:    42,
+					},
+				}))
+			})
+
+			Context("when getting the builds fails", func() {
+				BeforeEach(func() {
+					dbBuildFactory.GetBuildsBlockingWorkerDrainReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the containers fails", func() {
+				BeforeEach(func() {
+					dbTeam.FindContainersByMetadataReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the worker is running", func() {
+			BeforeEach(func() {
+				fakeWorker.StateReturns(db.WorkerStateRunning)
+				fakeWorker.DrainStartedAtReturns(time.Time{})
+			})
+
+			It("returns its state with nothing blocking it", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"name": "some-worker",
+					"state": "running",
+					"builds": [],
+					"containers": []
+				}`))
+
+				Expect(dbBuildFactory.GetBuildsBlockingWorkerDrainCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			BeforeEach(func() {
+				dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the request is authorized as the wrong team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsSystemReturns(false)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+				fakeaccess.IsSystemReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/retire", func() {
 		var (
 			response   *http.Response
@@ -759,6 +946,7 @@ var _ = Describe("Workers API", func() {
 			workerName = "some-name"
 			fakeWorker.NameReturns(workerName)
 			fakeWorker.ActiveContainersReturns(2)
+			fakeWorker.ActiveVolumesReturns(3)
 			fakeWorker.PlatformReturns("penguin")
 			fakeWorker.TagsReturns([]string{"some-tag"})
 			fakeWorker.StateReturns(db.WorkerStateRunning)
@@ -802,7 +990,7 @@ var _ = Describe("Workers API", func() {
 				"baggageclaim_url": "",
 				"reaper_url": "",
 				"active_containers": 2,
-				"active_volumes": 0,
+				"active_volumes": 3,
 				"resource_types": null,
 				"platform": "penguin",
 				"tags": ["some-tag"],
@@ -0,0 +1,86 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) GetWorkerDrainStatus(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+	logger := s.logger.Session("get-worker-drain-status", lager.Data{"worker": workerName})
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	status := atc.WorkerDrainStatus{
+		Name:       worker.Name(),
+		State:      string(worker.State()),
+		Builds:     []atc.Build{},
+		Containers: []atc.Container{},
+	}
+
+	if worker.State() != db.WorkerStateLanding && worker.State() != db.WorkerStateRetiring {
+		s.writeDrainStatus(logger, w, status)
+		return
+	}
+
+	if !worker.DrainStartedAt().IsZero() {
+		status.StartedAt = worker.DrainStartedAt().Unix()
+
+		if s.drainTimeout != 0 {
+			status.Deadline = worker.DrainStartedAt().Add(s.drainTimeout).Unix()
+		}
+	}
+
+	builds, err := s.dbBuildFactory.GetBuildsBlockingWorkerDrain(worker.Name())
+	if err != nil {
+		logger.Error("failed-to-get-builds", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	for _, build := range builds {
+		status.Builds = append(status.Builds, present.Build(build))
+
+		containers, err := s.teamFactory.GetByID(build.TeamID()).FindContainersByMetadata(db.ContainerMetadata{
+			BuildID: build.ID(),
+		})
+		if err != nil {
+			logger.Error("failed-to-get-containers", err, lager.Data{"build": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for _, container := range containers {
+			if container.WorkerName() == worker.Name() {
+				status.Containers = append(status.Containers, present.Container(container))
+			}
+		}
+	}
+
+	s.writeDrainStatus(logger, w, status)
+}
+
+func (s *Server) writeDrainStatus(logger lager.Logger, w http.ResponseWriter, status atc.WorkerDrainStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(status)
+	if err != nil {
+		logger.Error("failed-to-encode-drain-status", err)
+	}
+}
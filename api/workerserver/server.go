@@ -1,6 +1,8 @@
 package workerserver
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
@@ -11,20 +13,25 @@ type Server struct {
 
 	teamFactory     db.TeamFactory
 	dbWorkerFactory db.WorkerFactory
+	dbBuildFactory  db.BuildFactory
 	workerProvider  worker.WorkerProvider
+	drainTimeout    time.Duration
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	dbWorkerFactory db.WorkerFactory,
+	dbBuildFactory db.BuildFactory,
 	workerProvider worker.WorkerProvider,
-
+	drainTimeout time.Duration,
 ) *Server {
 	return &Server{
 		logger:          logger,
 		teamFactory:     teamFactory,
 		dbWorkerFactory: dbWorkerFactory,
+		dbBuildFactory:  dbBuildFactory,
 		workerProvider:  workerProvider,
+		drainTimeout:    drainTimeout,
 	}
 }
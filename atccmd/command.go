@@ -24,8 +24,11 @@ import (
 	"github.com/concourse/atc/api/containerserver"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/cache"
+	"github.com/concourse/atc/creds/dbvars"
 	"github.com/concourse/atc/creds/noop"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/blobstore"
 	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migration"
@@ -109,6 +112,13 @@ type ATCCommand struct {
 	CredentialManagement struct{} `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
+	CredentialCache struct {
+		Enabled          bool          `long:"enabled"           description:"Cache the credentials looked up from the configured credential manager."`
+		Duration         time.Duration `long:"duration"          default:"1m"  description:"Length of time for which a credential that was found is cached."`
+		NotFoundDuration time.Duration `long:"duration-notfound" default:"10s" description:"Length of time for which a credential that was not found is cached."`
+		MaxEntries       int           `long:"max-entries"       default:"10000" description:"Maximum number of credentials to cache."`
+	} `group:"Credential Caching" namespace:"secret-cache"`
+
 	EncryptionKey    flag.Cipher `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
 	OldEncryptionKey flag.Cipher `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is encrypted. If provided with a new key, data is re-encrypted."`
 
@@ -119,8 +129,9 @@ type ATCCommand struct {
 
 	InterceptIdleTimeout              time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
 	ResourceCheckingInterval          time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" description:"Method by which a worker is selected during container placement."`
+	ContainerPlacementStrategy        []string      `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"least-loaded" description:"Method by which a worker is selected during container placement. Can be specified multiple times, in which case each strategy breaks ties left by the previous one."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
+	WorkerDrainTimeout                time.Duration `long:"worker-drain-timeout" description:"How long a landing or retiring worker waits for the builds running on it before they are aborted. Set to 0 to wait forever."`
 
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
@@ -151,9 +162,23 @@ type ATCCommand struct {
 	GC struct {
 		Interval          time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
 		WorkerConcurrency int           `long:"worker-concurrency" default:"50" description:"Maximum number of delete operations to have in flight per worker."`
+		AuditRetention    time.Duration `long:"audit-retention" default:"720h" description:"How long to keep audit events before deleting them. Set to 0 to keep them forever."`
+
+		VersionHistoryLimit int `long:"version-history-limit" description:"Number of most recently checked versions to keep for resources which do not set version_history_limit. Versions used by builds are always kept. Set to 0 to keep every version."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	BuildLogRetention struct {
+		Builds                 int `long:"default-build-logs-to-retain" description:"Number of most recent builds' logs to keep for jobs which do not configure build log retention. Set to 0 for no limit."`
+		Days                   int `long:"default-days-to-retain-build-logs" description:"Number of days to keep build logs for jobs which do not configure build log retention. Set to 0 for no limit."`
+		MinimumSucceededBuilds int `long:"default-minimum-succeeded-builds-to-retain" description:"Number of most recent succeeded builds' logs to keep regardless of the default build log limits."`
+	} `group:"Build Log Retention"`
+
+	BuildLogArchive struct {
+		LocalDir flag.Dir `long:"local-dir" description:"Directory in which to archive the events of completed builds. If not specified, build events are kept in the database."`
+	} `group:"Build Log Archival" namespace:"build-log-archive"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
+	SchedulingInterval   time.Duration `long:"scheduling-interval" default:"1m" description:"Interval on which to schedule pipelines when nothing has changed. Pipelines are scheduled immediately when new versions are saved, builds finish, or jobs are unpaused."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 }
@@ -348,27 +373,6 @@ func (cmd *ATCCommand) constructMembers(
 		workerVersion = &version
 	}
 
-	var variablesFactory creds.VariablesFactory = noop.NewNoopFactory()
-	for name, manager := range cmd.CredentialManagers {
-		if !manager.IsConfigured() {
-			continue
-		}
-
-		err := manager.Validate()
-		if err != nil {
-			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
-		}
-
-		variablesFactory, err = manager.NewVariablesFactory(logger.Session("credential-manager", lager.Data{
-			"name": name,
-		}))
-		if err != nil {
-			return nil, err
-		}
-
-		break
-	}
-
 	var newKey *encryption.Key
 	if cmd.EncryptionKey.AEAD != nil {
 		newKey = encryption.NewKey(cmd.EncryptionKey.AEAD)
@@ -393,7 +397,44 @@ func (cmd *ATCCommand) constructMembers(
 
 	bus := dbConn.Bus()
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
+
+	var variablesFactory creds.VariablesFactory = noop.NewNoopFactory()
+	for name, manager := range cmd.CredentialManagers {
+		if !manager.IsConfigured() {
+			continue
+		}
+
+		err := manager.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
+		}
+
+		if dbVarsManager, ok := manager.(*dbvars.DBVarsManager); ok {
+			dbVarsManager.UseTeamFactory(teamFactory)
+		}
+
+		variablesFactory, err = manager.NewVariablesFactory(logger.Session("credential-manager", lager.Data{
+			"name": name,
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		break
+	}
+
+	if cmd.CredentialCache.Enabled {
+		variablesFactory = cache.NewVariablesFactory(
+			variablesFactory,
+			clock.NewClock(),
+			cmd.CredentialCache.Duration,
+			cmd.CredentialCache.NotFoundDuration,
+			cmd.CredentialCache.MaxEntries,
+		)
+	}
+
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory)
+	dbAuditEventFactory := db.NewAuditEventFactory(dbConn)
 	dbVolumeFactory := db.NewVolumeFactory(dbConn)
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -434,11 +475,12 @@ func (cmd *ATCCommand) constructMembers(
 	workerClient := cmd.constructWorkerPool(
 		logger,
 		workerProvider,
+		teamFactory,
 	)
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resource.NewResourceFactory(workerClient)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory)
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, teamFactory, variablesFactory)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
@@ -497,6 +539,7 @@ func (cmd *ATCCommand) constructMembers(
 		dbVolumeFactory,
 		dbContainerRepository,
 		dbBuildFactory,
+		dbAuditEventFactory,
 		signingKey,
 		engine,
 		workerClient,
@@ -660,6 +703,16 @@ func (cmd *ATCCommand) constructMembers(
 					logger.Session("resource-config-check-session-collector"),
 					resourceConfigCheckSessionLifecycle,
 				),
+				gc.NewAuditEventCollector(
+					logger.Session("audit-event-collector"),
+					dbAuditEventFactory,
+					cmd.GC.AuditRetention,
+				),
+				gc.NewResourceVersionCollector(
+					logger.Session("resource-version-collector"),
+					dbPipelineFactory,
+					cmd.GC.VersionHistoryLimit,
+				),
 			),
 			"collector",
 			lockFactory,
@@ -673,6 +726,7 @@ func (cmd *ATCCommand) constructMembers(
 				logger.Session("build-reaper"),
 				dbPipelineFactory,
 				500,
+				atc.BuildLogRetention(cmd.BuildLogRetention),
 			),
 			"build-reaper",
 			lockFactory,
@@ -681,6 +735,38 @@ func (cmd *ATCCommand) constructMembers(
 		)},
 	}
 
+	if cmd.BuildLogArchive.LocalDir != "" {
+		members = append(members, grouper.Member{"build-log-archiver", lockrunner.NewRunner(
+			logger.Session("build-log-archiver-runner"),
+			gc.NewBuildLogArchiver(
+				logger.Session("build-log-archiver"),
+				dbBuildFactory,
+				500,
+			),
+			"build-log-archiver",
+			lockFactory,
+			clock.NewClock(),
+			30*time.Second,
+		)})
+	}
+
+	if cmd.WorkerDrainTimeout != 0 {
+		members = append(members, grouper.Member{"worker-drain-reaper", lockrunner.NewRunner(
+			logger.Session("worker-drain-reaper-runner"),
+			gc.NewWorkerDrainReaper(
+				logger.Session("worker-drain-reaper"),
+				dbWorkerFactory,
+				dbBuildFactory,
+				clock.NewClock(),
+				cmd.WorkerDrainTimeout,
+			),
+			"worker-drain-reaper",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)})
+	}
+
 	if cmd.TelemetryOptIn {
 		url := fmt.Sprintf("http://telemetry.concourse-ci.org/?version=%s", Version)
 		go func() {
@@ -942,6 +1028,11 @@ func (cmd *ATCCommand) constructDBConn(
 		dbConn = db.Log(logger.Session("log-conn"), dbConn)
 	}
 
+	// Archive build events
+	if cmd.BuildLogArchive.LocalDir != "" {
+		dbConn = db.WithBuildLogStore(dbConn, blobstore.NewLocalStore(cmd.BuildLogArchive.LocalDir.Path()))
+	}
+
 	// Prepare
 	dbConn.SetMaxOpenConns(maxConn)
 
@@ -964,19 +1055,38 @@ func (cmd *ATCCommand) constructLockConn(driverName string) (*sql.DB, error) {
 func (cmd *ATCCommand) constructWorkerPool(
 	logger lager.Logger,
 	workerProvider worker.WorkerProvider,
+	teamFactory db.TeamFactory,
 ) worker.Client {
 
+	var strategies []worker.ChainablePlacementStrategy
+	for _, name := range cmd.ContainerPlacementStrategy {
+		switch name {
+		case "random":
+			strategies = append(strategies, worker.NewRandomPlacementStrategy())
+		case "fewest-build-containers":
+			strategies = append(strategies, worker.NewFewestBuildContainersPlacementStrategy())
+		case "least-loaded":
+			strategies = append(strategies, worker.NewLeastLoadedPlacementStrategy())
+		default:
+			strategies = append(strategies, worker.NewVolumeLocalityPlacementStrategy())
+		}
+	}
+
 	var strategy worker.ContainerPlacementStrategy
-	switch cmd.ContainerPlacementStrategy {
-	case "random":
-		strategy = worker.NewRandomPlacementStrategy()
-	default:
+	switch len(strategies) {
+	case 0:
 		strategy = worker.NewVolumeLocalityPlacementStrategy()
+	case 1:
+		strategy = strategies[0]
+	default:
+		strategy = worker.NewChainedPlacementStrategy(strategies...)
 	}
 
 	return worker.NewPool(
 		workerProvider,
+		teamFactory,
 		strategy,
+		clock.NewClock(),
 	)
 }
 
@@ -1047,6 +1157,7 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
+	teamFactory db.TeamFactory,
 	variablesFactory creds.VariablesFactory,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
@@ -1054,12 +1165,12 @@ func (cmd *ATCCommand) constructEngine(
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
-		variablesFactory,
+		teamFactory,
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(),
+		engine.NewBuildDelegateFactory(variablesFactory),
 		cmd.ExternalURL.String(),
 	)
 
@@ -1107,6 +1218,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	dbVolumeFactory db.VolumeFactory,
 	dbContainerRepository db.ContainerRepository,
 	dbBuildFactory db.BuildFactory,
+	dbAuditEventFactory db.AuditEventFactory,
 	signingKey *rsa.PrivateKey,
 	engine engine.Engine,
 	workerClient worker.Client,
@@ -1130,6 +1242,13 @@ func (cmd *ATCCommand) constructAPIHandler(
 			checkBuildWriteAccessHandlerFactory,
 			checkWorkerTeamAccessHandlerFactory,
 		),
+		wrappa.NewAuditWrappa(
+			logger.Session("audit"),
+			dbAuditEventFactory,
+			dbBuildFactory,
+			dbWorkerFactory,
+			clock.NewClock(),
+		),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
 
@@ -1147,6 +1266,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		dbVolumeFactory,
 		dbContainerRepository,
 		dbBuildFactory,
+		dbAuditEventFactory,
 
 		cmd.PeerURL.String(),
 		buildserver.NewEventHandler,
@@ -1169,6 +1289,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		WorkerVersion,
 		variablesFactory,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
+		cmd.WorkerDrainTimeout,
 	)
 }
 
@@ -1221,7 +1342,7 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 						Pipeline:  pipeline,
 						Scheduler: radarSchedulerFactory.BuildScheduler(pipeline, cmd.ExternalURL.String(), variables),
 						Noop:      cmd.Developer.Noop,
-						Interval:  10 * time.Second,
+						Interval:  cmd.SchedulingInterval,
 					},
 				},
 			})
@@ -0,0 +1,14 @@
+package atc
+
+// AuditEvent is a record of a mutating API call. Target holds the route's
+// parameters identifying the object acted upon, and Status is the HTTP status
+// the call responded with.
+type AuditEvent struct {
+	ID       int               `json:"id"`
+	Time     int64             `json:"time"`
+	UserName string            `json:"user_name"`
+	TeamName string            `json:"team_name"`
+	Route    string            `json:"route"`
+	Target   map[string]string `json:"target"`
+	Status   int               `json:"status"`
+}
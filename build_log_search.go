@@ -0,0 +1,19 @@
+package atc
+
+type BuildLogSearchResult struct {
+	Build   Build           `json:"build"`
+	Matches []BuildLogMatch `json:"matches"`
+}
+
+type BuildLogMatch struct {
+	Origin BuildLogOrigin `json:"origin"`
+	Line   int            `json:"line"`
+	Text   string         `json:"text"`
+	Before []string       `json:"before,omitempty"`
+	After  []string       `json:"after,omitempty"`
+}
+
+type BuildLogOrigin struct {
+	ID     string `json:"id"`
+	Source string `json:"source,omitempty"`
+}
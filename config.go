@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -57,6 +58,13 @@ type ResourceConfig struct {
 	Source       Source `yaml:"source" json:"source" mapstructure:"source"`
 	CheckEvery   string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
 	Tags         Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+
+	// LabelSelector restricts the workers the resource is checked on.
+	LabelSelector LabelSelector `yaml:"label_selector,omitempty" json:"label_selector,omitempty" mapstructure:"label_selector"`
+
+	// VersionHistoryLimit is the number of most recently checked versions of
+	// the resource to keep. Versions used by builds are always kept.
+	VersionHistoryLimit int `yaml:"version_history_limit,omitempty" json:"version_history_limit,omitempty" mapstructure:"version_history_limit"`
 }
 
 type ResourceType struct {
@@ -66,6 +74,8 @@ type ResourceType struct {
 	Privileged bool   `yaml:"privileged,omitempty" json:"privileged" mapstructure:"privileged"`
 	Tags       Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
 	Params     Params `yaml:"params,omitempty" json:"params" mapstructure:"params"`
+
+	LabelSelector LabelSelector `yaml:"label_selector,omitempty" json:"label_selector,omitempty" mapstructure:"label_selector"`
 }
 
 type ResourceTypes []ResourceType
@@ -202,6 +212,119 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+const (
+	ConditionTriggerManual    = "manual"
+	ConditionTriggerAutomatic = "automatic"
+)
+
+// A ConditionConfig describes when a step should run. All of the configured
+// conditions must hold for the step to run; otherwise it is skipped.
+type ConditionConfig struct {
+	// how the build was triggered, either 'manual' or 'automatic'
+	Trigger string `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
+
+	// regular expressions that version fields must match, keyed by the name of
+	// the get or put step that produced the version
+	Versions map[string]Version `yaml:"versions,omitempty" json:"versions,omitempty" mapstructure:"versions"`
+
+	// the required result of earlier steps, either 'succeeded' or 'failed',
+	// keyed by step name
+	Steps map[string]BuildStatus `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+}
+
+// VersionNames returns the names of the steps with a version condition, in
+// order.
+func (config ConditionConfig) VersionNames() []string {
+	names := []string{}
+	for name := range config.Versions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// VersionFields returns the fields of the version condition on the named
+// step, in order.
+func (config ConditionConfig) VersionFields(name string) []string {
+	fields := []string{}
+	for field := range config.Versions[name] {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+// StepNames returns the names of the steps with a status condition, in
+// order.
+func (config ConditionConfig) StepNames() []string {
+	names := []string{}
+	for name := range config.Steps {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// A MatrixConfig lists the values to run a step with, keyed by param name.
+type MatrixConfig map[string][]string
+
+// A MatrixParam is a single param of a matrix combination.
+type MatrixParam struct {
+	Name  string
+	Value string
+}
+
+// Combinations returns every combination of the matrix's values. Params are
+// ordered by name, and values in the order they are configured.
+func (matrix MatrixConfig) Combinations() [][]MatrixParam {
+	if len(matrix) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for name := range matrix {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	combinations := [][]MatrixParam{{}}
+	for _, name := range names {
+		expanded := [][]MatrixParam{}
+
+		for _, combination := range combinations {
+			for _, value := range matrix[name] {
+				params := make([]MatrixParam, len(combination), len(combination)+1)
+				copy(params, combination)
+
+				expanded = append(expanded, append(params, MatrixParam{
+					Name:  name,
+					Value: value,
+				}))
+			}
+		}
+
+		combinations = expanded
+	}
+
+	return combinations
+}
+
+// InParallelConfig configures a set of steps to run in parallel. If Limit is
+// set, at most that many steps run at once. If FailFast is set, the remaining
+// steps are interrupted as soon as one of them fails or errors.
+type InParallelConfig struct {
+	Steps    PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+	Limit    int          `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+	FailFast bool         `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
@@ -218,10 +341,14 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// a nested set of steps to run in parallel, optionally bounded
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
-	// jobs that this resource must have made it through
+	// jobs that this resource must have made it through; jobs in other
+	// pipelines of the team are named as pipeline-name/job-name
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
@@ -237,11 +364,15 @@ type PlanConfig struct {
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
-	// task config path, e.g. foo/build.yml
+	// task config path, e.g. foo/build.yml, or pipeline config path for set_pipeline
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure from `file`, within the build's team
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -258,6 +389,9 @@ type PlanConfig struct {
 	// used by any step to specify which workers are eligible to run the step
 	Tags Tags `yaml:"tags,omitempty" json:"tags,omitempty" mapstructure:"tags"`
 
+	// used by any step to select the eligible workers by their labels
+	LabelSelector LabelSelector `yaml:"label_selector,omitempty" json:"label_selector,omitempty" mapstructure:"label_selector"`
+
 	// used by any step to run something when the build is aborted during execution of the step
 	Abort *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
 
@@ -276,6 +410,15 @@ type PlanConfig struct {
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to skip it unless the condition is met when it is reached
+	If *ConditionConfig `yaml:"if,omitempty" json:"if,omitempty" mapstructure:"if"`
+
+	// used on task steps to run the task once for every combination of values
+	Matrix MatrixConfig `yaml:"matrix,omitempty" json:"matrix,omitempty" mapstructure:"matrix"`
+
+	// not present in yaml; appended to the names of a matrix instance's outputs
+	OutputSuffix string `yaml:"-" json:"-"`
+
 	// not present in yaml
 	DependentGet string `yaml:"-" json:"-"`
 
@@ -302,9 +445,49 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
 	return ""
 }
 
+// MatrixInstances expands a step with a matrix into one step per combination
+// of values. Each instance has the combination set as params, and its name
+// and outputs suffixed with the combination's values. Hooks and conditions
+// are left to the step as a whole.
+func (config PlanConfig) MatrixInstances() PlanSequence {
+	instances := PlanSequence{}
+
+	for _, combination := range config.Matrix.Combinations() {
+		instance := config
+		instance.Matrix = nil
+		instance.If = nil
+		instance.Abort = nil
+		instance.Failure = nil
+		instance.Ensure = nil
+		instance.Success = nil
+
+		instance.Params = Params{}
+		for k, v := range config.Params {
+			instance.Params[k] = v
+		}
+
+		values := []string{}
+		for _, param := range combination {
+			instance.Params[param.Name] = param.Value
+			values = append(values, param.Value)
+		}
+
+		instance.OutputSuffix = "-" + strings.Join(values, "-")
+		instance.Task = config.Task + instance.OutputSuffix
+
+		instances = append(instances, instance)
+	}
+
+	return instances
+}
+
 func (config PlanConfig) ResourceName() string {
 	resourceName := config.Resource
 	if resourceName != "" {
@@ -0,0 +1,63 @@
+package atc
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+type ExtraKeysError struct {
+	ExtraKeys []string
+}
+
+func (eke ExtraKeysError) Error() string {
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintln(msg, "unknown/extra keys:")
+	for _, unusedKey := range eke.ExtraKeys {
+		fmt.Fprintf(msg, "  - %s\n", unusedKey)
+	}
+
+	return msg.String()
+}
+
+// DecodeConfig decodes a pipeline config from its generic form, e.g. as
+// unmarshaled from YAML or JSON. Unknown keys nested within the config are
+// reported as an ExtraKeysError.
+func DecodeConfig(configStructure interface{}) (Config, error) {
+	var config Config
+	var md mapstructure.Metadata
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &md,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			SanitizeDecodeHook,
+			VersionConfigDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := decoder.Decode(configStructure); err != nil {
+		return Config{}, err
+	}
+
+	nestedUnused := []string{}
+	for _, unused := range md.Unused {
+		if strings.Contains(unused, ".") {
+			nestedUnused = append(nestedUnused, unused)
+		}
+	}
+
+	if len(nestedUnused) != 0 {
+		return Config{}, ExtraKeysError{ExtraKeys: nestedUnused}
+	}
+
+	return config, nil
+}
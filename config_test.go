@@ -46,4 +46,90 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("PlanConfig", func() {
+		Describe("MatrixInstances", func() {
+			It("returns one task per combination of values", func() {
+				planConfig := PlanConfig{
+					Task:           "unit",
+					TaskConfigPath: "ci/unit.yml",
+					Params:         Params{"some": "param"},
+					Attempts:       2,
+					Success:        &PlanConfig{Task: "notify"},
+					Matrix: MatrixConfig{
+						"os":         {"linux", "darwin"},
+						"go_version": {"1.9", "1.10"},
+					},
+				}
+
+				Expect(planConfig.MatrixInstances()).To(Equal(PlanSequence{
+					{
+						Task:           "unit-1.9-linux",
+						TaskConfigPath: "ci/unit.yml",
+						Params:         Params{"some": "param", "go_version": "1.9", "os": "linux"},
+						Attempts:       2,
+						OutputSuffix:   "-1.9-linux",
+					},
+					{
+						Task:           "unit-1.9-darwin",
+						TaskConfigPath: "ci/unit.yml",
+						Params:         Params{"some": "param", "go_version": "1.9", "os": "darwin"},
+						Attempts:       2,
+						OutputSuffix:   "-1.9-darwin",
+					},
+					{
+						Task:           "unit-1.10-linux",
+						TaskConfigPath: "ci/unit.yml",
+						Params:         Params{"some": "param", "go_version": "1.10", "os": "linux"},
+						Attempts:       2,
+						OutputSuffix:   "-1.10-linux",
+					},
+					{
+						Task:           "unit-1.10-darwin",
+						TaskConfigPath: "ci/unit.yml",
+						Params:         Params{"some": "param", "go_version": "1.10", "os": "darwin"},
+						Attempts:       2,
+						OutputSuffix:   "-1.10-darwin",
+					},
+				}))
+			})
+
+			It("does not modify the params of the original step", func() {
+				planConfig := PlanConfig{
+					Task:   "unit",
+					Params: Params{"some": "param"},
+					Matrix: MatrixConfig{"os": {"linux"}},
+				}
+
+				planConfig.MatrixInstances()
+
+				Expect(planConfig.Params).To(Equal(Params{"some": "param"}))
+			})
+		})
+	})
+	Describe("ConditionConfig", func() {
+		condition := ConditionConfig{
+			Versions: map[string]Version{
+				"some-resource":  {"ref": "abc", "branch": "master"},
+				"other-resource": {"tag": "v.*"},
+			},
+			Steps: map[string]BuildStatus{
+				"test":  StatusSucceeded,
+				"build": StatusFailed,
+			},
+		}
+
+		It("returns the names of its version conditions in order", func() {
+			Expect(condition.VersionNames()).To(Equal([]string{"other-resource", "some-resource"}))
+		})
+
+		It("returns the fields of a version condition in order", func() {
+			Expect(condition.VersionFields("some-resource")).To(Equal([]string{"branch", "ref"}))
+			Expect(condition.VersionFields("bogus")).To(BeEmpty())
+		})
+
+		It("returns the names of its step conditions in order", func() {
+			Expect(condition.StepNames()).To(Equal([]string{"build", "test"}))
+		})
+	})
 })
@@ -0,0 +1,131 @@
+package atc
+
+import "reflect"
+
+// PipelineConfigVersion describes a config that was saved for a pipeline. Config is
+// only included when fetching a single version.
+type PipelineConfigVersion struct {
+	Version   int     `json:"version"`
+	Author    string  `json:"author"`
+	CreatedAt int64   `json:"created_at"`
+	Config    *Config `json:"config,omitempty"`
+}
+
+type ConfigChangeType string
+
+const (
+	ConfigChangeAdded   ConfigChangeType = "added"
+	ConfigChangeRemoved ConfigChangeType = "removed"
+	ConfigChangeChanged ConfigChangeType = "changed"
+)
+
+// ConfigChange is a single group, resource, resource type, or job that
+// differs between two configs. Before and After hold its config on either
+// side, and are omitted when it was added or removed.
+type ConfigChange struct {
+	Section string           `json:"section"`
+	Name    string           `json:"name"`
+	Type    ConfigChangeType `json:"type"`
+	Before  interface{}      `json:"before,omitempty"`
+	After   interface{}      `json:"after,omitempty"`
+}
+
+type namedConfig struct {
+	name   string
+	config interface{}
+}
+
+// DiffConfigs lists what changed going from one config to another, section by
+// section. Within a section, removed and changed entries come first in their
+// original order, followed by added entries.
+func DiffConfigs(from Config, to Config) []ConfigChange {
+	changes := []ConfigChange{}
+
+	changes = append(changes, diffSection("groups", namedGroups(from.Groups), namedGroups(to.Groups))...)
+	changes = append(changes, diffSection("resource_types", namedResourceTypes(from.ResourceTypes), namedResourceTypes(to.ResourceTypes))...)
+	changes = append(changes, diffSection("resources", namedResources(from.Resources), namedResources(to.Resources))...)
+	changes = append(changes, diffSection("jobs", namedJobs(from.Jobs), namedJobs(to.Jobs))...)
+
+	return changes
+}
+
+func diffSection(section string, from []namedConfig, to []namedConfig) []ConfigChange {
+	changes := []ConfigChange{}
+
+	toByName := map[string]interface{}{}
+	for _, c := range to {
+		toByName[c.name] = c.config
+	}
+
+	fromByName := map[string]interface{}{}
+	for _, c := range from {
+		fromByName[c.name] = c.config
+
+		after, found := toByName[c.name]
+		if !found {
+			changes = append(changes, ConfigChange{
+				Section: section,
+				Name:    c.name,
+				Type:    ConfigChangeRemoved,
+				Before:  c.config,
+			})
+		} else if !reflect.DeepEqual(c.config, after) {
+			changes = append(changes, ConfigChange{
+				Section: section,
+				Name:    c.name,
+				Type:    ConfigChangeChanged,
+				Before:  c.config,
+				After:   after,
+			})
+		}
+	}
+
+	for _, c := range to {
+		if _, found := fromByName[c.name]; !found {
+			changes = append(changes, ConfigChange{
+				Section: section,
+				Name:    c.name,
+				Type:    ConfigChangeAdded,
+				After:   c.config,
+			})
+		}
+	}
+
+	return changes
+}
+
+func namedGroups(groups GroupConfigs) []namedConfig {
+	named := []namedConfig{}
+	for _, group := range groups {
+		named = append(named, namedConfig{group.Name, group})
+	}
+
+	return named
+}
+
+func namedResourceTypes(resourceTypes ResourceTypes) []namedConfig {
+	named := []namedConfig{}
+	for _, resourceType := range resourceTypes {
+		named = append(named, namedConfig{resourceType.Name, resourceType})
+	}
+
+	return named
+}
+
+func namedResources(resources ResourceConfigs) []namedConfig {
+	named := []namedConfig{}
+	for _, resource := range resources {
+		named = append(named, namedConfig{resource.Name, resource})
+	}
+
+	return named
+}
+
+func namedJobs(jobs JobConfigs) []namedConfig {
+	named := []namedConfig{}
+	for _, job := range jobs {
+		named = append(named, namedConfig{job.Name, job})
+	}
+
+	return named
+}
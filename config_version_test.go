@@ -0,0 +1,70 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffConfigs", func() {
+	var from, to Config
+
+	BeforeEach(func() {
+		from = Config{
+			Groups: GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+			Resources: ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: Source{"uri": "some-uri"}},
+				{Name: "removed-resource", Type: "git"},
+			},
+			Jobs: JobConfigs{
+				{Name: "some-job", Public: true},
+			},
+		}
+
+		to = Config{
+			Groups: GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+			Resources: ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: Source{"uri": "some-other-uri"}},
+			},
+			ResourceTypes: ResourceTypes{
+				{Name: "some-type", Type: "docker-image"},
+			},
+			Jobs: JobConfigs{
+				{Name: "some-job", Public: true},
+			},
+		}
+	})
+
+	It("lists every change, section by section", func() {
+		Expect(DiffConfigs(from, to)).To(Equal([]ConfigChange{
+			{
+				Section: "resource_types",
+				Name:    "some-type",
+				Type:    ConfigChangeAdded,
+				After:   ResourceType{Name: "some-type", Type: "docker-image"},
+			},
+			{
+				Section: "resources",
+				Name:    "some-resource",
+				Type:    ConfigChangeChanged,
+				Before:  ResourceConfig{Name: "some-resource", Type: "git", Source: Source{"uri": "some-uri"}},
+				After:   ResourceConfig{Name: "some-resource", Type: "git", Source: Source{"uri": "some-other-uri"}},
+			},
+			{
+				Section: "resources",
+				Name:    "removed-resource",
+				Type:    ConfigChangeRemoved,
+				Before:  ResourceConfig{Name: "removed-resource", Type: "git"},
+			},
+		}))
+	})
+
+	It("returns no changes for identical configs", func() {
+		Expect(DiffConfigs(from, from)).To(BeEmpty())
+	})
+})
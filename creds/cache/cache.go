@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/metric"
+)
+
+type cacheKey struct {
+	teamName     string
+	pipelineName string
+	varName      string
+}
+
+type cacheEntry struct {
+	value     interface{}
+	found     bool
+	expiresAt time.Time
+}
+
+type variablesFactory struct {
+	factory creds.VariablesFactory
+	clock   clock.Clock
+
+	duration         time.Duration
+	notFoundDuration time.Duration
+	maxEntries       int
+
+	entries  map[cacheKey]cacheEntry
+	entriesL *sync.Mutex
+}
+
+// NewVariablesFactory wraps a VariablesFactory so that the results of looking
+// up a var are remembered for a while. Vars that were found are cached for
+// duration, and vars that were not found are cached for notFoundDuration. A
+// zero duration disables caching for that case. Errors are never cached.
+//
+// At most maxEntries vars are cached. When the cache is full, expired entries
+// are evicted first, and then the entry closest to expiring.
+//
+// The cache is shared by all of the Variables created by the factory.
+func NewVariablesFactory(
+	factory creds.VariablesFactory,
+	clock clock.Clock,
+	duration time.Duration,
+	notFoundDuration time.Duration,
+	maxEntries int,
+) creds.VariablesFactory {
+	return &variablesFactory{
+		factory: factory,
+		clock:   clock,
+
+		duration:         duration,
+		notFoundDuration: notFoundDuration,
+		maxEntries:       maxEntries,
+
+		entries:  map[cacheKey]cacheEntry{},
+		entriesL: &sync.Mutex{},
+	}
+}
+
+func (factory *variablesFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
+	return &variables{
+		variables:    factory.factory.NewVariables(teamName, pipelineName),
+		factory:      factory,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+func (factory *variablesFactory) lookup(key cacheKey) (cacheEntry, bool) {
+	factory.entriesL.Lock()
+	defer factory.entriesL.Unlock()
+
+	entry, found := factory.entries[key]
+	if !found {
+		return cacheEntry{}, false
+	}
+
+	if !factory.clock.Now().Before(entry.expiresAt) {
+		delete(factory.entries, key)
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (factory *variablesFactory) store(key cacheKey, value interface{}, found bool) {
+	duration := factory.duration
+	if !found {
+		duration = factory.notFoundDuration
+	}
+
+	if duration <= 0 {
+		return
+	}
+
+	factory.entriesL.Lock()
+	defer factory.entriesL.Unlock()
+
+	now := factory.clock.Now()
+
+	if _, cached := factory.entries[key]; !cached && len(factory.entries) >= factory.maxEntries {
+		factory.evict(now)
+	}
+
+	factory.entries[key] = cacheEntry{
+		value:     value,
+		found:     found,
+		expiresAt: now.Add(duration),
+	}
+}
+
+// evict makes room for a new entry by removing every expired entry, or the
+// entry closest to expiring if none have expired. It must be called with
+// entriesL held.
+func (factory *variablesFactory) evict(now time.Time) {
+	var (
+		soonestKey       cacheKey
+		soonestExpiresAt time.Time
+	)
+
+	for key, entry := range factory.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(factory.entries, key)
+			continue
+		}
+
+		if soonestExpiresAt.IsZero() || entry.expiresAt.Before(soonestExpiresAt) {
+			soonestKey = key
+			soonestExpiresAt = entry.expiresAt
+		}
+	}
+
+	if len(factory.entries) >= factory.maxEntries {
+		delete(factory.entries, soonestKey)
+	}
+}
+
+type variables struct {
+	variables creds.Variables
+	factory   *variablesFactory
+
+	teamName     string
+	pipelineName string
+}
+
+func (v *variables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	key := cacheKey{
+		teamName:     v.teamName,
+		pipelineName: v.pipelineName,
+		varName:      varDef.Name,
+	}
+
+	entry, cached := v.factory.lookup(key)
+	if cached {
+		metric.CredentialCacheHits.Inc()
+		return entry.value, entry.found, nil
+	}
+
+	metric.CredentialCacheMisses.Inc()
+
+	value, found, err := v.variables.Get(varDef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	v.factory.store(key, value, found)
+
+	return value, found, nil
+}
+
+// List is passed through to the wrapped Variables without caching.
+func (v *variables) List() ([]template.VariableDefinition, error) {
+	return v.variables.List()
+}
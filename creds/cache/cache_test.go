@@ -0,0 +1,239 @@
+package cache_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/cache"
+	"github.com/concourse/atc/creds/credsfakes"
+	"github.com/concourse/atc/metric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cached variables", func() {
+	var (
+		fakeFactory   *credsfakes.FakeVariablesFactory
+		fakeVariables *credsfakes.FakeVariables
+		fakeClock     *fakeclock.FakeClock
+
+		notFoundDuration time.Duration
+		maxEntries       int
+
+		factory   creds.VariablesFactory
+		variables creds.Variables
+	)
+
+	varDef := template.VariableDefinition{Name: "some-var"}
+
+	BeforeEach(func() {
+		fakeFactory = new(credsfakes.FakeVariablesFactory)
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeFactory.NewVariablesReturns(fakeVariables)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		notFoundDuration = 10 * time.Second
+		maxEntries = 100
+
+		metric.CredentialCacheHits.Delta()
+		metric.CredentialCacheMisses.Delta()
+	})
+
+	JustBeforeEach(func() {
+		factory = cache.NewVariablesFactory(fakeFactory, fakeClock, time.Minute, notFoundDuration, maxEntries)
+		variables = factory.NewVariables("some-team", "some-pipeline")
+	})
+
+	It("creates the wrapped variables for the same team and pipeline", func() {
+		Expect(fakeFactory.NewVariablesCallCount()).To(Equal(1))
+		teamName, pipelineName := fakeFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+	})
+
+	Context("when the var is found", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns("some-value", true, nil)
+		})
+
+		It("looks it up once and then serves it from the cache", func() {
+			value, found, err := variables.Get(varDef)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			value, found, err = variables.Get(varDef)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+		})
+
+		It("counts hits and misses", func() {
+			variables.Get(varDef)
+			variables.Get(varDef)
+			variables.Get(varDef)
+
+			Expect(metric.CredentialCacheMisses.Delta()).To(Equal(1))
+			Expect(metric.CredentialCacheHits.Delta()).To(Equal(2))
+		})
+
+		It("shares the cache between variables for the same pipeline", func() {
+			variables.Get(varDef)
+
+			factory.NewVariables("some-team", "some-pipeline").Get(varDef)
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+		})
+
+		It("does not share the cache between pipelines", func() {
+			variables.Get(varDef)
+
+			factory.NewVariables("some-team", "some-other-pipeline").Get(varDef)
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+
+		It("looks it up again once the duration has elapsed", func() {
+			variables.Get(varDef)
+
+			fakeClock.Increment(59 * time.Second)
+			variables.Get(varDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+
+			fakeClock.Increment(time.Second)
+			variables.Get(varDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the cache is full", func() {
+		otherVarDef := template.VariableDefinition{Name: "other-var"}
+		newVarDef := template.VariableDefinition{Name: "new-var"}
+
+		BeforeEach(func() {
+			maxEntries = 2
+			fakeVariables.GetReturns("some-value", true, nil)
+		})
+
+		JustBeforeEach(func() {
+			variables.Get(varDef)
+
+			fakeClock.Increment(30 * time.Second)
+			variables.Get(otherVarDef)
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+
+		It("evicts the entry closest to expiring", func() {
+			variables.Get(newVarDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			variables.Get(otherVarDef)
+			variables.Get(newVarDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			variables.Get(varDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(4))
+			Expect(fakeVariables.GetArgsForCall(3)).To(Equal(varDef))
+		})
+
+		Context("when entries have expired", func() {
+			JustBeforeEach(func() {
+				fakeClock.Increment(time.Minute)
+			})
+
+			It("evicts all of them", func() {
+				variables.Get(newVarDef)
+				variables.Get(template.VariableDefinition{Name: "another-var"})
+				Expect(fakeVariables.GetCallCount()).To(Equal(4))
+
+				variables.Get(newVarDef)
+				Expect(fakeVariables.GetCallCount()).To(Equal(4))
+			})
+		})
+
+		It("does not evict anything when replacing a cached var", func() {
+			fakeClock.Increment(30 * time.Second)
+			variables.Get(varDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			variables.Get(otherVarDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+		})
+	})
+
+	Context("when the var is not found", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, nil)
+		})
+
+		It("caches its absence for the not found duration", func() {
+			_, found, err := variables.Get(varDef)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = variables.Get(varDef)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+
+			fakeClock.Increment(10 * time.Second)
+
+			variables.Get(varDef)
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+
+		Context("when the not found duration is zero", func() {
+			BeforeEach(func() {
+				notFoundDuration = 0
+			})
+
+			It("does not cache its absence", func() {
+				variables.Get(varDef)
+				variables.Get(varDef)
+
+				Expect(fakeVariables.GetCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when looking up the var fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, disaster)
+		})
+
+		It("returns the error without caching it", func() {
+			_, _, err := variables.Get(varDef)
+			Expect(err).To(Equal(disaster))
+
+			_, _, err = variables.Get(varDef)
+			Expect(err).To(Equal(disaster))
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			fakeVariables.ListReturns([]template.VariableDefinition{{Name: "some-var"}}, nil)
+		})
+
+		It("is passed through to the wrapped variables", func() {
+			varDefs, err := variables.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(varDefs).To(Equal([]template.VariableDefinition{{Name: "some-var"}}))
+
+			variables.List()
+			Expect(fakeVariables.ListCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,73 @@
+package dbvars
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/db"
+)
+
+// DBVars resolves vars from those saved to a team. Vars are shared by all of
+// the team's pipelines.
+type DBVars struct {
+	logger      lager.Logger
+	teamFactory db.TeamFactory
+	TeamName    string
+}
+
+func NewDBVars(logger lager.Logger, teamFactory db.TeamFactory, teamName string) *DBVars {
+	return &DBVars{
+		logger:      logger,
+		teamFactory: teamFactory,
+		TeamName:    teamName,
+	}
+}
+
+func (v *DBVars) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	team, found, err := v.teamFactory.FindTeam(v.TeamName)
+	if err != nil {
+		v.logger.Error("failed-to-find-team", err, lager.Data{"team": v.TeamName})
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	value, found, err := team.Var(varDef.Name)
+	if err != nil {
+		v.logger.Error("failed-to-get-var", err, lager.Data{
+			"team": v.TeamName,
+			"var":  varDef.Name,
+		})
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	return value, true, nil
+}
+
+func (v *DBVars) List() ([]template.VariableDefinition, error) {
+	team, found, err := v.teamFactory.FindTeam(v.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return []template.VariableDefinition{}, nil
+	}
+
+	names, err := team.VarNames()
+	if err != nil {
+		return nil, err
+	}
+
+	varDefs := []template.VariableDefinition{}
+	for _, name := range names {
+		varDefs = append(varDefs, template.VariableDefinition{Name: name})
+	}
+
+	return varDefs, nil
+}
@@ -0,0 +1,23 @@
+package dbvars
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+type dbVarsFactory struct {
+	logger      lager.Logger
+	teamFactory db.TeamFactory
+}
+
+func NewDBVarsFactory(logger lager.Logger, teamFactory db.TeamFactory) *dbVarsFactory {
+	return &dbVarsFactory{
+		logger:      logger,
+		teamFactory: teamFactory,
+	}
+}
+
+func (factory *dbVarsFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
+	return NewDBVars(factory.logger, factory.teamFactory, teamName)
+}
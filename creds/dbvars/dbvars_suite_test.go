@@ -0,0 +1,13 @@
+package dbvars_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDBVars(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DB Vars Creds Suite")
+}
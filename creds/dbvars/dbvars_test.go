@@ -0,0 +1,110 @@
+package dbvars_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/dbvars"
+	"github.com/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DBVars", func() {
+	var (
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		variables = dbvars.NewDBVarsFactory(lagertest.NewTestLogger("test"), fakeTeamFactory).
+			NewVariables("some-team", "some-pipeline")
+	})
+
+	Describe("Get", func() {
+		var (
+			value interface{}
+			found bool
+			err   error
+		)
+
+		JustBeforeEach(func() {
+			value, found, err = variables.Get(template.VariableDefinition{Name: "some-var"})
+		})
+
+		Context("when the team has the var", func() {
+			BeforeEach(func() {
+				fakeTeam.VarReturns("some-value", true, nil)
+			})
+
+			It("returns its value", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-value"))
+			})
+
+			It("looks up the var on the pipeline's team", func() {
+				Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+				Expect(fakeTeam.VarArgsForCall(0)).To(Equal("some-var"))
+			})
+		})
+
+		Context("when the team does not have the var", func() {
+			BeforeEach(func() {
+				fakeTeam.VarReturns("", false, nil)
+			})
+
+			It("is not found", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the team does not exist", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			It("is not found", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the var fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTeam.VarReturns("", false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			fakeTeam.VarNamesReturns([]string{"some-var", "some-other-var"}, nil)
+		})
+
+		It("returns a definition for each of the team's vars", func() {
+			varDefs, err := variables.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(varDefs).To(Equal([]template.VariableDefinition{
+				{Name: "some-var"},
+				{Name: "some-other-var"},
+			}))
+		})
+	})
+})
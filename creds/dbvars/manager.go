@@ -0,0 +1,39 @@
+package dbvars
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+var ErrNoTeamFactory = errors.New("database credential manager has not been given a team factory")
+
+type DBVarsManager struct {
+	Enabled bool `long:"enabled" description:"Resolve ((vars)) from the variables each team has saved in the ATC's database."`
+
+	teamFactory db.TeamFactory
+}
+
+func (manager *DBVarsManager) IsConfigured() bool {
+	return manager.Enabled
+}
+
+func (manager *DBVarsManager) Validate() error {
+	return nil
+}
+
+// UseTeamFactory gives the manager access to the database, which is only
+// available once the manager's flags have been parsed.
+func (manager *DBVarsManager) UseTeamFactory(teamFactory db.TeamFactory) {
+	manager.teamFactory = teamFactory
+}
+
+func (manager *DBVarsManager) NewVariablesFactory(logger lager.Logger) (creds.VariablesFactory, error) {
+	if manager.teamFactory == nil {
+		return nil, ErrNoTeamFactory
+	}
+
+	return NewDBVarsFactory(logger, manager.teamFactory), nil
+}
@@ -0,0 +1,26 @@
+package dbvars
+
+import (
+	"github.com/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type dbVarsManagerFactory struct{}
+
+func init() {
+	creds.Register("db", NewDBVarsManagerFactory())
+}
+
+func NewDBVarsManagerFactory() creds.ManagerFactory {
+	return &dbVarsManagerFactory{}
+}
+
+func (factory *dbVarsManagerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &DBVarsManager{}
+	subGroup, err := group.AddGroup("Database Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+	subGroup.Namespace = "db-vars"
+	return manager
+}
@@ -0,0 +1,48 @@
+package dbvars_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/creds/dbvars"
+	"github.com/concourse/atc/db/dbfakes"
+	flags "github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DBVarsManager", func() {
+	var manager *dbvars.DBVarsManager
+
+	BeforeEach(func() {
+		manager = &dbvars.DBVarsManager{}
+	})
+
+	Describe("IsConfigured()", func() {
+		It("is not configured by default", func() {
+			_, err := flags.ParseArgs(manager, []string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("is configured when enabled", func() {
+			_, err := flags.ParseArgs(manager, []string{"--enabled"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("NewVariablesFactory()", func() {
+		It("fails without a team factory", func() {
+			_, err := manager.NewVariablesFactory(lagertest.NewTestLogger("test"))
+			Expect(err).To(Equal(dbvars.ErrNoTeamFactory))
+		})
+
+		It("succeeds once given a team factory", func() {
+			manager.UseTeamFactory(new(dbfakes.FakeTeamFactory))
+
+			factory, err := manager.NewVariablesFactory(lagertest.NewTestLogger("test"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(factory).ToNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package creds
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// TrackedVariables wraps Variables, remembering every value resolved through
+// it so that they can be redacted from build output.
+type TrackedVariables struct {
+	variables Variables
+
+	values map[string]struct{}
+	lock   *sync.RWMutex
+}
+
+func NewTrackedVariables(variables Variables) *TrackedVariables {
+	return &TrackedVariables{
+		variables: variables,
+
+		values: map[string]struct{}{},
+		lock:   &sync.RWMutex{},
+	}
+}
+
+func (v *TrackedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	val, found, err := v.variables.Get(varDef)
+	if err != nil || !found {
+		return val, found, err
+	}
+
+	v.lock.Lock()
+	v.track(val)
+	v.lock.Unlock()
+
+	return val, found, err
+}
+
+func (v *TrackedVariables) List() ([]template.VariableDefinition, error) {
+	return v.variables.List()
+}
+
+// Values returns every non-empty string value resolved so far, including
+// those nested within structured credentials.
+func (v *TrackedVariables) Values() []string {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	values := make([]string, 0, len(v.values))
+	for val := range v.values {
+		values = append(values, val)
+	}
+
+	return values
+}
+
+func (v *TrackedVariables) track(val interface{}) {
+	switch x := val.(type) {
+	case string:
+		if x != "" {
+			v.values[x] = struct{}{}
+		}
+	case map[string]interface{}:
+		for _, sub := range x {
+			v.track(sub)
+		}
+	case map[interface{}]interface{}:
+		for _, sub := range x {
+			v.track(sub)
+		}
+	case []interface{}:
+		for _, sub := range x {
+			v.track(sub)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package creds_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TrackedVariables", func() {
+	var (
+		fakeVariables *credsfakes.FakeVariables
+
+		variables *creds.TrackedVariables
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(credsfakes.FakeVariables)
+
+		variables = creds.NewTrackedVariables(fakeVariables)
+	})
+
+	It("starts out with no values", func() {
+		Expect(variables.Values()).To(BeEmpty())
+	})
+
+	Describe("Get", func() {
+		var (
+			value interface{}
+			found bool
+			err   error
+		)
+
+		JustBeforeEach(func() {
+			value, found, err = variables.Get(template.VariableDefinition{Name: "some-var"})
+		})
+
+		Context("when the variable is a string", func() {
+			BeforeEach(func() {
+				fakeVariables.GetReturns("some-secret", true, nil)
+			})
+
+			It("returns the underlying value", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-secret"))
+			})
+
+			It("looks up the same variable", func() {
+				Expect(fakeVariables.GetArgsForCall(0)).To(Equal(template.VariableDefinition{Name: "some-var"}))
+			})
+
+			It("tracks the value", func() {
+				Expect(variables.Values()).To(ConsistOf("some-secret"))
+			})
+		})
+
+		Context("when the variable is structured", func() {
+			BeforeEach(func() {
+				fakeVariables.GetReturns(map[string]interface{}{
+					"username": "some-user",
+					"password": "some-password",
+					"nested": map[interface{}]interface{}{
+						"keys": []interface{}{"some-key", 42},
+					},
+					"empty": "",
+				}, true, nil)
+			})
+
+			It("tracks every string within it", func() {
+				Expect(variables.Values()).To(ConsistOf("some-user", "some-password", "some-key"))
+			})
+		})
+
+		Context("when the variable is not found", func() {
+			BeforeEach(func() {
+				fakeVariables.GetReturns(nil, false, nil)
+			})
+
+			It("returns not found", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("tracks nothing", func() {
+				Expect(variables.Values()).To(BeEmpty())
+			})
+		})
+
+		Context("when looking up the variable fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeVariables.GetReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disaster))
+			})
+
+			It("tracks nothing", func() {
+				Expect(variables.Values()).To(BeEmpty())
+			})
+		})
+	})
+})
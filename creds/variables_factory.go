@@ -8,6 +8,8 @@ type VariablesFactory interface {
 	NewVariables(string, string) Variables
 }
 
+//go:generate counterfeiter . Variables
+
 type Variables interface {
 	Get(template.VariableDefinition) (interface{}, bool, error)
 	List() ([]template.VariableDefinition, error)
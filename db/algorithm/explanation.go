@@ -0,0 +1,122 @@
+package algorithm
+
+import "sort"
+
+// InputExplanation describes the versions that were considered for an input
+// when resolving a job's inputs.
+type InputExplanation struct {
+	Name string
+
+	// Candidates are the versions of the input's resource which were
+	// considered, most recently checked first.
+	Candidates []VersionExplanation
+
+	// IndependentVersionID is the version the input resolves to on its own,
+	// or 0 if it does not resolve.
+	IndependentVersionID int
+
+	// VersionID is the version the input resolves to along with the job's
+	// other inputs, or 0 if the inputs do not resolve together.
+	VersionID int
+}
+
+type VersionExplanation struct {
+	VersionID  int
+	CheckOrder int
+
+	// NotPassed are the jobs in the input's passed constraints which the
+	// version has not made it through.
+	NotPassed JobSet
+}
+
+// Explain resolves the inputs in the same way as Resolve, describing how
+// each input's versions were narrowed down.
+func (configs InputConfigs) Explain(db *VersionsDB) []InputExplanation {
+	mapping, resolved := configs.Resolve(db)
+
+	explanations := []InputExplanation{}
+	for _, inputConfig := range configs {
+		explanation := InputExplanation{
+			Name:       inputConfig.Name,
+			Candidates: explainCandidates(db, inputConfig),
+		}
+
+		independentMapping, ok := InputConfigs{inputConfig}.Resolve(db)
+		if ok {
+			explanation.IndependentVersionID = independentMapping[inputConfig.Name].VersionID
+		}
+
+		if resolved {
+			explanation.VersionID = mapping[inputConfig.Name].VersionID
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations
+}
+
+func explainCandidates(db *VersionsDB, inputConfig InputConfig) []VersionExplanation {
+	candidates := []VersionExplanation{}
+
+	if len(inputConfig.Passed) == 0 && !inputConfig.UseEveryVersion {
+		var candidate VersionCandidate
+		var found bool
+
+		if inputConfig.PinnedVersionID != 0 {
+			candidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+		} else {
+			candidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
+		}
+
+		if found {
+			candidates = append(candidates, VersionExplanation{
+				VersionID:  candidate.VersionID,
+				CheckOrder: candidate.CheckOrder,
+				NotPassed:  JobSet{},
+			})
+		}
+
+		return candidates
+	}
+
+	passedJobs := map[int]JobSet{}
+	for _, output := range db.BuildOutputs {
+		if output.ResourceID != inputConfig.ResourceID || !inputConfig.Passed.Contains(output.JobID) {
+			continue
+		}
+
+		jobs, found := passedJobs[output.VersionID]
+		if !found {
+			jobs = JobSet{}
+			passedJobs[output.VersionID] = jobs
+		}
+
+		jobs[output.JobID] = struct{}{}
+	}
+
+	for _, v := range db.ResourceVersions {
+		if v.ResourceID != inputConfig.ResourceID {
+			continue
+		}
+
+		notPassed := JobSet{}
+		for jobID := range inputConfig.Passed {
+			if !passedJobs[v.VersionID].Contains(jobID) {
+				notPassed[jobID] = struct{}{}
+			}
+		}
+
+		candidates = append(candidates, VersionExplanation{
+			VersionID:  v.VersionID,
+			CheckOrder: v.CheckOrder,
+			NotPassed:  notPassed,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].CheckOrder > candidates[j].CheckOrder
+	})
+
+	return candidates
+}
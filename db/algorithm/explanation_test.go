@@ -0,0 +1,134 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+		explanations []algorithm.InputExplanation
+	)
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 22, CheckOrder: 1},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         32,
+					JobID:           12,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         33,
+					JobID:           11,
+				},
+			},
+			BuildInputs: []algorithm.BuildInput{},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12, "j3": 13},
+			ResourceIDs: map[string]int{"r1": 21, "r2": 22},
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanations = inputConfigs.Explain(versionsDB)
+	})
+
+	Context("when an input has no passed constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					Passed:     algorithm.JobSet{},
+					ResourceID: 21,
+					JobID:      13,
+				},
+			}
+		})
+
+		It("considers only the latest version", func() {
+			Expect(explanations).To(Equal([]algorithm.InputExplanation{
+				{
+					Name: "some-input",
+					Candidates: []algorithm.VersionExplanation{
+						{VersionID: 2, CheckOrder: 2, NotPassed: algorithm.JobSet{}},
+					},
+					IndependentVersionID: 2,
+					VersionID:            2,
+				},
+			}))
+		})
+	})
+
+	Context("when an input has passed constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					Passed:     algorithm.JobSet{11: struct{}{}, 12: struct{}{}},
+					ResourceID: 21,
+					JobID:      13,
+				},
+			}
+		})
+
+		It("considers every version, with the jobs each has not passed", func() {
+			Expect(explanations).To(Equal([]algorithm.InputExplanation{
+				{
+					Name: "some-input",
+					Candidates: []algorithm.VersionExplanation{
+						{VersionID: 2, CheckOrder: 2, NotPassed: algorithm.JobSet{12: struct{}{}}},
+						{VersionID: 1, CheckOrder: 1, NotPassed: algorithm.JobSet{}},
+					},
+					IndependentVersionID: 1,
+					VersionID:            1,
+				},
+			}))
+		})
+	})
+
+	Context("when one input cannot be resolved", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					Passed:     algorithm.JobSet{},
+					ResourceID: 21,
+					JobID:      13,
+				},
+				{
+					Name:       "some-other-input",
+					Passed:     algorithm.JobSet{11: struct{}{}},
+					ResourceID: 22,
+					JobID:      13,
+				},
+			}
+		})
+
+		It("resolves the other input independently but not together", func() {
+			Expect(explanations).To(HaveLen(2))
+
+			Expect(explanations[0].IndependentVersionID).To(Equal(2))
+			Expect(explanations[0].VersionID).To(BeZero())
+
+			Expect(explanations[1].Candidates).To(Equal([]algorithm.VersionExplanation{
+				{VersionID: 3, CheckOrder: 1, NotPassed: algorithm.JobSet{11: struct{}{}}},
+			}))
+			Expect(explanations[1].IndependentVersionID).To(BeZero())
+			Expect(explanations[1].VersionID).To(BeZero())
+		})
+	})
+})
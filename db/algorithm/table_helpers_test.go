@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/concourse/atc/db/algorithm"
 	. "github.com/onsi/gomega"
@@ -164,6 +165,10 @@ func (example Example) Run() {
 
 	resolved, ok := inputConfigs.Resolve(db)
 
+	incrementalResolved, incrementalOK := inputConfigs.Resolve(loadIncrementally(db))
+	Expect(incrementalOK).To(Equal(ok))
+	Expect(incrementalResolved).To(Equal(resolved))
+
 	prettyValues := map[string]string{}
 	for name, inputVersion := range resolved {
 		prettyValues[name] = versionIDs.Name(inputVersion.VersionID)
@@ -173,3 +178,70 @@ func (example Example) Run() {
 
 	Expect(actualResult).To(Equal(example.Result))
 }
+
+// loadIncrementally rebuilds the VersionsDB as though half of it had been
+// loaded before the rest was saved, with the last build loaded still running.
+func loadIncrementally(db *algorithm.VersionsDB) *algorithm.VersionsDB {
+	buildIDs := []int{}
+	seenBuildIDs := algorithm.BuildSet{}
+	for _, input := range db.BuildInputs {
+		if !seenBuildIDs.Contains(input.BuildID) {
+			seenBuildIDs[input.BuildID] = struct{}{}
+			buildIDs = append(buildIDs, input.BuildID)
+		}
+	}
+
+	for _, output := range db.BuildOutputs {
+		if !seenBuildIDs.Contains(output.BuildID) {
+			seenBuildIDs[output.BuildID] = struct{}{}
+			buildIDs = append(buildIDs, output.BuildID)
+		}
+	}
+
+	sort.Ints(buildIDs)
+
+	update := algorithm.VersionsDBUpdate{
+		BuildIDs:    algorithm.BuildSet{},
+		JobIDs:      db.JobIDs,
+		ResourceIDs: db.ResourceIDs,
+	}
+
+	runningBuildID := 0
+	if len(buildIDs) > 0 {
+		runningBuildID = buildIDs[len(buildIDs)/2]
+
+		for _, buildID := range buildIDs[len(buildIDs)/2:] {
+			update.BuildIDs[buildID] = struct{}{}
+		}
+	}
+
+	loadedVersions := len(db.ResourceVersions) / 2
+
+	loaded := &algorithm.VersionsDB{
+		ResourceVersions: db.ResourceVersions[:loadedVersions],
+		JobIDs:           db.JobIDs,
+		ResourceIDs:      db.ResourceIDs,
+	}
+
+	update.ResourceVersions = db.ResourceVersions[loadedVersions:]
+
+	for _, input := range db.BuildInputs {
+		if update.BuildIDs.Contains(input.BuildID) {
+			update.BuildInputs = append(update.BuildInputs, input)
+		}
+
+		if !update.BuildIDs.Contains(input.BuildID) || input.BuildID == runningBuildID {
+			loaded.BuildInputs = append(loaded.BuildInputs, input)
+		}
+	}
+
+	for _, output := range db.BuildOutputs {
+		if update.BuildIDs.Contains(output.BuildID) {
+			update.BuildOutputs = append(update.BuildOutputs, output)
+		} else {
+			loaded.BuildOutputs = append(loaded.BuildOutputs, output)
+		}
+	}
+
+	return loaded.Update(update)
+}
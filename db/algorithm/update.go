@@ -0,0 +1,50 @@
+package algorithm
+
+// VersionsDBUpdate holds the rows which have changed since a VersionsDB was
+// loaded.
+type VersionsDBUpdate struct {
+	// ResourceVersions are the versions saved since the VersionsDB was loaded.
+	ResourceVersions []ResourceVersion
+
+	// BuildIDs are the builds whose inputs and outputs are replaced by
+	// BuildInputs and BuildOutputs.
+	BuildIDs     BuildSet
+	BuildInputs  []BuildInput
+	BuildOutputs []BuildOutput
+
+	JobIDs      map[string]int
+	ResourceIDs map[string]int
+}
+
+// Update returns a copy of the VersionsDB with the update applied. The
+// VersionsDB itself is left untouched, as it may still be in use.
+func (db *VersionsDB) Update(update VersionsDBUpdate) *VersionsDB {
+	updated := &VersionsDB{
+		ResourceVersions: make([]ResourceVersion, 0, len(db.ResourceVersions)+len(update.ResourceVersions)),
+		BuildInputs:      make([]BuildInput, 0, len(db.BuildInputs)+len(update.BuildInputs)),
+		BuildOutputs:     make([]BuildOutput, 0, len(db.BuildOutputs)+len(update.BuildOutputs)),
+		JobIDs:           update.JobIDs,
+		ResourceIDs:      update.ResourceIDs,
+	}
+
+	updated.ResourceVersions = append(updated.ResourceVersions, db.ResourceVersions...)
+	updated.ResourceVersions = append(updated.ResourceVersions, update.ResourceVersions...)
+
+	for _, input := range db.BuildInputs {
+		if !update.BuildIDs.Contains(input.BuildID) {
+			updated.BuildInputs = append(updated.BuildInputs, input)
+		}
+	}
+
+	updated.BuildInputs = append(updated.BuildInputs, update.BuildInputs...)
+
+	for _, output := range db.BuildOutputs {
+		if !update.BuildIDs.Contains(output.BuildID) {
+			updated.BuildOutputs = append(updated.BuildOutputs, output)
+		}
+	}
+
+	updated.BuildOutputs = append(updated.BuildOutputs, update.BuildOutputs...)
+
+	return updated
+}
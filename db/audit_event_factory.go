@@ -0,0 +1,170 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AuditEvent records a single mutating API call: who made it, against what,
+// and how it turned out.
+type AuditEvent struct {
+	ID       int
+	Time     time.Time
+	UserName string
+	TeamName string
+	Route    string
+	Target   map[string]string
+	Status   int
+}
+
+//go:generate counterfeiter . AuditEventFactory
+
+type AuditEventFactory interface {
+	SaveAuditEvent(AuditEvent) error
+	AuditEvents(Page) ([]AuditEvent, Pagination, error)
+	DeleteAuditEventsOlderThan(time.Duration) (int, error)
+}
+
+type auditEventFactory struct {
+	conn Conn
+}
+
+func NewAuditEventFactory(conn Conn) AuditEventFactory {
+	return &auditEventFactory{
+		conn: conn,
+	}
+}
+
+var auditEventsQuery = psql.Select("id", "time", "user_name", "team_name", "route", "target", "status").
+	From("audit_events")
+
+func (f *auditEventFactory) SaveAuditEvent(event AuditEvent) error {
+	target := event.Target
+	if target == nil {
+		target = map[string]string{}
+	}
+
+	targetJSON, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("audit_events").
+		Columns("time", "user_name", "team_name", "route", "target", "status").
+		Values(event.Time, event.UserName, event.TeamName, event.Route, targetJSON, event.Status).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *auditEventFactory) AuditEvents(page Page) ([]AuditEvent, Pagination, error) {
+	query := auditEventsQuery
+
+	var reverse bool
+	if page.Since == 0 && page.Until == 0 {
+		query = query.OrderBy("id DESC").Limit(uint64(page.Limit))
+	} else if page.Until != 0 {
+		query = query.Where(sq.Gt{"id": page.Until}).OrderBy("id ASC").Limit(uint64(page.Limit))
+		reverse = true
+	} else {
+		query = query.Where(sq.Lt{"id": page.Since}).OrderBy("id DESC").Limit(uint64(page.Limit))
+	}
+
+	rows, err := query.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	events := []AuditEvent{}
+
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	if reverse {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var minID int
+	var maxID int
+	err = psql.Select("COALESCE(MAX(id), 0)", "COALESCE(MIN(id), 0)").
+		From("audit_events").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
+
+func (f *auditEventFactory) DeleteAuditEventsOlderThan(retention time.Duration) (int, error) {
+	result, err := psql.Delete("audit_events").
+		Where(sq.Expr("time < now() - (? || ' SECONDS')::INTERVAL", retention.Seconds())).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func scanAuditEvent(row scannable) (AuditEvent, error) {
+	var (
+		event      AuditEvent
+		targetJSON []byte
+	)
+
+	err := row.Scan(&event.ID, &event.Time, &event.UserName, &event.TeamName, &event.Route, &targetJSON, &event.Status)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+
+	err = json.Unmarshal(targetJSON, &event.Target)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,134 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventFactory", func() {
+	var auditEventFactory db.AuditEventFactory
+
+	BeforeEach(func() {
+		auditEventFactory = db.NewAuditEventFactory(dbConn)
+	})
+
+	saveEvent := func(route string, at time.Time) {
+		err := auditEventFactory.SaveAuditEvent(db.AuditEvent{
+			Time:     at,
+			UserName: "some-user",
+			TeamName: "some-team",
+			Route:    route,
+			Target:   map[string]string{"pipeline_name": "some-pipeline"},
+			Status:   204,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("SaveAuditEvent", func() {
+		It("can be listed back", func() {
+			now := time.Now().Truncate(time.Second)
+			saveEvent("DeletePipeline", now)
+
+			events, _, err := auditEventFactory.AuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			Expect(events[0].ID).NotTo(BeZero())
+			Expect(events[0].Time.Unix()).To(Equal(now.Unix()))
+			Expect(events[0].UserName).To(Equal("some-user"))
+			Expect(events[0].TeamName).To(Equal("some-team"))
+			Expect(events[0].Route).To(Equal("DeletePipeline"))
+			Expect(events[0].Target).To(Equal(map[string]string{"pipeline_name": "some-pipeline"}))
+			Expect(events[0].Status).To(Equal(204))
+		})
+
+		It("saves an event without a target", func() {
+			err := auditEventFactory.SaveAuditEvent(db.AuditEvent{
+				Time:   time.Now(),
+				Route:  "SetTeam",
+				Status: 201,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, _, err := auditEventFactory.AuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Target).To(BeEmpty())
+		})
+	})
+
+	Describe("AuditEvents", func() {
+		var ids []int
+
+		BeforeEach(func() {
+			for _, route := range []string{"PausePipeline", "UnpausePipeline", "PauseJob"} {
+				saveEvent(route, time.Now())
+			}
+
+			events, _, err := auditEventFactory.AuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			ids = []int{}
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+		})
+
+		It("returns the newest events first", func() {
+			events, pagination, err := auditEventFactory.AuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Route).To(Equal("PauseJob"))
+			Expect(events[2].Route).To(Equal("PausePipeline"))
+
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(BeNil())
+		})
+
+		It("paginates with a limit", func() {
+			events, pagination, err := auditEventFactory.AuditEvents(db.Page{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(HaveLen(2))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: ids[1], Limit: 2}))
+
+			events, pagination, err = auditEventFactory.AuditEvents(*pagination.Next)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(ids[2]))
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: ids[2], Limit: 2}))
+			Expect(pagination.Next).To(BeNil())
+
+			events, _, err = auditEventFactory.AuditEvents(*pagination.Previous)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(ids[0]))
+			Expect(events[1].ID).To(Equal(ids[1]))
+		})
+	})
+
+	Describe("DeleteAuditEventsOlderThan", func() {
+		BeforeEach(func() {
+			saveEvent("DeletePipeline", time.Now().Add(-48*time.Hour))
+			saveEvent("SaveConfig", time.Now())
+		})
+
+		It("deletes only the events older than the retention period", func() {
+			deleted, err := auditEventFactory.DeleteAuditEventsOlderThan(24 * time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(1))
+
+			events, _, err := auditEventFactory.AuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Route).To(Equal("SaveConfig"))
+		})
+	})
+})
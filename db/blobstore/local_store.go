@@ -0,0 +1,77 @@
+package blobstore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore keeps blobs as files in a directory on the local filesystem.
+// Keys may contain slashes, which are mapped to subdirectories.
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{
+		dir: dir,
+	}
+}
+
+func (store *LocalStore) Put(key string, contents io.Reader) error {
+	path := store.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that readers never see a partially
+	// written blob
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, contents)
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (store *LocalStore) Get(key string) (io.ReadCloser, error) {
+	file, err := os.Open(store.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrBlobNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (store *LocalStore) Delete(key string) error {
+	err := os.Remove(store.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *LocalStore) path(key string) string {
+	return filepath.Join(store.dir, filepath.FromSlash(filepath.Clean("/"+key)))
+}
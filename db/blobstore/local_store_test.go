@@ -0,0 +1,112 @@
+package blobstore_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/db/blobstore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalStore", func() {
+	var (
+		dir   string
+		store *blobstore.LocalStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "local-store")
+		Expect(err).ToNot(HaveOccurred())
+
+		store = blobstore.NewLocalStore(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	readBlob := func(key string) string {
+		blob, err := store.Get(key)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer blob.Close()
+
+		contents, err := ioutil.ReadAll(blob)
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(contents)
+	}
+
+	It("returns the contents of a blob that was put", func() {
+		err := store.Put("some/key", bytes.NewBufferString("some-contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(readBlob("some/key")).To(Equal("some-contents"))
+	})
+
+	It("overwrites a blob that is put again", func() {
+		err := store.Put("some-key", bytes.NewBufferString("some-contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = store.Put("some-key", bytes.NewBufferString("some-other-contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(readBlob("some-key")).To(Equal("some-other-contents"))
+	})
+
+	It("keeps blobs within the directory", func() {
+		err := store.Put("../../some-key", bytes.NewBufferString("some-contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(filepath.Join(dir, "some-key")).To(BeAnExistingFile())
+	})
+
+	Context("when the blob does not exist", func() {
+		It("returns ErrBlobNotFound", func() {
+			_, err := store.Get("bogus-key")
+			Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+		})
+	})
+
+	Context("when reading the contents fails", func() {
+		It("does not leave a blob behind", func() {
+			err := store.Put("some-key", &failingReader{})
+			Expect(err).To(HaveOccurred())
+
+			_, err = store.Get("some-key")
+			Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		})
+	})
+
+	Describe("Delete", func() {
+		It("removes the blob", func() {
+			err := store.Put("some-key", bytes.NewBufferString("some-contents"))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = store.Delete("some-key")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = store.Get("some-key")
+			Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+		})
+
+		It("succeeds when the blob does not exist", func() {
+			Expect(store.Delete("bogus-key")).To(Succeed())
+		})
+	})
+})
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("nope")
+}
@@ -0,0 +1,18 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+//go:generate counterfeiter . Store
+
+// Store is a place to keep large blobs of data, such as archived build logs,
+// outside of the database.
+type Store interface {
+	Put(key string, contents io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
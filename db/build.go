@@ -1,16 +1,19 @@
 package db
 
 import (
+	"compress/gzip"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/blobstore"
 	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/event"
@@ -28,7 +31,7 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by, COALESCE(j.priority, 0)").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -41,6 +44,7 @@ type Build interface {
 	Name() string
 	JobID() int
 	JobName() string
+	Priority() int
 	PipelineID() int
 	PipelineName() string
 	TeamID() int
@@ -73,6 +77,7 @@ type Build interface {
 
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
+	ArchiveEvents() error
 
 	SaveInput(input BuildInput) error
 	SaveOutput(vr VersionedResource) error
@@ -103,6 +108,7 @@ type build struct {
 	pipelineName string
 	jobID        int
 	jobName      string
+	priority     int
 
 	isManuallyTriggered bool
 
@@ -126,6 +132,7 @@ func (b *build) ID() int                      { return b.id }
 func (b *build) Name() string                 { return b.name }
 func (b *build) JobID() int                   { return b.jobID }
 func (b *build) JobName() string              { return b.jobName }
+func (b *build) Priority() int                { return b.priority }
 func (b *build) PipelineID() int              { return b.pipelineID }
 func (b *build) PipelineName() string         { return b.pipelineName }
 func (b *build) TeamID() int                  { return b.teamID }
@@ -355,7 +362,66 @@ func (b *build) Finish(status BuildStatus) error {
 	}
 
 	_, err = b.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY transition_builds_per_job`)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if b.pipelineID != 0 {
+		notifyScheduler(b.conn, b.pipelineID)
+	}
+
+	if b.jobID != 0 {
+		// the build has finished regardless; downstream pipelines which aren't
+		// notified are scheduled on their next interval
+		_ = b.notifyDownstreamPipelines()
+	}
+
+	return nil
+}
+
+// notifyDownstreamPipelines notifies the other pipelines of the team with
+// jobs that have passed constraints on the build's job, so that they are
+// scheduled right away rather than on the next interval. The jobs are found
+// by the upstream jobs stored when their pipeline was saved.
+func (b *build) notifyDownstreamPipelines() error {
+	rows, err := psql.Select("DISTINCT j.pipeline_id").
+		From("jobs j").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{
+			"p.team_id": b.teamID,
+			"j.active":  true,
+		}).
+		Where(sq.NotEq{"p.id": b.pipelineID}).
+		Where(sq.Expr("j.upstream_jobs @> ARRAY[?]", b.pipelineName+"/"+b.jobName)).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	pipelineIDs := []int{}
+	for rows.Next() {
+		var pipelineID int
+		err = rows.Scan(&pipelineID)
+		if err != nil {
+			return err
+		}
+
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		notifyScheduler(b.conn, pipelineID)
+	}
+
+	return nil
 }
 
 func (b *build) Delete() (bool, error) {
@@ -683,20 +749,148 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newBuildEventSource(
 		b.id,
-		table,
+		b.eventsTable(),
 		b.conn,
 		notifier,
 		from,
 	), nil
 }
 
+// ArchiveEvents moves the events of a completed build out of the database
+// and into the connection's build log store. Events continues to return them
+// afterwards. A failed attempt is counted so that builds which keep failing
+// to archive are retried after the others.
+func (b *build) ArchiveEvents() error {
+	store := b.conn.BuildLogStore()
+	if store == nil {
+		return ErrNoBuildLogStore
+	}
+
+	err := b.archiveEvents(store)
+	if err != nil {
+		_, _ = psql.Update("builds").
+			Set("events_archive_attempts", sq.Expr("events_archive_attempts + 1")).
+			Where(sq.Eq{"id": b.id}).
+			RunWith(b.conn).
+			Exec()
+
+		return err
+	}
+
+	return nil
+}
+
+func (b *build) archiveEvents(store blobstore.Store) error {
+	var completed, archived bool
+	err := psql.Select("completed", "events_archived").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&completed, &archived)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBuildDisappeared
+		}
+
+		return err
+	}
+
+	if archived {
+		return nil
+	}
+
+	if !completed {
+		return ErrBuildNotCompleted
+	}
+
+	rows, err := b.conn.Query(`
+		SELECT type, version, payload
+		FROM `+b.eventsTable()+`
+		WHERE build_id = $1
+		ORDER BY event_id ASC
+	`, b.id)
+	if err != nil {
+		return err
+	}
+
+	archive, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(writeEventsArchive(writer, rows))
+	}()
+
+	err = store.Put(buildEventsArchiveKey(b.id), archive)
+
+	// unblocks the writer if the store gave up before reading everything
+	_ = archive.Close()
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("builds").
+		Set("events_archived", true).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func writeEventsArchive(w io.Writer, rows *sql.Rows) error {
+	defer Close(rows)
+
+	compressor := gzip.NewWriter(w)
+	encoder := json.NewEncoder(compressor)
+
+	for rows.Next() {
+		var t, v, p string
+		err := rows.Scan(&t, &v, &p)
+		if err != nil {
+			return err
+		}
+
+		data := json.RawMessage(p)
+
+		err = encoder.Encode(event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return err
+	}
+
+	return compressor.Close()
+}
+
 func (b *build) SaveEvent(event atc.Event) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -987,7 +1181,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		status string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy, &b.priority)
 	if err != nil {
 		return err
 	}
@@ -1034,11 +1228,7 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 		return err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-	_, err = psql.Insert(table).
+	_, err = psql.Insert(b.eventsTable()).
 		Columns("event_id", "build_id", "type", "version", "payload").
 		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
 		RunWith(tx).
@@ -1046,6 +1236,14 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 	return err
 }
 
+func (b *build) eventsTable() string {
+	if b.pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
+}
+
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 	var buildID int
 	err := psql.Insert("builds").
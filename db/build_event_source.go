@@ -1,8 +1,10 @@
 package db
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"errors"
+	"io"
 	"sync"
 
 	"github.com/concourse/atc"
@@ -97,18 +99,25 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 
 		completed := false
+		archived := false
 
 		err := source.conn.QueryRow(`
-			SELECT builds.completed
+			SELECT builds.completed, builds.events_archived
 			FROM builds
 			WHERE builds.id = $1
-		`, source.buildID).Scan(&completed)
+		`, source.buildID).Scan(&completed, &archived)
 		if err != nil {
 			source.err = err
 			close(source.events)
 			return
 		}
 
+		if archived {
+			source.err = source.collectArchivedEvents(cursor)
+			close(source.events)
+			return
+		}
+
 		rows, err := source.conn.Query(`
 			SELECT type, version, payload
 			FROM `+source.table+`
@@ -165,6 +174,22 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 
 		if completed {
+			// the events may have been archived since the build was checked
+			err := source.conn.QueryRow(`
+				SELECT builds.events_archived
+				FROM builds
+				WHERE builds.id = $1
+			`, source.buildID).Scan(&archived)
+			if err != nil {
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			if archived {
+				continue
+			}
+
 			source.err = ErrEndOfBuildEventStream
 			close(source.events)
 			return
@@ -179,3 +204,48 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 	}
 }
+
+func (source *buildEventSource) collectArchivedEvents(cursor uint) error {
+	store := source.conn.BuildLogStore()
+	if store == nil {
+		return ErrNoBuildLogStore
+	}
+
+	blob, err := store.Get(buildEventsArchiveKey(source.buildID))
+	if err != nil {
+		return err
+	}
+
+	defer blob.Close()
+
+	reader, err := gzip.NewReader(blob)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(reader)
+
+	var skipped uint
+	for {
+		var ev event.Envelope
+		err := decoder.Decode(&ev)
+		if err != nil {
+			if err == io.EOF {
+				return ErrEndOfBuildEventStream
+			}
+
+			return err
+		}
+
+		if skipped < cursor {
+			skipped++
+			continue
+		}
+
+		select {
+		case source.events <- ev:
+		case <-source.stop:
+			return ErrBuildEventStreamClosed
+		}
+	}
+}
@@ -14,6 +14,8 @@ type BuildFactory interface {
 	VisibleBuilds([]string, Page) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
+	GetCompletedBuildsToArchive(limit int) ([]Build, error)
+	GetBuildsBlockingWorkerDrain(workerName string) ([]Build, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
 }
@@ -118,6 +120,71 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	return bs, nil
 }
 
+// GetBuildsBlockingWorkerDrain returns the builds with containers on the
+// worker which keep it from landing or retiring.
+func (f *buildFactory) GetBuildsBlockingWorkerDrain(workerName string) ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(drainBlockingBuild).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM containers c WHERE c.build_id = b.id AND c.worker_name = ?)", workerName)).
+		OrderBy("b.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	bs := []Build{}
+
+	for rows.Next() {
+		b := &build{conn: f.conn, lockFactory: f.lockFactory}
+		err := scanBuild(b, rows, f.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, b)
+	}
+
+	return bs, nil
+}
+
+// GetCompletedBuildsToArchive returns up to limit of the oldest completed
+// builds whose events are still in the database, starting with those which
+// failed to be archived the fewest times.
+func (f *buildFactory) GetCompletedBuildsToArchive(limit int) ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.completed":       true,
+			"b.events_archived": false,
+			"b.reap_time":       nil,
+		}).
+		OrderBy("b.events_archive_attempts ASC", "b.id ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	bs := []Build{}
+
+	for rows.Next() {
+		b := &build{conn: f.conn, lockFactory: f.lockFactory}
+		err := scanBuild(b, rows, f.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, b)
+	}
+
+	return bs, nil
+}
+
 func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
 	var rows *sql.Rows
 	var err error
@@ -98,7 +98,7 @@ var _ = Describe("BuildFactory", func() {
 							Name: "some-other-job",
 						},
 					},
-				}, db.ConfigVersion(0), db.PipelineUnpaused)
+				}, db.ConfigVersion(0), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				j, found, err := p.Job("some-other-job")
@@ -199,7 +199,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -209,7 +209,7 @@ var _ = Describe("BuildFactory", func() {
 			build2, err = privateJob.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 			err = publicPipeline.Expose()
 			Expect(err).NotTo(HaveOccurred())
@@ -246,7 +246,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -256,7 +256,7 @@ var _ = Describe("BuildFactory", func() {
 			_, err = privateJob.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 			err = publicPipeline.Expose()
 			Expect(err).NotTo(HaveOccurred())
@@ -289,7 +289,7 @@ var _ = Describe("BuildFactory", func() {
 						Name: "some-job",
 					},
 				},
-			}, db.ConfigVersion(0), db.PipelineUnpaused)
+			}, db.ConfigVersion(0), db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -326,4 +326,76 @@ var _ = Describe("BuildFactory", func() {
 			Expect(builds).To(ConsistOf(build1DB, build2DB))
 		})
 	})
+
+	Describe("GetBuildsBlockingWorkerDrain", func() {
+		var (
+			blockingBuild      db.Build
+			interruptibleBuild db.Build
+			finishedBuild      db.Build
+		)
+
+		BeforeEach(func() {
+			pipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+					},
+					{
+						Name:          "interruptible-job",
+						Interruptible: true,
+					},
+				},
+			}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			interruptibleJob, found, err := pipeline.Job("interruptible-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			blockingBuild, err = job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			interruptibleBuild, err = interruptibleJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			finishedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			otherWorkerBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			otherWorkerPayload := defaultWorkerPayload
+			otherWorkerPayload.Name = "other-worker"
+			otherWorker, err := workerFactory.SaveWorker(otherWorkerPayload, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, build := range []db.Build{blockingBuild, interruptibleBuild, finishedBuild} {
+				_, err = build.Start("exec.v2", "{}", atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = team.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-plan")), db.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err = otherWorkerBuild.Start("exec.v2", "{}", atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = team.CreateContainer(otherWorker.Name(), db.NewBuildStepContainerOwner(otherWorkerBuild.ID(), atc.PlanID("some-plan")), db.ContainerMetadata{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the unfinished builds of uninterruptible jobs with containers on the worker", func() {
+			builds, err := buildFactory.GetBuildsBlockingWorkerDrain(defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(blockingBuild.ID()))
+		})
+	})
 })
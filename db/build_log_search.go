@@ -0,0 +1,209 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
+)
+
+// logSearchVector must match the expression of the *_log_search indexes on
+// the build events tables for them to be used.
+const logSearchVector = `to_tsvector('simple', (e.payload::jsonb)->>'payload')`
+
+// anyLogSearchTerm matches an event which logged any one of the words of the
+// query given as its placeholder.
+const anyLogSearchTerm = `replace(plainto_tsquery('simple', %s)::text, ' & ', ' | ')::tsquery`
+
+// buildLogOutput reassembles the log output of each origin of the builds
+// matching the given condition, as log events are not split on line
+// boundaries.
+const buildLogOutput = `
+	SELECT
+		build_id,
+		(payload::jsonb)->'origin' AS origin,
+		min(event_id) AS first_event_id,
+		string_agg((payload::jsonb)->>'payload', '' ORDER BY event_id) AS text
+	FROM build_events
+	WHERE type = 'log'
+	AND %s
+	GROUP BY build_id, (payload::jsonb)->'origin'
+`
+
+// buildLogLineMatches is the condition for a line of reassembled output to
+// match the query given as its placeholder.
+const buildLogLineMatches = `to_tsvector('simple', line) @@ plainto_tsquery('simple', %s)`
+
+// BuildLogSearch searches the log lines of builds. The events of archived
+// builds are no longer in the database, so those builds are never matched.
+type BuildLogSearch struct {
+	Query string
+
+	// From and To bound the start time of the searched builds. Either may be
+	// left zero.
+	From time.Time
+	To   time.Time
+
+	// JobIDs restricts the search to builds of the given jobs when non-nil.
+	JobIDs []int
+
+	// Context is the number of lines to return either side of each match.
+	Context int
+}
+
+type BuildLogSearchResult struct {
+	Build   Build
+	Matches []BuildLogMatch
+}
+
+type BuildLogMatch struct {
+	Origin event.Origin
+	Line   int
+	Text   string
+	Before []string
+	After  []string
+}
+
+func createBuildLogSearchIndex(tx Tx, table string) error {
+	_, err := tx.Exec(fmt.Sprintf(`
+		CREATE INDEX %[1]s_log_search ON %[1]s USING gin (to_tsvector('simple', (payload::jsonb)->>'payload')) WHERE type = 'log'
+	`, table))
+	return err
+}
+
+// searchBuildLogs pages through the builds matching scope which logged a
+// line matching the search, and returns the matching lines of each.
+//
+// Reassembling every build's output into lines is too slow to do for all of
+// the builds in scope, so the *_log_search indexes are first used to pick the
+// builds which logged any of the query's words within a single event. A line
+// is therefore only missed if each of its matching words was split across
+// two events.
+func searchBuildLogs(conn Conn, lockFactory lock.LockFactory, scope sq.Sqlizer, search BuildLogSearch, page Page) ([]BuildLogSearchResult, Pagination, error) {
+	query := buildsQuery.
+		Where(scope).
+		Where(sq.Eq{"b.events_archived": false}).
+		Where(sq.Expr(`b.id IN (
+			SELECT e.build_id
+			FROM build_events e
+			WHERE e.type = 'log'
+			AND `+logSearchVector+` @@ `+fmt.Sprintf(anyLogSearchTerm, "?")+`
+		)`, search.Query)).
+		Where(sq.Expr(`EXISTS (
+			SELECT 1
+			FROM (`+fmt.Sprintf(buildLogOutput, "build_id = b.id")+`) o,
+				regexp_split_to_table(o.text, E'\n') AS l(line)
+			WHERE `+fmt.Sprintf(buildLogLineMatches, "?")+`
+		)`, search.Query))
+
+	if !search.From.IsZero() {
+		query = query.Where(sq.GtOrEq{"b.start_time": search.From})
+	}
+
+	if !search.To.IsZero() {
+		query = query.Where(sq.LtOrEq{"b.start_time": search.To})
+	}
+
+	if search.JobIDs != nil {
+		if len(search.JobIDs) == 0 {
+			return []BuildLogSearchResult{}, Pagination{}, nil
+		}
+
+		query = query.Where(sq.Eq{"b.job_id": search.JobIDs})
+	}
+
+	builds, pagination, err := getBuildsWithPagination(query, page, conn, lockFactory)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	buildIDs := make([]int, len(builds))
+	for i, build := range builds {
+		buildIDs[i] = build.ID()
+	}
+
+	matches, err := buildLogMatches(conn, buildIDs, search)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	results := []BuildLogSearchResult{}
+	for _, build := range builds {
+		buildMatches := matches[build.ID()]
+		if len(buildMatches) == 0 {
+			continue
+		}
+
+		results = append(results, BuildLogSearchResult{
+			Build:   build,
+			Matches: buildMatches,
+		})
+	}
+
+	return results, pagination, nil
+}
+
+// buildLogMatches splits the output of each origin of the given builds into
+// lines and returns the lines matching the search along with their
+// surrounding lines.
+func buildLogMatches(conn Conn, buildIDs []int, search BuildLogSearch) (map[int][]BuildLogMatch, error) {
+	matches := map[int][]BuildLogMatch{}
+	if len(buildIDs) == 0 {
+		return matches, nil
+	}
+
+	before := "NULL::text[]"
+	after := "NULL::text[]"
+	if search.Context > 0 {
+		before = fmt.Sprintf("array_agg(l.line) OVER (PARTITION BY o.build_id, o.origin ORDER BY l.n ROWS BETWEEN %d PRECEDING AND 1 PRECEDING)", search.Context)
+		after = fmt.Sprintf("array_agg(l.line) OVER (PARTITION BY o.build_id, o.origin ORDER BY l.n ROWS BETWEEN 1 FOLLOWING AND %d FOLLOWING)", search.Context)
+	}
+
+	rows, err := conn.Query(`
+		WITH output AS (`+fmt.Sprintf(buildLogOutput, "build_id = ANY($1)")+`)
+		SELECT build_id, origin, n, line, before, after
+		FROM (
+			SELECT o.build_id, o.origin, o.first_event_id, l.n, l.line,
+				`+before+` AS before,
+				`+after+` AS after
+			FROM output o, regexp_split_to_table(o.text, E'\n') WITH ORDINALITY AS l(line, n)
+		) lines
+		WHERE `+fmt.Sprintf(buildLogLineMatches, "$2")+`
+		ORDER BY build_id, first_event_id, n
+	`, pq.Array(buildIDs), search.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			buildID       int
+			origin        []byte
+			match         BuildLogMatch
+			before, after pq.StringArray
+		)
+
+		err = rows.Scan(&buildID, &origin, &match.Line, &match.Text, &before, &after)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(origin, &match.Origin)
+		if err != nil {
+			return nil, err
+		}
+
+		match.Before = []string(before)
+		match.After = []string(after)
+
+		matches[buildID] = append(matches[buildID], match)
+	}
+
+	return matches, nil
+}
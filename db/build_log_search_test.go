@@ -0,0 +1,206 @@
+package db_test
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/blobstore"
+	"github.com/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SearchBuildLogs", func() {
+	var (
+		jobBuild     db.Build
+		oneOffBuild  db.Build
+		stepOrigin   event.Origin
+		otherOrigin  event.Origin
+		search       db.BuildLogSearch
+		page         db.Page
+		matchedLines func([]db.BuildLogSearchResult) []string
+	)
+
+	BeforeEach(func() {
+		var err error
+		jobBuild, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = jobBuild.Start("exec.v2", "{}", atc.Plan{})
+		Expect(err).NotTo(HaveOccurred())
+
+		stepOrigin = event.Origin{ID: "some-step", Source: event.OriginSourceStdout}
+		otherOrigin = event.Origin{ID: "other-step", Source: event.OriginSourceStdout}
+
+		for _, e := range []event.Log{
+			{Origin: stepOrigin, Payload: "fetching\ndialing\ncon"},
+			{Origin: otherOrigin, Payload: "unrelated connection output\n"},
+			{Origin: stepOrigin, Payload: "nection reset by peer\nretrying\ngiving up\n"},
+		} {
+			err = jobBuild.SaveEvent(e)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = oneOffBuild.Start("exec.v2", "{}", atc.Plan{})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = oneOffBuild.SaveEvent(event.Log{Origin: stepOrigin, Payload: "connection reset\n"})
+		Expect(err).NotTo(HaveOccurred())
+
+		search = db.BuildLogSearch{Query: "connection reset", Context: 1}
+		page = db.Page{Limit: 10}
+
+		matchedLines = func(results []db.BuildLogSearchResult) []string {
+			lines := []string{}
+			for _, result := range results {
+				for _, match := range result.Matches {
+					lines = append(lines, match.Text)
+				}
+			}
+			return lines
+		}
+	})
+
+	It("returns the matching lines of each build with their origin and surrounding lines", func() {
+		results, _, err := defaultJob.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Build.ID()).To(Equal(jobBuild.ID()))
+		Expect(results[0].Matches).To(Equal([]db.BuildLogMatch{
+			{
+				Origin: stepOrigin,
+				Line:   3,
+				Text:   "connection reset by peer",
+				Before: []string{"dialing"},
+				After:  []string{"retrying"},
+			},
+		}))
+	})
+
+	It("does not return surrounding lines without context", func() {
+		search.Context = 0
+
+		results, _, err := defaultJob.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Matches[0].Before).To(BeEmpty())
+		Expect(results[0].Matches[0].After).To(BeEmpty())
+	})
+
+	It("scopes the search to the team, pipeline or job", func() {
+		results, _, err := defaultTeam.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Build.ID()).To(Equal(oneOffBuild.ID()))
+		Expect(results[1].Build.ID()).To(Equal(jobBuild.ID()))
+
+		results, _, err = defaultPipeline.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Build.ID()).To(Equal(jobBuild.ID()))
+	})
+
+	It("does not return builds without a matching line", func() {
+		search.Query = "timeout"
+
+		results, _, err := defaultTeam.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+	})
+
+	It("does not match words from different lines of the same event", func() {
+		search.Query = "fetching dialing"
+
+		results, _, err := defaultTeam.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+	})
+
+	Context("when a build's events have been archived", func() {
+		var archiveDir string
+
+		BeforeEach(func() {
+			var err error
+			archiveDir, err = ioutil.TempDir("", "build-log-archive")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = oneOffBuild.Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			archivingConn := db.WithBuildLogStore(dbConn, blobstore.NewLocalStore(archiveDir))
+
+			archivingBuild, found, err := db.NewBuildFactory(archivingConn, lockFactory).Build(oneOffBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = archivingBuild.ArchiveEvents()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(archiveDir)).To(Succeed())
+		})
+
+		It("is not searched", func() {
+			results, _, err := defaultTeam.SearchBuildLogs(search, page)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Build.ID()).To(Equal(jobBuild.ID()))
+		})
+	})
+
+	It("only searches builds started within the time range", func() {
+		search.From = time.Now().Add(time.Hour)
+
+		results, _, err := defaultTeam.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+
+		search.From = time.Now().Add(-time.Hour)
+		search.To = time.Now().Add(time.Hour)
+
+		results, _, err = defaultTeam.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matchedLines(results)).To(ConsistOf("connection reset", "connection reset by peer"))
+	})
+
+	Context("when restricted to jobs", func() {
+		It("only searches the builds of those jobs", func() {
+			search.JobIDs = []int{defaultJob.ID()}
+
+			results, _, err := defaultTeam.SearchBuildLogs(search, page)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Build.ID()).To(Equal(jobBuild.ID()))
+		})
+
+		It("searches nothing when no jobs are given", func() {
+			search.JobIDs = []int{}
+
+			results, _, err := defaultTeam.SearchBuildLogs(search, page)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
+		})
+	})
+
+	It("paginates over the matching builds", func() {
+		page.Limit = 1
+
+		results, pagination, err := defaultTeam.SearchBuildLogs(search, page)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Build.ID()).To(Equal(oneOffBuild.ID()))
+		Expect(pagination.Next).To(Equal(&db.Page{Since: oneOffBuild.ID(), Limit: 1}))
+
+		results, _, err = defaultTeam.SearchBuildLogs(search, *pagination.Next)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Build.ID()).To(Equal(jobBuild.ID()))
+	})
+})
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/blobstore"
+)
+
+var ErrNoBuildLogStore = errors.New("no build log store is configured")
+var ErrBuildNotCompleted = errors.New("build has not completed")
+
+// WithBuildLogStore returns a Conn whose builds archive their events to the
+// given store, and which reads back the events of archived builds from it.
+func WithBuildLogStore(conn Conn, store blobstore.Store) Conn {
+	return &buildLogStoreConn{
+		Conn:  conn,
+		store: store,
+	}
+}
+
+type buildLogStoreConn struct {
+	Conn
+
+	store blobstore.Store
+}
+
+func (c *buildLogStoreConn) BuildLogStore() blobstore.Store {
+	return c.store
+}
+
+func buildEventsArchiveKey(buildID int) string {
+	return fmt.Sprintf("build-events/%d.json.gz", buildID)
+}
+
+// findArchivedBuildIDs returns the IDs of the builds matching the condition whose
+// events have been archived, so that their archives can be deleted along with
+// them.
+func findArchivedBuildIDs(tx Tx, condition sq.Sqlizer) ([]int, error) {
+	rows, err := psql.Select("id").
+		From("builds").
+		Where(condition).
+		Where(sq.Eq{"events_archived": true}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// deleteBuildEventsArchives deletes the archived events of the builds from
+// the connection's build log store, if it has one.
+func deleteBuildEventsArchives(conn Conn, buildIDs []int) error {
+	store := conn.BuildLogStore()
+	if store == nil {
+		return nil
+	}
+
+	for _, id := range buildIDs {
+		err := store.Delete(buildEventsArchiveKey(id))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,10 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/db/blobstore"
+	"github.com/concourse/atc/db/blobstore/blobstorefakes"
 	"github.com/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -317,6 +323,289 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("ArchiveEvents", func() {
+		var (
+			archiveDir     string
+			archivingConn  db.Conn
+			build          db.Build
+			archivingBuild db.Build
+		)
+
+		countEvents := func() int {
+			var count int
+			err := psql.Select("COUNT(*)").
+				From("build_events").
+				Where(sq.Eq{"build_id": build.ID()}).
+				RunWith(dbConn).
+				QueryRow().
+				Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			return count
+		}
+
+		BeforeEach(func() {
+			var err error
+			archiveDir, err = ioutil.TempDir("", "build-log-archive")
+			Expect(err).NotTo(HaveOccurred())
+
+			archivingConn = db.WithBuildLogStore(dbConn, blobstore.NewLocalStore(archiveDir))
+
+			build, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some "})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			archivingBuild, found, err = db.NewBuildFactory(archivingConn, lockFactory).Build(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(archiveDir)).To(Succeed())
+		})
+
+		Context("when the build has completed", func() {
+			var statusEvent event.Envelope
+
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				statusEvent = envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				})
+			})
+
+			It("moves the events out of the database", func() {
+				Expect(countEvents()).To(Equal(3))
+
+				err := archivingBuild.ArchiveEvents()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(countEvents()).To(BeZero())
+			})
+
+			It("streams the archived events back", func() {
+				err := archivingBuild.ArchiveEvents()
+				Expect(err).NotTo(HaveOccurred())
+
+				events, err := archivingBuild.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some "})))
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "log"})))
+				Expect(events.Next()).To(Equal(statusEvent))
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("streams the archived events back from an offset", func() {
+				err := archivingBuild.ArchiveEvents()
+				Expect(err).NotTo(HaveOccurred())
+
+				events, err := archivingBuild.Events(2)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(statusEvent))
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("keeps streaming the events to subscribers who started before it was archived", func() {
+				events, err := archivingBuild.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some "})))
+
+				err = archivingBuild.ArchiveEvents()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "log"})))
+				Expect(events.Next()).To(Equal(statusEvent))
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("is no longer returned as a build to archive", func() {
+				builds, err := buildFactory.GetCompletedBuildsToArchive(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(build.ID()))
+
+				err = archivingBuild.ArchiveEvents()
+				Expect(err).NotTo(HaveOccurred())
+
+				builds, err = buildFactory.GetCompletedBuildsToArchive(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+
+			Context("when the build's events are reaped", func() {
+				BeforeEach(func() {
+					err := archivingBuild.ArchiveEvents()
+					Expect(err).NotTo(HaveOccurred())
+
+					team, found, err := db.NewTeamFactory(archivingConn, lockFactory).FindTeam(defaultTeam.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					pipeline, found, err := team.Pipeline(defaultPipeline.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = pipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("deletes the archive", func() {
+					files, err := ioutil.ReadDir(filepath.Join(archiveDir, "build-events"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(files).To(BeEmpty())
+				})
+
+				It("no longer streams any events", func() {
+					events, err := archivingBuild.Events(0)
+					Expect(err).NotTo(HaveOccurred())
+
+					defer db.Close(events)
+
+					_, err = events.Next()
+					Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+				})
+			})
+
+			Context("when the build's pipeline is destroyed", func() {
+				BeforeEach(func() {
+					err := archivingBuild.ArchiveEvents()
+					Expect(err).NotTo(HaveOccurred())
+
+					team, found, err := db.NewTeamFactory(archivingConn, lockFactory).FindTeam(defaultTeam.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					pipeline, found, err := team.Pipeline(defaultPipeline.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = pipeline.Destroy()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("deletes the archive", func() {
+					files, err := ioutil.ReadDir(filepath.Join(archiveDir, "build-events"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(files).To(BeEmpty())
+				})
+			})
+
+			Context("when the build's team is deleted", func() {
+				BeforeEach(func() {
+					err := archivingBuild.ArchiveEvents()
+					Expect(err).NotTo(HaveOccurred())
+
+					team, found, err := db.NewTeamFactory(archivingConn, lockFactory).FindTeam(defaultTeam.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = team.Delete()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("deletes the archive", func() {
+					files, err := ioutil.ReadDir(filepath.Join(archiveDir, "build-events"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(files).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when the build log store fails to store the archive", func() {
+			var (
+				disaster   error
+				otherBuild db.Build
+			)
+
+			BeforeEach(func() {
+				disaster = errors.New("nope")
+
+				fakeStore := new(blobstorefakes.FakeStore)
+				fakeStore.PutReturns(disaster)
+
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				otherBuild, err = defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				archivingBuild, found, err = db.NewBuildFactory(db.WithBuildLogStore(dbConn, fakeStore), lockFactory).Build(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("returns the error and keeps the events", func() {
+				err := archivingBuild.ArchiveEvents()
+				Expect(err).To(Equal(disaster))
+
+				Expect(countEvents()).To(Equal(3))
+			})
+
+			It("returns the build to archive after the builds which have not failed", func() {
+				err := archivingBuild.ArchiveEvents()
+				Expect(err).To(HaveOccurred())
+
+				builds, err := buildFactory.GetCompletedBuildsToArchive(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(2))
+				Expect(builds[0].ID()).To(Equal(otherBuild.ID()))
+				Expect(builds[1].ID()).To(Equal(build.ID()))
+			})
+		})
+
+		Context("when the build has not completed", func() {
+			It("returns ErrBuildNotCompleted and keeps the events", func() {
+				err := archivingBuild.ArchiveEvents()
+				Expect(err).To(Equal(db.ErrBuildNotCompleted))
+
+				Expect(countEvents()).To(Equal(2))
+			})
+		})
+
+		Context("when no build log store is configured", func() {
+			It("returns ErrNoBuildLogStore and keeps the events", func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.ArchiveEvents()
+				Expect(err).To(Equal(db.ErrNoBuildLogStore))
+
+				Expect(countEvents()).To(Equal(3))
+			})
+		})
+	})
+
 	Describe("SaveInput", func() {
 		var pipeline db.Pipeline
 		var job db.Job
@@ -337,7 +626,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -404,7 +693,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -485,7 +774,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -560,7 +849,7 @@ var _ = Describe("Build", func() {
 							Name: "some-job",
 						},
 					},
-				}, db.ConfigVersion(1), db.PipelineUnpaused)
+				}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := createdPipeline.Job("some-job")
@@ -667,7 +956,7 @@ var _ = Describe("Build", func() {
 							Name: "some-job",
 						},
 					},
-				}, db.ConfigVersion(1), db.PipelineUnpaused)
+				}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -850,7 +1139,7 @@ var _ = Describe("Build", func() {
 						},
 					}
 
-					pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(2), db.PipelineUnpaused)
+					pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(2), db.PipelineUnpaused, "")
 					Expect(err).ToNot(HaveOccurred())
 
 					err = pipeline.SaveResourceVersions(
@@ -930,7 +1219,7 @@ var _ = Describe("Build", func() {
 							Name: "some-job",
 						},
 					},
-				}, db.ConfigVersion(1), db.PipelineUnpaused)
+				}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := pipeline.Job("some-job")
@@ -1014,7 +1303,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err := team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err := team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -18,6 +18,14 @@ type ContainerMetadata struct {
 	PipelineName string
 	JobName      string
 	BuildName    string
+
+	// Memory limit declared for the container, in bytes.
+	MemoryLimit uint64
+
+	// Priority of the build the container is for, used to decide which
+	// containers are placed first when workers are at capacity. It is not
+	// saved with the container.
+	Priority int
 }
 
 type ContainerType string
@@ -91,6 +99,10 @@ func (metadata ContainerMetadata) SQLMap() map[string]interface{} {
 		m["meta_build_name"] = metadata.BuildName
 	}
 
+	if metadata.MemoryLimit != 0 {
+		m["meta_memory_limit"] = metadata.MemoryLimit
+	}
+
 	return m
 }
 
@@ -106,6 +118,7 @@ var containerMetadataColumns = []string{
 	"meta_pipeline_name",
 	"meta_job_name",
 	"meta_build_name",
+	"meta_memory_limit",
 }
 
 func (metadata *ContainerMetadata) ScanTargets() []interface{} {
@@ -121,5 +134,6 @@ func (metadata *ContainerMetadata) ScanTargets() []interface{} {
 		&metadata.PipelineName,
 		&metadata.JobName,
 		&metadata.BuildName,
+		&metadata.MemoryLimit,
 	}
 }
@@ -68,6 +68,8 @@ var (
 
 		WorkingDirectory: "/some/work/dir",
 		User:             "some-user",
+
+		MemoryLimit: 1024 * 1024 * 1024,
 	}
 
 	psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
@@ -151,7 +153,7 @@ var _ = BeforeEach(func() {
 				},
 			},
 		},
-	}, db.ConfigVersion(0), db.PipelineUnpaused)
+	}, db.ConfigVersion(0), db.PipelineUnpaused, "")
 	Expect(err).NotTo(HaveOccurred())
 
 	var found bool
@@ -65,6 +65,32 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetCompletedBuildsToArchiveStub        func(limit int) ([]db.Build, error)
+	getCompletedBuildsToArchiveMutex       sync.RWMutex
+	getCompletedBuildsToArchiveArgsForCall []struct {
+		limit int
+	}
+	getCompletedBuildsToArchiveReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getCompletedBuildsToArchiveReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetBuildsBlockingWorkerDrainStub        func(workerName string) ([]db.Build, error)
+	getBuildsBlockingWorkerDrainMutex       sync.RWMutex
+	getBuildsBlockingWorkerDrainArgsForCall []struct {
+		workerName string
+	}
+	getBuildsBlockingWorkerDrainReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getBuildsBlockingWorkerDrainReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	MarkNonInterceptibleBuildsStub        func() error
 	markNonInterceptibleBuildsMutex       sync.RWMutex
 	markNonInterceptibleBuildsArgsForCall []struct{}
@@ -289,6 +315,108 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetCompletedBuildsToArchive(limit int) ([]db.Build, error) {
+	fake.getCompletedBuildsToArchiveMutex.Lock()
+	ret, specificReturn := fake.getCompletedBuildsToArchiveReturnsOnCall[len(fake.getCompletedBuildsToArchiveArgsForCall)]
+	fake.getCompletedBuildsToArchiveArgsForCall = append(fake.getCompletedBuildsToArchiveArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("GetCompletedBuildsToArchive", []interface{}{limit})
+	fake.getCompletedBuildsToArchiveMutex.Unlock()
+	if fake.GetCompletedBuildsToArchiveStub != nil {
+		return fake.GetCompletedBuildsToArchiveStub(limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getCompletedBuildsToArchiveReturns.result1, fake.getCompletedBuildsToArchiveReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetCompletedBuildsToArchiveCallCount() int {
+	fake.getCompletedBuildsToArchiveMutex.RLock()
+	defer fake.getCompletedBuildsToArchiveMutex.RUnlock()
+	return len(fake.getCompletedBuildsToArchiveArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetCompletedBuildsToArchiveArgsForCall(i int) int {
+	fake.getCompletedBuildsToArchiveMutex.RLock()
+	defer fake.getCompletedBuildsToArchiveMutex.RUnlock()
+	return fake.getCompletedBuildsToArchiveArgsForCall[i].limit
+}
+
+func (fake *FakeBuildFactory) GetCompletedBuildsToArchiveReturns(result1 []db.Build, result2 error) {
+	fake.GetCompletedBuildsToArchiveStub = nil
+	fake.getCompletedBuildsToArchiveReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetCompletedBuildsToArchiveReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.GetCompletedBuildsToArchiveStub = nil
+	if fake.getCompletedBuildsToArchiveReturnsOnCall == nil {
+		fake.getCompletedBuildsToArchiveReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getCompletedBuildsToArchiveReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetBuildsBlockingWorkerDrain(workerName string) ([]db.Build, error) {
+	fake.getBuildsBlockingWorkerDrainMutex.Lock()
+	ret, specificReturn := fake.getBuildsBlockingWorkerDrainReturnsOnCall[len(fake.getBuildsBlockingWorkerDrainArgsForCall)]
+	fake.getBuildsBlockingWorkerDrainArgsForCall = append(fake.getBuildsBlockingWorkerDrainArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("GetBuildsBlockingWorkerDrain", []interface{}{workerName})
+	fake.getBuildsBlockingWorkerDrainMutex.Unlock()
+	if fake.GetBuildsBlockingWorkerDrainStub != nil {
+		return fake.GetBuildsBlockingWorkerDrainStub(workerName)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getBuildsBlockingWorkerDrainReturns.result1, fake.getBuildsBlockingWorkerDrainReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetBuildsBlockingWorkerDrainCallCount() int {
+	fake.getBuildsBlockingWorkerDrainMutex.RLock()
+	defer fake.getBuildsBlockingWorkerDrainMutex.RUnlock()
+	return len(fake.getBuildsBlockingWorkerDrainArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetBuildsBlockingWorkerDrainArgsForCall(i int) string {
+	fake.getBuildsBlockingWorkerDrainMutex.RLock()
+	defer fake.getBuildsBlockingWorkerDrainMutex.RUnlock()
+	return fake.getBuildsBlockingWorkerDrainArgsForCall[i].workerName
+}
+
+func (fake *FakeBuildFactory) GetBuildsBlockingWorkerDrainReturns(result1 []db.Build, result2 error) {
+	fake.GetBuildsBlockingWorkerDrainStub = nil
+	fake.getBuildsBlockingWorkerDrainReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetBuildsBlockingWorkerDrainReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.GetBuildsBlockingWorkerDrainStub = nil
+	if fake.getBuildsBlockingWorkerDrainReturnsOnCall == nil {
+		fake.getBuildsBlockingWorkerDrainReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getBuildsBlockingWorkerDrainReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) MarkNonInterceptibleBuilds() error {
 	fake.markNonInterceptibleBuildsMutex.Lock()
 	ret, specificReturn := fake.markNonInterceptibleBuildsReturnsOnCall[len(fake.markNonInterceptibleBuildsArgsForCall)]
@@ -340,6 +468,10 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.publicBuildsMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
+	fake.getCompletedBuildsToArchiveMutex.RLock()
+	defer fake.getCompletedBuildsToArchiveMutex.RUnlock()
+	fake.getBuildsBlockingWorkerDrainMutex.RLock()
+	defer fake.getBuildsBlockingWorkerDrainMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
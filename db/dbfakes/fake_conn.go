@@ -8,6 +8,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/blobstore"
 	"github.com/concourse/atc/db/encryption"
 )
 
@@ -30,6 +31,15 @@ type FakeConn struct {
 	encryptionStrategyReturnsOnCall map[int]struct {
 		result1 encryption.Strategy
 	}
+	BuildLogStoreStub        func() blobstore.Store
+	buildLogStoreMutex       sync.RWMutex
+	buildLogStoreArgsForCall []struct{}
+	buildLogStoreReturns     struct {
+		result1 blobstore.Store
+	}
+	buildLogStoreReturnsOnCall map[int]struct {
+		result1 blobstore.Store
+	}
 	PingStub        func() error
 	pingMutex       sync.RWMutex
 	pingArgsForCall []struct{}
@@ -233,6 +243,46 @@ func (fake *FakeConn) EncryptionStrategyReturnsOnCall(i int, result1 encryption.
 	}{result1}
 }
 
+func (fake *FakeConn) BuildLogStore() blobstore.Store {
+	fake.buildLogStoreMutex.Lock()
+	ret, specificReturn := fake.buildLogStoreReturnsOnCall[len(fake.buildLogStoreArgsForCall)]
+	fake.buildLogStoreArgsForCall = append(fake.buildLogStoreArgsForCall, struct{}{})
+	fake.recordInvocation("BuildLogStore", []interface{}{})
+	fake.buildLogStoreMutex.Unlock()
+	if fake.BuildLogStoreStub != nil {
+		return fake.BuildLogStoreStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.buildLogStoreReturns.result1
+}
+
+func (fake *FakeConn) BuildLogStoreCallCount() int {
+	fake.buildLogStoreMutex.RLock()
+	defer fake.buildLogStoreMutex.RUnlock()
+	return len(fake.buildLogStoreArgsForCall)
+}
+
+func (fake *FakeConn) BuildLogStoreReturns(result1 blobstore.Store) {
+	fake.BuildLogStoreStub = nil
+	fake.buildLogStoreReturns = struct {
+		result1 blobstore.Store
+	}{result1}
+}
+
+func (fake *FakeConn) BuildLogStoreReturnsOnCall(i int, result1 blobstore.Store) {
+	fake.BuildLogStoreStub = nil
+	if fake.buildLogStoreReturnsOnCall == nil {
+		fake.buildLogStoreReturnsOnCall = make(map[int]struct {
+			result1 blobstore.Store
+		})
+	}
+	fake.buildLogStoreReturnsOnCall[i] = struct {
+		result1 blobstore.Store
+	}{result1}
+}
+
 func (fake *FakeConn) Ping() error {
 	fake.pingMutex.Lock()
 	ret, specificReturn := fake.pingReturnsOnCall[len(fake.pingArgsForCall)]
@@ -735,6 +785,8 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.busMutex.RUnlock()
 	fake.encryptionStrategyMutex.RLock()
 	defer fake.encryptionStrategyMutex.RUnlock()
+	fake.buildLogStoreMutex.RLock()
+	defer fake.buildLogStoreMutex.RUnlock()
 	fake.pingMutex.RLock()
 	defer fake.pingMutex.RUnlock()
 	fake.driverMutex.RLock()
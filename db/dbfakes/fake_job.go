@@ -155,6 +155,22 @@ type FakeJob struct {
 		result2 db.Pagination
 		result3 error
 	}
+	SearchBuildLogsStub        func(db.BuildLogSearch, db.Page) ([]db.BuildLogSearchResult, db.Pagination, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}
 	BuildStub        func(name string) (db.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -932,6 +948,61 @@ func (fake *FakeJob) BuildsReturnsOnCall(i int, result1 []db.Build, result2 db.P
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) SearchBuildLogs(arg1 db.BuildLogSearch, arg2 db.Page) ([]db.BuildLogSearchResult, db.Pagination, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}{arg1, arg2})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.searchBuildLogsReturns.result1, fake.searchBuildLogsReturns.result2, fake.searchBuildLogsReturns.result3
+}
+
+func (fake *FakeJob) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeJob) SearchBuildLogsArgsForCall(i int) (db.BuildLogSearch, db.Page) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return fake.searchBuildLogsArgsForCall[i].arg1, fake.searchBuildLogsArgsForCall[i].arg2
+}
+
+func (fake *FakeJob) SearchBuildLogsReturns(result1 []db.BuildLogSearchResult, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogSearchResult, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogSearchResult
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) Build(name string) (db.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -1584,6 +1655,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.createBuildMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.finishedAndNextBuildMutex.RLock()
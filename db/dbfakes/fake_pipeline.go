@@ -118,6 +118,32 @@ type FakePipeline struct {
 		result1 bool
 		result2 error
 	}
+	ConfigVersionsStub        func() ([]db.PipelineConfig, error)
+	configVersionsMutex       sync.RWMutex
+	configVersionsArgsForCall []struct{}
+	configVersionsReturns     struct {
+		result1 []db.PipelineConfig
+		result2 error
+	}
+	configVersionsReturnsOnCall map[int]struct {
+		result1 []db.PipelineConfig
+		result2 error
+	}
+	ConfigAtVersionStub        func(db.ConfigVersion) (db.PipelineConfig, bool, error)
+	configAtVersionMutex       sync.RWMutex
+	configAtVersionArgsForCall []struct {
+		arg1 db.ConfigVersion
+	}
+	configAtVersionReturns struct {
+		result1 db.PipelineConfig
+		result2 bool
+		result3 error
+	}
+	configAtVersionReturnsOnCall map[int]struct {
+		result1 db.PipelineConfig
+		result2 bool
+		result3 error
+	}
 	CausalityStub        func(versionedResourceID int) ([]db.Cause, error)
 	causalityMutex       sync.RWMutex
 	causalityArgsForCall []struct {
@@ -293,6 +319,22 @@ type FakePipeline struct {
 		result2 db.Pagination
 		result3 error
 	}
+	SearchBuildLogsStub        func(db.BuildLogSearch, db.Page) ([]db.BuildLogSearchResult, db.Pagination, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}
 	DeleteBuildEventsByBuildIDsStub        func(buildIDs []int) error
 	deleteBuildEventsByBuildIDsMutex       sync.RWMutex
 	deleteBuildEventsByBuildIDsArgsForCall []struct {
@@ -320,6 +362,32 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	AcquireUpstreamSchedulingLocksStub        func(lager.Logger) (lock.Lock, bool, error)
+	acquireUpstreamSchedulingLocksMutex       sync.RWMutex
+	acquireUpstreamSchedulingLocksArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	acquireUpstreamSchedulingLocksReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireUpstreamSchedulingLocksReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	SchedulingNotifierStub        func() (db.Notifier, error)
+	schedulingNotifierMutex       sync.RWMutex
+	schedulingNotifierArgsForCall []struct{}
+	schedulingNotifierReturns     struct {
+		result1 db.Notifier
+		result2 error
+	}
+	schedulingNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
 	AcquireResourceCheckingLockWithIntervalCheckStub        func(logger lager.Logger, resourceName string, usedResourceConfig *db.UsedResourceConfig, interval time.Duration, immediate bool) (lock.Lock, bool, error)
 	acquireResourceCheckingLockWithIntervalCheckMutex       sync.RWMutex
 	acquireResourceCheckingLockWithIntervalCheckArgsForCall []struct {
@@ -985,6 +1053,103 @@ func (fake *FakePipeline) ReloadReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) ConfigVersions() ([]db.PipelineConfig, error) {
+	fake.configVersionsMutex.Lock()
+	ret, specificReturn := fake.configVersionsReturnsOnCall[len(fake.configVersionsArgsForCall)]
+	fake.configVersionsArgsForCall = append(fake.configVersionsArgsForCall, struct{}{})
+	fake.recordInvocation("ConfigVersions", []interface{}{})
+	fake.configVersionsMutex.Unlock()
+	if fake.ConfigVersionsStub != nil {
+		return fake.ConfigVersionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.configVersionsReturns.result1, fake.configVersionsReturns.result2
+}
+
+func (fake *FakePipeline) ConfigVersionsCallCount() int {
+	fake.configVersionsMutex.RLock()
+	defer fake.configVersionsMutex.RUnlock()
+	return len(fake.configVersionsArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigVersionsReturns(result1 []db.PipelineConfig, result2 error) {
+	fake.ConfigVersionsStub = nil
+	fake.configVersionsReturns = struct {
+		result1 []db.PipelineConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigVersionsReturnsOnCall(i int, result1 []db.PipelineConfig, result2 error) {
+	fake.ConfigVersionsStub = nil
+	if fake.configVersionsReturnsOnCall == nil {
+		fake.configVersionsReturnsOnCall = make(map[int]struct {
+			result1 []db.PipelineConfig
+			result2 error
+		})
+	}
+	fake.configVersionsReturnsOnCall[i] = struct {
+		result1 []db.PipelineConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigAtVersion(arg1 db.ConfigVersion) (db.PipelineConfig, bool, error) {
+	fake.configAtVersionMutex.Lock()
+	ret, specificReturn := fake.configAtVersionReturnsOnCall[len(fake.configAtVersionArgsForCall)]
+	fake.configAtVersionArgsForCall = append(fake.configAtVersionArgsForCall, struct {
+		arg1 db.ConfigVersion
+	}{arg1})
+	fake.recordInvocation("ConfigAtVersion", []interface{}{arg1})
+	fake.configAtVersionMutex.Unlock()
+	if fake.ConfigAtVersionStub != nil {
+		return fake.ConfigAtVersionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.configAtVersionReturns.result1, fake.configAtVersionReturns.result2, fake.configAtVersionReturns.result3
+}
+
+func (fake *FakePipeline) ConfigAtVersionCallCount() int {
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
+	return len(fake.configAtVersionArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigAtVersionArgsForCall(i int) db.ConfigVersion {
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
+	return fake.configAtVersionArgsForCall[i].arg1
+}
+
+func (fake *FakePipeline) ConfigAtVersionReturns(result1 db.PipelineConfig, result2 bool, result3 error) {
+	fake.ConfigAtVersionStub = nil
+	fake.configAtVersionReturns = struct {
+		result1 db.PipelineConfig
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigAtVersionReturnsOnCall(i int, result1 db.PipelineConfig, result2 bool, result3 error) {
+	fake.ConfigAtVersionStub = nil
+	if fake.configAtVersionReturnsOnCall == nil {
+		fake.configAtVersionReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineConfig
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configAtVersionReturnsOnCall[i] = struct {
+		result1 db.PipelineConfig
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) Causality(versionedResourceID int) ([]db.Cause, error) {
 	fake.causalityMutex.Lock()
 	ret, specificReturn := fake.causalityReturnsOnCall[len(fake.causalityArgsForCall)]
@@ -1655,6 +1820,61 @@ func (fake *FakePipeline) BuildsReturnsOnCall(i int, result1 []db.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) SearchBuildLogs(arg1 db.BuildLogSearch, arg2 db.Page) ([]db.BuildLogSearchResult, db.Pagination, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}{arg1, arg2})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.searchBuildLogsReturns.result1, fake.searchBuildLogsReturns.result2, fake.searchBuildLogsReturns.result3
+}
+
+func (fake *FakePipeline) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakePipeline) SearchBuildLogsArgsForCall(i int) (db.BuildLogSearch, db.Page) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return fake.searchBuildLogsArgsForCall[i].arg1, fake.searchBuildLogsArgsForCall[i].arg2
+}
+
+func (fake *FakePipeline) SearchBuildLogsReturns(result1 []db.BuildLogSearchResult, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogSearchResult, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogSearchResult
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	var buildIDsCopy []int
 	if buildIDs != nil {
@@ -1763,6 +1983,103 @@ func (fake *FakePipeline) AcquireSchedulingLockReturnsOnCall(i int, result1 lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) AcquireUpstreamSchedulingLocks(arg1 lager.Logger) (lock.Lock, bool, error) {
+	fake.acquireUpstreamSchedulingLocksMutex.Lock()
+	ret, specificReturn := fake.acquireUpstreamSchedulingLocksReturnsOnCall[len(fake.acquireUpstreamSchedulingLocksArgsForCall)]
+	fake.acquireUpstreamSchedulingLocksArgsForCall = append(fake.acquireUpstreamSchedulingLocksArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("AcquireUpstreamSchedulingLocks", []interface{}{arg1})
+	fake.acquireUpstreamSchedulingLocksMutex.Unlock()
+	if fake.AcquireUpstreamSchedulingLocksStub != nil {
+		return fake.AcquireUpstreamSchedulingLocksStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.acquireUpstreamSchedulingLocksReturns.result1, fake.acquireUpstreamSchedulingLocksReturns.result2, fake.acquireUpstreamSchedulingLocksReturns.result3
+}
+
+func (fake *FakePipeline) AcquireUpstreamSchedulingLocksCallCount() int {
+	fake.acquireUpstreamSchedulingLocksMutex.RLock()
+	defer fake.acquireUpstreamSchedulingLocksMutex.RUnlock()
+	return len(fake.acquireUpstreamSchedulingLocksArgsForCall)
+}
+
+func (fake *FakePipeline) AcquireUpstreamSchedulingLocksArgsForCall(i int) lager.Logger {
+	fake.acquireUpstreamSchedulingLocksMutex.RLock()
+	defer fake.acquireUpstreamSchedulingLocksMutex.RUnlock()
+	return fake.acquireUpstreamSchedulingLocksArgsForCall[i].arg1
+}
+
+func (fake *FakePipeline) AcquireUpstreamSchedulingLocksReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireUpstreamSchedulingLocksStub = nil
+	fake.acquireUpstreamSchedulingLocksReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) AcquireUpstreamSchedulingLocksReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireUpstreamSchedulingLocksStub = nil
+	if fake.acquireUpstreamSchedulingLocksReturnsOnCall == nil {
+		fake.acquireUpstreamSchedulingLocksReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireUpstreamSchedulingLocksReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) SchedulingNotifier() (db.Notifier, error) {
+	fake.schedulingNotifierMutex.Lock()
+	ret, specificReturn := fake.schedulingNotifierReturnsOnCall[len(fake.schedulingNotifierArgsForCall)]
+	fake.schedulingNotifierArgsForCall = append(fake.schedulingNotifierArgsForCall, struct{}{})
+	fake.recordInvocation("SchedulingNotifier", []interface{}{})
+	fake.schedulingNotifierMutex.Unlock()
+	if fake.SchedulingNotifierStub != nil {
+		return fake.SchedulingNotifierStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.schedulingNotifierReturns.result1, fake.schedulingNotifierReturns.result2
+}
+
+func (fake *FakePipeline) SchedulingNotifierCallCount() int {
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
+	return len(fake.schedulingNotifierArgsForCall)
+}
+
+func (fake *FakePipeline) SchedulingNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.SchedulingNotifierStub = nil
+	fake.schedulingNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SchedulingNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.SchedulingNotifierStub = nil
+	if fake.schedulingNotifierReturnsOnCall == nil {
+		fake.schedulingNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.schedulingNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) AcquireResourceCheckingLockWithIntervalCheck(logger lager.Logger, resourceName string, usedResourceConfig *db.UsedResourceConfig, interval time.Duration, immediate bool) (lock.Lock, bool, error) {
 	fake.acquireResourceCheckingLockWithIntervalCheckMutex.Lock()
 	ret, specificReturn := fake.acquireResourceCheckingLockWithIntervalCheckReturnsOnCall[len(fake.acquireResourceCheckingLockWithIntervalCheckArgsForCall)]
@@ -2580,6 +2897,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.checkPausedMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.configVersionsMutex.RLock()
+	defer fake.configVersionsMutex.RUnlock()
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
 	fake.causalityMutex.RLock()
 	defer fake.causalityMutex.RUnlock()
 	fake.setResourceCheckErrorMutex.RLock()
@@ -2606,10 +2927,16 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.getBuildsWithVersionAsOutputMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
 	fake.acquireSchedulingLockMutex.RLock()
 	defer fake.acquireSchedulingLockMutex.RUnlock()
+	fake.acquireUpstreamSchedulingLocksMutex.RLock()
+	defer fake.acquireUpstreamSchedulingLocksMutex.RUnlock()
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
 	fake.acquireResourceCheckingLockWithIntervalCheckMutex.RLock()
 	defer fake.acquireResourceCheckingLockWithIntervalCheckMutex.RUnlock()
 	fake.acquireResourceTypeCheckingLockWithIntervalCheckMutex.RLock()
@@ -82,6 +82,15 @@ type FakeResource struct {
 	tagsReturnsOnCall map[int]struct {
 		result1 atc.Tags
 	}
+	LabelSelectorStub        func() atc.LabelSelector
+	labelSelectorMutex       sync.RWMutex
+	labelSelectorArgsForCall []struct{}
+	labelSelectorReturns     struct {
+		result1 atc.LabelSelector
+	}
+	labelSelectorReturnsOnCall map[int]struct {
+		result1 atc.LabelSelector
+	}
 	CheckErrorStub        func() error
 	checkErrorMutex       sync.RWMutex
 	checkErrorArgsForCall []struct{}
@@ -118,6 +127,51 @@ type FakeResource struct {
 	failingToCheckReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	VersionHistoryLimitStub        func() int
+	versionHistoryLimitMutex       sync.RWMutex
+	versionHistoryLimitArgsForCall []struct{}
+	versionHistoryLimitReturns     struct {
+		result1 int
+	}
+	versionHistoryLimitReturnsOnCall map[int]struct {
+		result1 int
+	}
+	PinnedVersionIDStub        func() int
+	pinnedVersionIDMutex       sync.RWMutex
+	pinnedVersionIDArgsForCall []struct{}
+	pinnedVersionIDReturns     struct {
+		result1 int
+	}
+	pinnedVersionIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	PinnedVersionStub        func() atc.Version
+	pinnedVersionMutex       sync.RWMutex
+	pinnedVersionArgsForCall []struct{}
+	pinnedVersionReturns     struct {
+		result1 atc.Version
+	}
+	pinnedVersionReturnsOnCall map[int]struct {
+		result1 atc.Version
+	}
+	PinnedByStub        func() string
+	pinnedByMutex       sync.RWMutex
+	pinnedByArgsForCall []struct{}
+	pinnedByReturns     struct {
+		result1 string
+	}
+	pinnedByReturnsOnCall map[int]struct {
+		result1 string
+	}
+	PinCommentStub        func() string
+	pinCommentMutex       sync.RWMutex
+	pinCommentArgsForCall []struct{}
+	pinCommentReturns     struct {
+		result1 string
+	}
+	pinCommentReturnsOnCall map[int]struct {
+		result1 string
+	}
 	SetResourceConfigStub        func(int) error
 	setResourceConfigMutex       sync.RWMutex
 	setResourceConfigArgsForCall []struct {
@@ -129,6 +183,45 @@ type FakeResource struct {
 	setResourceConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PinVersionStub        func(versionID int, pinnedBy string, comment string) (bool, error)
+	pinVersionMutex       sync.RWMutex
+	pinVersionArgsForCall []struct {
+		versionID int
+		pinnedBy  string
+		comment   string
+	}
+	pinVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	pinVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UnpinVersionStub        func() error
+	unpinVersionMutex       sync.RWMutex
+	unpinVersionArgsForCall []struct{}
+	unpinVersionReturns     struct {
+		result1 error
+	}
+	unpinVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PruneVersionsStub        func(limit int, keep []atc.Version, upstreamJobs []atc.PassedJob) (int, error)
+	pruneVersionsMutex       sync.RWMutex
+	pruneVersionsArgsForCall []struct {
+		limit        int
+		keep         []atc.Version
+		upstreamJobs []atc.PassedJob
+	}
+	pruneVersionsReturns struct {
+		result1 int
+		result2 error
+	}
+	pruneVersionsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	PauseStub        func() error
 	pauseMutex       sync.RWMutex
 	pauseArgsForCall []struct{}
@@ -482,6 +575,46 @@ func (fake *FakeResource) TagsReturnsOnCall(i int, result1 atc.Tags) {
 	}{result1}
 }
 
+func (fake *FakeResource) LabelSelector() atc.LabelSelector {
+	fake.labelSelectorMutex.Lock()
+	ret, specificReturn := fake.labelSelectorReturnsOnCall[len(fake.labelSelectorArgsForCall)]
+	fake.labelSelectorArgsForCall = append(fake.labelSelectorArgsForCall, struct{}{})
+	fake.recordInvocation("LabelSelector", []interface{}{})
+	fake.labelSelectorMutex.Unlock()
+	if fake.LabelSelectorStub != nil {
+		return fake.LabelSelectorStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.labelSelectorReturns.result1
+}
+
+func (fake *FakeResource) LabelSelectorCallCount() int {
+	fake.labelSelectorMutex.RLock()
+	defer fake.labelSelectorMutex.RUnlock()
+	return len(fake.labelSelectorArgsForCall)
+}
+
+func (fake *FakeResource) LabelSelectorReturns(result1 atc.LabelSelector) {
+	fake.LabelSelectorStub = nil
+	fake.labelSelectorReturns = struct {
+		result1 atc.LabelSelector
+	}{result1}
+}
+
+func (fake *FakeResource) LabelSelectorReturnsOnCall(i int, result1 atc.LabelSelector) {
+	fake.LabelSelectorStub = nil
+	if fake.labelSelectorReturnsOnCall == nil {
+		fake.labelSelectorReturnsOnCall = make(map[int]struct {
+			result1 atc.LabelSelector
+		})
+	}
+	fake.labelSelectorReturnsOnCall[i] = struct {
+		result1 atc.LabelSelector
+	}{result1}
+}
+
 func (fake *FakeResource) CheckError() error {
 	fake.checkErrorMutex.Lock()
 	ret, specificReturn := fake.checkErrorReturnsOnCall[len(fake.checkErrorArgsForCall)]
@@ -642,6 +775,206 @@ func (fake *FakeResource) FailingToCheckReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeResource) VersionHistoryLimit() int {
+	fake.versionHistoryLimitMutex.Lock()
+	ret, specificReturn := fake.versionHistoryLimitReturnsOnCall[len(fake.versionHistoryLimitArgsForCall)]
+	fake.versionHistoryLimitArgsForCall = append(fake.versionHistoryLimitArgsForCall, struct{}{})
+	fake.recordInvocation("VersionHistoryLimit", []interface{}{})
+	fake.versionHistoryLimitMutex.Unlock()
+	if fake.VersionHistoryLimitStub != nil {
+		return fake.VersionHistoryLimitStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.versionHistoryLimitReturns.result1
+}
+
+func (fake *FakeResource) VersionHistoryLimitCallCount() int {
+	fake.versionHistoryLimitMutex.RLock()
+	defer fake.versionHistoryLimitMutex.RUnlock()
+	return len(fake.versionHistoryLimitArgsForCall)
+}
+
+func (fake *FakeResource) VersionHistoryLimitReturns(result1 int) {
+	fake.VersionHistoryLimitStub = nil
+	fake.versionHistoryLimitReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) VersionHistoryLimitReturnsOnCall(i int, result1 int) {
+	fake.VersionHistoryLimitStub = nil
+	if fake.versionHistoryLimitReturnsOnCall == nil {
+		fake.versionHistoryLimitReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.versionHistoryLimitReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedVersionID() int {
+	fake.pinnedVersionIDMutex.Lock()
+	ret, specificReturn := fake.pinnedVersionIDReturnsOnCall[len(fake.pinnedVersionIDArgsForCall)]
+	fake.pinnedVersionIDArgsForCall = append(fake.pinnedVersionIDArgsForCall, struct{}{})
+	fake.recordInvocation("PinnedVersionID", []interface{}{})
+	fake.pinnedVersionIDMutex.Unlock()
+	if fake.PinnedVersionIDStub != nil {
+		return fake.PinnedVersionIDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinnedVersionIDReturns.result1
+}
+
+func (fake *FakeResource) PinnedVersionIDCallCount() int {
+	fake.pinnedVersionIDMutex.RLock()
+	defer fake.pinnedVersionIDMutex.RUnlock()
+	return len(fake.pinnedVersionIDArgsForCall)
+}
+
+func (fake *FakeResource) PinnedVersionIDReturns(result1 int) {
+	fake.PinnedVersionIDStub = nil
+	fake.pinnedVersionIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedVersionIDReturnsOnCall(i int, result1 int) {
+	fake.PinnedVersionIDStub = nil
+	if fake.pinnedVersionIDReturnsOnCall == nil {
+		fake.pinnedVersionIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.pinnedVersionIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedVersion() atc.Version {
+	fake.pinnedVersionMutex.Lock()
+	ret, specificReturn := fake.pinnedVersionReturnsOnCall[len(fake.pinnedVersionArgsForCall)]
+	fake.pinnedVersionArgsForCall = append(fake.pinnedVersionArgsForCall, struct{}{})
+	fake.recordInvocation("PinnedVersion", []interface{}{})
+	fake.pinnedVersionMutex.Unlock()
+	if fake.PinnedVersionStub != nil {
+		return fake.PinnedVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinnedVersionReturns.result1
+}
+
+func (fake *FakeResource) PinnedVersionCallCount() int {
+	fake.pinnedVersionMutex.RLock()
+	defer fake.pinnedVersionMutex.RUnlock()
+	return len(fake.pinnedVersionArgsForCall)
+}
+
+func (fake *FakeResource) PinnedVersionReturns(result1 atc.Version) {
+	fake.PinnedVersionStub = nil
+	fake.pinnedVersionReturns = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedVersionReturnsOnCall(i int, result1 atc.Version) {
+	fake.PinnedVersionStub = nil
+	if fake.pinnedVersionReturnsOnCall == nil {
+		fake.pinnedVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+		})
+	}
+	fake.pinnedVersionReturnsOnCall[i] = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedBy() string {
+	fake.pinnedByMutex.Lock()
+	ret, specificReturn := fake.pinnedByReturnsOnCall[len(fake.pinnedByArgsForCall)]
+	fake.pinnedByArgsForCall = append(fake.pinnedByArgsForCall, struct{}{})
+	fake.recordInvocation("PinnedBy", []interface{}{})
+	fake.pinnedByMutex.Unlock()
+	if fake.PinnedByStub != nil {
+		return fake.PinnedByStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinnedByReturns.result1
+}
+
+func (fake *FakeResource) PinnedByCallCount() int {
+	fake.pinnedByMutex.RLock()
+	defer fake.pinnedByMutex.RUnlock()
+	return len(fake.pinnedByArgsForCall)
+}
+
+func (fake *FakeResource) PinnedByReturns(result1 string) {
+	fake.PinnedByStub = nil
+	fake.pinnedByReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedByReturnsOnCall(i int, result1 string) {
+	fake.PinnedByStub = nil
+	if fake.pinnedByReturnsOnCall == nil {
+		fake.pinnedByReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pinnedByReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) PinComment() string {
+	fake.pinCommentMutex.Lock()
+	ret, specificReturn := fake.pinCommentReturnsOnCall[len(fake.pinCommentArgsForCall)]
+	fake.pinCommentArgsForCall = append(fake.pinCommentArgsForCall, struct{}{})
+	fake.recordInvocation("PinComment", []interface{}{})
+	fake.pinCommentMutex.Unlock()
+	if fake.PinCommentStub != nil {
+		return fake.PinCommentStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinCommentReturns.result1
+}
+
+func (fake *FakeResource) PinCommentCallCount() int {
+	fake.pinCommentMutex.RLock()
+	defer fake.pinCommentMutex.RUnlock()
+	return len(fake.pinCommentArgsForCall)
+}
+
+func (fake *FakeResource) PinCommentReturns(result1 string) {
+	fake.PinCommentStub = nil
+	fake.pinCommentReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) PinCommentReturnsOnCall(i int, result1 string) {
+	fake.PinCommentStub = nil
+	if fake.pinCommentReturnsOnCall == nil {
+		fake.pinCommentReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pinCommentReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResource) SetResourceConfig(arg1 int) error {
 	fake.setResourceConfigMutex.Lock()
 	ret, specificReturn := fake.setResourceConfigReturnsOnCall[len(fake.setResourceConfigArgsForCall)]
@@ -690,6 +1023,162 @@ func (fake *FakeResource) SetResourceConfigReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) PinVersion(versionID int, pinnedBy string, comment string) (bool, error) {
+	fake.pinVersionMutex.Lock()
+	ret, specificReturn := fake.pinVersionReturnsOnCall[len(fake.pinVersionArgsForCall)]
+	fake.pinVersionArgsForCall = append(fake.pinVersionArgsForCall, struct {
+		versionID int
+		pinnedBy  string
+		comment   string
+	}{versionID, pinnedBy, comment})
+	fake.recordInvocation("PinVersion", []interface{}{versionID, pinnedBy, comment})
+	fake.pinVersionMutex.Unlock()
+	if fake.PinVersionStub != nil {
+		return fake.PinVersionStub(versionID, pinnedBy, comment)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pinVersionReturns.result1, fake.pinVersionReturns.result2
+}
+
+func (fake *FakeResource) PinVersionCallCount() int {
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	return len(fake.pinVersionArgsForCall)
+}
+
+func (fake *FakeResource) PinVersionArgsForCall(i int) (int, string, string) {
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	return fake.pinVersionArgsForCall[i].versionID, fake.pinVersionArgsForCall[i].pinnedBy, fake.pinVersionArgsForCall[i].comment
+}
+
+func (fake *FakeResource) PinVersionReturns(result1 bool, result2 error) {
+	fake.PinVersionStub = nil
+	fake.pinVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) PinVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.PinVersionStub = nil
+	if fake.pinVersionReturnsOnCall == nil {
+		fake.pinVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pinVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) UnpinVersion() error {
+	fake.unpinVersionMutex.Lock()
+	ret, specificReturn := fake.unpinVersionReturnsOnCall[len(fake.unpinVersionArgsForCall)]
+	fake.unpinVersionArgsForCall = append(fake.unpinVersionArgsForCall, struct{}{})
+	fake.recordInvocation("UnpinVersion", []interface{}{})
+	fake.unpinVersionMutex.Unlock()
+	if fake.UnpinVersionStub != nil {
+		return fake.UnpinVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.unpinVersionReturns.result1
+}
+
+func (fake *FakeResource) UnpinVersionCallCount() int {
+	fake.unpinVersionMutex.RLock()
+	defer fake.unpinVersionMutex.RUnlock()
+	return len(fake.unpinVersionArgsForCall)
+}
+
+func (fake *FakeResource) UnpinVersionReturns(result1 error) {
+	fake.UnpinVersionStub = nil
+	fake.unpinVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UnpinVersionReturnsOnCall(i int, result1 error) {
+	fake.UnpinVersionStub = nil
+	if fake.unpinVersionReturnsOnCall == nil {
+		fake.unpinVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpinVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) PruneVersions(limit int, keep []atc.Version, upstreamJobs []atc.PassedJob) (int, error) {
+	var keepCopy []atc.Version
+	if keep != nil {
+		keepCopy = make([]atc.Version, len(keep))
+		copy(keepCopy, keep)
+	}
+	var upstreamJobsCopy []atc.PassedJob
+	if upstreamJobs != nil {
+		upstreamJobsCopy = make([]atc.PassedJob, len(upstreamJobs))
+		copy(upstreamJobsCopy, upstreamJobs)
+	}
+	fake.pruneVersionsMutex.Lock()
+	ret, specificReturn := fake.pruneVersionsReturnsOnCall[len(fake.pruneVersionsArgsForCall)]
+	fake.pruneVersionsArgsForCall = append(fake.pruneVersionsArgsForCall, struct {
+		limit        int
+		keep         []atc.Version
+		upstreamJobs []atc.PassedJob
+	}{limit, keepCopy, upstreamJobsCopy})
+	fake.recordInvocation("PruneVersions", []interface{}{limit, keepCopy, upstreamJobsCopy})
+	fake.pruneVersionsMutex.Unlock()
+	if fake.PruneVersionsStub != nil {
+		return fake.PruneVersionsStub(limit, keep, upstreamJobs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pruneVersionsReturns.result1, fake.pruneVersionsReturns.result2
+}
+
+func (fake *FakeResource) PruneVersionsCallCount() int {
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	return len(fake.pruneVersionsArgsForCall)
+}
+
+func (fake *FakeResource) PruneVersionsArgsForCall(i int) (int, []atc.Version, []atc.PassedJob) {
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	return fake.pruneVersionsArgsForCall[i].limit, fake.pruneVersionsArgsForCall[i].keep, fake.pruneVersionsArgsForCall[i].upstreamJobs
+}
+
+func (fake *FakeResource) PruneVersionsReturns(result1 int, result2 error) {
+	fake.PruneVersionsStub = nil
+	fake.pruneVersionsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) PruneVersionsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.PruneVersionsStub = nil
+	if fake.pruneVersionsReturnsOnCall == nil {
+		fake.pruneVersionsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.pruneVersionsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) Pause() error {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
@@ -832,6 +1321,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.lastCheckedMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.labelSelectorMutex.RLock()
+	defer fake.labelSelectorMutex.RUnlock()
 	fake.checkErrorMutex.RLock()
 	defer fake.checkErrorMutex.RUnlock()
 	fake.pausedMutex.RLock()
@@ -840,8 +1331,24 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.webhookTokenMutex.RUnlock()
 	fake.failingToCheckMutex.RLock()
 	defer fake.failingToCheckMutex.RUnlock()
+	fake.versionHistoryLimitMutex.RLock()
+	defer fake.versionHistoryLimitMutex.RUnlock()
+	fake.pinnedVersionIDMutex.RLock()
+	defer fake.pinnedVersionIDMutex.RUnlock()
+	fake.pinnedVersionMutex.RLock()
+	defer fake.pinnedVersionMutex.RUnlock()
+	fake.pinnedByMutex.RLock()
+	defer fake.pinnedByMutex.RUnlock()
+	fake.pinCommentMutex.RLock()
+	defer fake.pinCommentMutex.RUnlock()
 	fake.setResourceConfigMutex.RLock()
 	defer fake.setResourceConfigMutex.RUnlock()
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	fake.unpinVersionMutex.RLock()
+	defer fake.unpinVersionMutex.RUnlock()
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
 	fake.pauseMutex.RLock()
 	defer fake.pauseMutex.RUnlock()
 	fake.unpauseMutex.RLock()
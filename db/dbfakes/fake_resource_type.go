@@ -72,6 +72,15 @@ type FakeResourceType struct {
 	tagsReturnsOnCall map[int]struct {
 		result1 atc.Tags
 	}
+	LabelSelectorStub        func() atc.LabelSelector
+	labelSelectorMutex       sync.RWMutex
+	labelSelectorArgsForCall []struct{}
+	labelSelectorReturns     struct {
+		result1 atc.LabelSelector
+	}
+	labelSelectorReturnsOnCall map[int]struct {
+		result1 atc.LabelSelector
+	}
 	SetResourceConfigStub        func(int) error
 	setResourceConfigMutex       sync.RWMutex
 	setResourceConfigArgsForCall []struct {
@@ -398,6 +407,46 @@ func (fake *FakeResourceType) TagsReturnsOnCall(i int, result1 atc.Tags) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) LabelSelector() atc.LabelSelector {
+	fake.labelSelectorMutex.Lock()
+	ret, specificReturn := fake.labelSelectorReturnsOnCall[len(fake.labelSelectorArgsForCall)]
+	fake.labelSelectorArgsForCall = append(fake.labelSelectorArgsForCall, struct{}{})
+	fake.recordInvocation("LabelSelector", []interface{}{})
+	fake.labelSelectorMutex.Unlock()
+	if fake.LabelSelectorStub != nil {
+		return fake.LabelSelectorStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.labelSelectorReturns.result1
+}
+
+func (fake *FakeResourceType) LabelSelectorCallCount() int {
+	fake.labelSelectorMutex.RLock()
+	defer fake.labelSelectorMutex.RUnlock()
+	return len(fake.labelSelectorArgsForCall)
+}
+
+func (fake *FakeResourceType) LabelSelectorReturns(result1 atc.LabelSelector) {
+	fake.LabelSelectorStub = nil
+	fake.labelSelectorReturns = struct {
+		result1 atc.LabelSelector
+	}{result1}
+}
+
+func (fake *FakeResourceType) LabelSelectorReturnsOnCall(i int, result1 atc.LabelSelector) {
+	fake.LabelSelectorStub = nil
+	if fake.labelSelectorReturnsOnCall == nil {
+		fake.labelSelectorReturnsOnCall = make(map[int]struct {
+			result1 atc.LabelSelector
+		})
+	}
+	fake.labelSelectorReturnsOnCall[i] = struct {
+		result1 atc.LabelSelector
+	}{result1}
+}
+
 func (fake *FakeResourceType) SetResourceConfig(arg1 int) error {
 	fake.setResourceConfigMutex.Lock()
 	ret, specificReturn := fake.setResourceConfigReturnsOnCall[len(fake.setResourceConfigArgsForCall)]
@@ -594,6 +643,8 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.paramsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.labelSelectorMutex.RLock()
+	defer fake.labelSelectorMutex.RUnlock()
 	fake.setResourceConfigMutex.RLock()
 	defer fake.setResourceConfigMutex.RUnlock()
 	fake.versionMutex.RLock()
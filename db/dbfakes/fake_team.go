@@ -49,6 +49,15 @@ type FakeTeam struct {
 	authReturnsOnCall map[int]struct {
 		result1 map[string]*json.RawMessage
 	}
+	MaxContainersStub        func() int
+	maxContainersMutex       sync.RWMutex
+	maxContainersArgsForCall []struct{}
+	maxContainersReturns     struct {
+		result1 int
+	}
+	maxContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct{}
@@ -69,13 +78,14 @@ type FakeTeam struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SavePipelineStub        func(pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.Pipeline, bool, error)
+	SavePipelineStub        func(pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState, author string) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
 		pipelineName string
 		config       atc.Config
 		from         db.ConfigVersion
 		pausedState  db.PipelinePausedState
+		author       string
 	}
 	savePipelineReturns struct {
 		result1 db.Pipeline
@@ -187,6 +197,22 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	SearchBuildLogsStub        func(db.BuildLogSearch, db.Page) ([]db.BuildLogSearchResult, db.Pagination, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}
 	SaveWorkerStub        func(atcWorker atc.Worker, ttl time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -343,6 +369,79 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateMaxContainersStub        func(int) error
+	updateMaxContainersMutex       sync.RWMutex
+	updateMaxContainersArgsForCall []struct {
+		arg1 int
+	}
+	updateMaxContainersReturns struct {
+		result1 error
+	}
+	updateMaxContainersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerQuotaReachedStub        func() (bool, error)
+	containerQuotaReachedMutex       sync.RWMutex
+	containerQuotaReachedArgsForCall []struct{}
+	containerQuotaReachedReturns     struct {
+		result1 bool
+		result2 error
+	}
+	containerQuotaReachedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SaveVarStub        func(name string, value string) error
+	saveVarMutex       sync.RWMutex
+	saveVarArgsForCall []struct {
+		name  string
+		value string
+	}
+	saveVarReturns struct {
+		result1 error
+	}
+	saveVarReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VarStub        func(name string) (string, bool, error)
+	varMutex       sync.RWMutex
+	varArgsForCall []struct {
+		name string
+	}
+	varReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	varReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	VarNamesStub        func() ([]string, error)
+	varNamesMutex       sync.RWMutex
+	varNamesArgsForCall []struct{}
+	varNamesReturns     struct {
+		result1 []string
+		result2 error
+	}
+	varNamesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DeleteVarStub        func(name string) (bool, error)
+	deleteVarMutex       sync.RWMutex
+	deleteVarArgsForCall []struct {
+		name string
+	}
+	deleteVarReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteVarReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -507,6 +606,46 @@ func (fake *FakeTeam) AuthReturnsOnCall(i int, result1 map[string]*json.RawMessa
 	}{result1}
 }
 
+func (fake *FakeTeam) MaxContainers() int {
+	fake.maxContainersMutex.Lock()
+	ret, specificReturn := fake.maxContainersReturnsOnCall[len(fake.maxContainersArgsForCall)]
+	fake.maxContainersArgsForCall = append(fake.maxContainersArgsForCall, struct{}{})
+	fake.recordInvocation("MaxContainers", []interface{}{})
+	fake.maxContainersMutex.Unlock()
+	if fake.MaxContainersStub != nil {
+		return fake.MaxContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.maxContainersReturns.result1
+}
+
+func (fake *FakeTeam) MaxContainersCallCount() int {
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	return len(fake.maxContainersArgsForCall)
+}
+
+func (fake *FakeTeam) MaxContainersReturns(result1 int) {
+	fake.MaxContainersStub = nil
+	fake.maxContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) MaxContainersReturnsOnCall(i int, result1 int) {
+	fake.MaxContainersStub = nil
+	if fake.maxContainersReturnsOnCall == nil {
+		fake.maxContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeTeam) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -595,7 +734,7 @@ func (fake *FakeTeam) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) SavePipeline(pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.Pipeline, bool, error) {
+func (fake *FakeTeam) SavePipeline(pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState, author string) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
@@ -603,11 +742,12 @@ func (fake *FakeTeam) SavePipeline(pipelineName string, config atc.Config, from
 		config       atc.Config
 		from         db.ConfigVersion
 		pausedState  db.PipelinePausedState
-	}{pipelineName, config, from, pausedState})
-	fake.recordInvocation("SavePipeline", []interface{}{pipelineName, config, from, pausedState})
+		author       string
+	}{pipelineName, config, from, pausedState, author})
+	fake.recordInvocation("SavePipeline", []interface{}{pipelineName, config, from, pausedState, author})
 	fake.savePipelineMutex.Unlock()
 	if fake.SavePipelineStub != nil {
-		return fake.SavePipelineStub(pipelineName, config, from, pausedState)
+		return fake.SavePipelineStub(pipelineName, config, from, pausedState, author)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -621,10 +761,10 @@ func (fake *FakeTeam) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState, string) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
-	return fake.savePipelineArgsForCall[i].pipelineName, fake.savePipelineArgsForCall[i].config, fake.savePipelineArgsForCall[i].from, fake.savePipelineArgsForCall[i].pausedState
+	return fake.savePipelineArgsForCall[i].pipelineName, fake.savePipelineArgsForCall[i].config, fake.savePipelineArgsForCall[i].from, fake.savePipelineArgsForCall[i].pausedState, fake.savePipelineArgsForCall[i].author
 }
 
 func (fake *FakeTeam) SavePipelineReturns(result1 db.Pipeline, result2 bool, result3 error) {
@@ -1039,6 +1179,61 @@ func (fake *FakeTeam) BuildsReturnsOnCall(i int, result1 []db.Build, result2 db.
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SearchBuildLogs(arg1 db.BuildLogSearch, arg2 db.Page) ([]db.BuildLogSearchResult, db.Pagination, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}{arg1, arg2})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.searchBuildLogsReturns.result1, fake.searchBuildLogsReturns.result2, fake.searchBuildLogsReturns.result3
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) (db.BuildLogSearch, db.Page) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return fake.searchBuildLogsArgsForCall[i].arg1, fake.searchBuildLogsArgsForCall[i].arg2
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []db.BuildLogSearchResult, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogSearchResult, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogSearchResult
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogSearchResult
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1611,6 +1806,294 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateMaxContainers(arg1 int) error {
+	fake.updateMaxContainersMutex.Lock()
+	ret, specificReturn := fake.updateMaxContainersReturnsOnCall[len(fake.updateMaxContainersArgsForCall)]
+	fake.updateMaxContainersArgsForCall = append(fake.updateMaxContainersArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("UpdateMaxContainers", []interface{}{arg1})
+	fake.updateMaxContainersMutex.Unlock()
+	if fake.UpdateMaxContainersStub != nil {
+		return fake.UpdateMaxContainersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateMaxContainersReturns.result1
+}
+
+func (fake *FakeTeam) UpdateMaxContainersCallCount() int {
+	fake.updateMaxContainersMutex.RLock()
+	defer fake.updateMaxContainersMutex.RUnlock()
+	return len(fake.updateMaxContainersArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateMaxContainersArgsForCall(i int) int {
+	fake.updateMaxContainersMutex.RLock()
+	defer fake.updateMaxContainersMutex.RUnlock()
+	return fake.updateMaxContainersArgsForCall[i].arg1
+}
+
+func (fake *FakeTeam) UpdateMaxContainersReturns(result1 error) {
+	fake.UpdateMaxContainersStub = nil
+	fake.updateMaxContainersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateMaxContainersReturnsOnCall(i int, result1 error) {
+	fake.UpdateMaxContainersStub = nil
+	if fake.updateMaxContainersReturnsOnCall == nil {
+		fake.updateMaxContainersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateMaxContainersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerQuotaReached() (bool, error) {
+	fake.containerQuotaReachedMutex.Lock()
+	ret, specificReturn := fake.containerQuotaReachedReturnsOnCall[len(fake.containerQuotaReachedArgsForCall)]
+	fake.containerQuotaReachedArgsForCall = append(fake.containerQuotaReachedArgsForCall, struct{}{})
+	fake.recordInvocation("ContainerQuotaReached", []interface{}{})
+	fake.containerQuotaReachedMutex.Unlock()
+	if fake.ContainerQuotaReachedStub != nil {
+		return fake.ContainerQuotaReachedStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.containerQuotaReachedReturns.result1, fake.containerQuotaReachedReturns.result2
+}
+
+func (fake *FakeTeam) ContainerQuotaReachedCallCount() int {
+	fake.containerQuotaReachedMutex.RLock()
+	defer fake.containerQuotaReachedMutex.RUnlock()
+	return len(fake.containerQuotaReachedArgsForCall)
+}
+
+func (fake *FakeTeam) ContainerQuotaReachedReturns(result1 bool, result2 error) {
+	fake.ContainerQuotaReachedStub = nil
+	fake.containerQuotaReachedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ContainerQuotaReachedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ContainerQuotaReachedStub = nil
+	if fake.containerQuotaReachedReturnsOnCall == nil {
+		fake.containerQuotaReachedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.containerQuotaReachedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SaveVar(name string, value string) error {
+	fake.saveVarMutex.Lock()
+	ret, specificReturn := fake.saveVarReturnsOnCall[len(fake.saveVarArgsForCall)]
+	fake.saveVarArgsForCall = append(fake.saveVarArgsForCall, struct {
+		name  string
+		value string
+	}{name, value})
+	fake.recordInvocation("SaveVar", []interface{}{name, value})
+	fake.saveVarMutex.Unlock()
+	if fake.SaveVarStub != nil {
+		return fake.SaveVarStub(name, value)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveVarReturns.result1
+}
+
+func (fake *FakeTeam) SaveVarCallCount() int {
+	fake.saveVarMutex.RLock()
+	defer fake.saveVarMutex.RUnlock()
+	return len(fake.saveVarArgsForCall)
+}
+
+func (fake *FakeTeam) SaveVarArgsForCall(i int) (string, string) {
+	fake.saveVarMutex.RLock()
+	defer fake.saveVarMutex.RUnlock()
+	return fake.saveVarArgsForCall[i].name, fake.saveVarArgsForCall[i].value
+}
+
+func (fake *FakeTeam) SaveVarReturns(result1 error) {
+	fake.SaveVarStub = nil
+	fake.saveVarReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SaveVarReturnsOnCall(i int, result1 error) {
+	fake.SaveVarStub = nil
+	if fake.saveVarReturnsOnCall == nil {
+		fake.saveVarReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveVarReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) Var(name string) (string, bool, error) {
+	fake.varMutex.Lock()
+	ret, specificReturn := fake.varReturnsOnCall[len(fake.varArgsForCall)]
+	fake.varArgsForCall = append(fake.varArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("Var", []interface{}{name})
+	fake.varMutex.Unlock()
+	if fake.VarStub != nil {
+		return fake.VarStub(name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.varReturns.result1, fake.varReturns.result2, fake.varReturns.result3
+}
+
+func (fake *FakeTeam) VarCallCount() int {
+	fake.varMutex.RLock()
+	defer fake.varMutex.RUnlock()
+	return len(fake.varArgsForCall)
+}
+
+func (fake *FakeTeam) VarArgsForCall(i int) string {
+	fake.varMutex.RLock()
+	defer fake.varMutex.RUnlock()
+	return fake.varArgsForCall[i].name
+}
+
+func (fake *FakeTeam) VarReturns(result1 string, result2 bool, result3 error) {
+	fake.VarStub = nil
+	fake.varReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) VarReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.VarStub = nil
+	if fake.varReturnsOnCall == nil {
+		fake.varReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.varReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) VarNames() ([]string, error) {
+	fake.varNamesMutex.Lock()
+	ret, specificReturn := fake.varNamesReturnsOnCall[len(fake.varNamesArgsForCall)]
+	fake.varNamesArgsForCall = append(fake.varNamesArgsForCall, struct{}{})
+	fake.recordInvocation("VarNames", []interface{}{})
+	fake.varNamesMutex.Unlock()
+	if fake.VarNamesStub != nil {
+		return fake.VarNamesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.varNamesReturns.result1, fake.varNamesReturns.result2
+}
+
+func (fake *FakeTeam) VarNamesCallCount() int {
+	fake.varNamesMutex.RLock()
+	defer fake.varNamesMutex.RUnlock()
+	return len(fake.varNamesArgsForCall)
+}
+
+func (fake *FakeTeam) VarNamesReturns(result1 []string, result2 error) {
+	fake.VarNamesStub = nil
+	fake.varNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) VarNamesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.VarNamesStub = nil
+	if fake.varNamesReturnsOnCall == nil {
+		fake.varNamesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.varNamesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteVar(name string) (bool, error) {
+	fake.deleteVarMutex.Lock()
+	ret, specificReturn := fake.deleteVarReturnsOnCall[len(fake.deleteVarArgsForCall)]
+	fake.deleteVarArgsForCall = append(fake.deleteVarArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("DeleteVar", []interface{}{name})
+	fake.deleteVarMutex.Unlock()
+	if fake.DeleteVarStub != nil {
+		return fake.DeleteVarStub(name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deleteVarReturns.result1, fake.deleteVarReturns.result2
+}
+
+func (fake *FakeTeam) DeleteVarCallCount() int {
+	fake.deleteVarMutex.RLock()
+	defer fake.deleteVarMutex.RUnlock()
+	return len(fake.deleteVarArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteVarArgsForCall(i int) string {
+	fake.deleteVarMutex.RLock()
+	defer fake.deleteVarMutex.RUnlock()
+	return fake.deleteVarArgsForCall[i].name
+}
+
+func (fake *FakeTeam) DeleteVarReturns(result1 bool, result2 error) {
+	fake.DeleteVarStub = nil
+	fake.deleteVarReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteVarReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DeleteVarStub = nil
+	if fake.deleteVarReturnsOnCall == nil {
+		fake.deleteVarReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteVarReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1622,6 +2105,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.adminMutex.RUnlock()
 	fake.authMutex.RLock()
 	defer fake.authMutex.RUnlock()
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.renameMutex.RLock()
@@ -1644,6 +2129,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.workersMutex.RLock()
@@ -1666,6 +2153,18 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createContainerMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.updateMaxContainersMutex.RLock()
+	defer fake.updateMaxContainersMutex.RUnlock()
+	fake.containerQuotaReachedMutex.RLock()
+	defer fake.containerQuotaReachedMutex.RUnlock()
+	fake.saveVarMutex.RLock()
+	defer fake.saveVarMutex.RUnlock()
+	fake.varMutex.RLock()
+	defer fake.varMutex.RUnlock()
+	fake.varNamesMutex.RLock()
+	defer fake.varNamesMutex.RUnlock()
+	fake.deleteVarMutex.RLock()
+	defer fake.deleteVarMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
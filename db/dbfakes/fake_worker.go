@@ -113,6 +113,42 @@ type FakeWorker struct {
 	activeContainersReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ActiveVolumesStub        func() int
+	activeVolumesMutex       sync.RWMutex
+	activeVolumesArgsForCall []struct{}
+	activeVolumesReturns     struct {
+		result1 int
+	}
+	activeVolumesReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ReservedMemoryStub        func() uint64
+	reservedMemoryMutex       sync.RWMutex
+	reservedMemoryArgsForCall []struct{}
+	reservedMemoryReturns     struct {
+		result1 uint64
+	}
+	reservedMemoryReturnsOnCall map[int]struct {
+		result1 uint64
+	}
+	BuildContainersStub        func() int
+	buildContainersMutex       sync.RWMutex
+	buildContainersArgsForCall []struct{}
+	buildContainersReturns     struct {
+		result1 int
+	}
+	buildContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
+	MaxBuildContainersStub        func() int
+	maxBuildContainersMutex       sync.RWMutex
+	maxBuildContainersArgsForCall []struct{}
+	maxBuildContainersReturns     struct {
+		result1 int
+	}
+	maxBuildContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
 	ResourceTypesStub        func() []atc.WorkerResourceType
 	resourceTypesMutex       sync.RWMutex
 	resourceTypesArgsForCall []struct{}
@@ -140,6 +176,15 @@ type FakeWorker struct {
 	tagsReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	LabelsStub        func() map[string]string
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct{}
+	labelsReturns     struct {
+		result1 map[string]string
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct{}
@@ -176,6 +221,15 @@ type FakeWorker struct {
 	expiresAtReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	DrainStartedAtStub        func() time.Time
+	drainStartedAtMutex       sync.RWMutex
+	drainStartedAtArgsForCall []struct{}
+	drainStartedAtReturns     struct {
+		result1 time.Time
+	}
+	drainStartedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct{}
@@ -673,6 +727,166 @@ func (fake *FakeWorker) ActiveContainersReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ActiveVolumes() int {
+	fake.activeVolumesMutex.Lock()
+	ret, specificReturn := fake.activeVolumesReturnsOnCall[len(fake.activeVolumesArgsForCall)]
+	fake.activeVolumesArgsForCall = append(fake.activeVolumesArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveVolumes", []interface{}{})
+	fake.activeVolumesMutex.Unlock()
+	if fake.ActiveVolumesStub != nil {
+		return fake.ActiveVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.activeVolumesReturns.result1
+}
+
+func (fake *FakeWorker) ActiveVolumesCallCount() int {
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	return len(fake.activeVolumesArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveVolumesReturns(result1 int) {
+	fake.ActiveVolumesStub = nil
+	fake.activeVolumesReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
+	fake.ActiveVolumesStub = nil
+	if fake.activeVolumesReturnsOnCall == nil {
+		fake.activeVolumesReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.activeVolumesReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ReservedMemory() uint64 {
+	fake.reservedMemoryMutex.Lock()
+	ret, specificReturn := fake.reservedMemoryReturnsOnCall[len(fake.reservedMemoryArgsForCall)]
+	fake.reservedMemoryArgsForCall = append(fake.reservedMemoryArgsForCall, struct{}{})
+	fake.recordInvocation("ReservedMemory", []interface{}{})
+	fake.reservedMemoryMutex.Unlock()
+	if fake.ReservedMemoryStub != nil {
+		return fake.ReservedMemoryStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.reservedMemoryReturns.result1
+}
+
+func (fake *FakeWorker) ReservedMemoryCallCount() int {
+	fake.reservedMemoryMutex.RLock()
+	defer fake.reservedMemoryMutex.RUnlock()
+	return len(fake.reservedMemoryArgsForCall)
+}
+
+func (fake *FakeWorker) ReservedMemoryReturns(result1 uint64) {
+	fake.ReservedMemoryStub = nil
+	fake.reservedMemoryReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) ReservedMemoryReturnsOnCall(i int, result1 uint64) {
+	fake.ReservedMemoryStub = nil
+	if fake.reservedMemoryReturnsOnCall == nil {
+		fake.reservedMemoryReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.reservedMemoryReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) BuildContainers() int {
+	fake.buildContainersMutex.Lock()
+	ret, specificReturn := fake.buildContainersReturnsOnCall[len(fake.buildContainersArgsForCall)]
+	fake.buildContainersArgsForCall = append(fake.buildContainersArgsForCall, struct{}{})
+	fake.recordInvocation("BuildContainers", []interface{}{})
+	fake.buildContainersMutex.Unlock()
+	if fake.BuildContainersStub != nil {
+		return fake.BuildContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.buildContainersReturns.result1
+}
+
+func (fake *FakeWorker) BuildContainersCallCount() int {
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	return len(fake.buildContainersArgsForCall)
+}
+
+func (fake *FakeWorker) BuildContainersReturns(result1 int) {
+	fake.BuildContainersStub = nil
+	fake.buildContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) BuildContainersReturnsOnCall(i int, result1 int) {
+	fake.BuildContainersStub = nil
+	if fake.buildContainersReturnsOnCall == nil {
+		fake.buildContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.buildContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxBuildContainers() int {
+	fake.maxBuildContainersMutex.Lock()
+	ret, specificReturn := fake.maxBuildContainersReturnsOnCall[len(fake.maxBuildContainersArgsForCall)]
+	fake.maxBuildContainersArgsForCall = append(fake.maxBuildContainersArgsForCall, struct{}{})
+	fake.recordInvocation("MaxBuildContainers", []interface{}{})
+	fake.maxBuildContainersMutex.Unlock()
+	if fake.MaxBuildContainersStub != nil {
+		return fake.MaxBuildContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.maxBuildContainersReturns.result1
+}
+
+func (fake *FakeWorker) MaxBuildContainersCallCount() int {
+	fake.maxBuildContainersMutex.RLock()
+	defer fake.maxBuildContainersMutex.RUnlock()
+	return len(fake.maxBuildContainersArgsForCall)
+}
+
+func (fake *FakeWorker) MaxBuildContainersReturns(result1 int) {
+	fake.MaxBuildContainersStub = nil
+	fake.maxBuildContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxBuildContainersReturnsOnCall(i int, result1 int) {
+	fake.MaxBuildContainersStub = nil
+	if fake.maxBuildContainersReturnsOnCall == nil {
+		fake.maxBuildContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxBuildContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) ResourceTypes() []atc.WorkerResourceType {
 	fake.resourceTypesMutex.Lock()
 	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
@@ -793,6 +1007,46 @@ func (fake *FakeWorker) TagsReturnsOnCall(i int, result1 []string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Labels() map[string]string {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct{}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.labelsReturns.result1
+}
+
+func (fake *FakeWorker) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakeWorker) LabelsReturns(result1 map[string]string) {
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeWorker) LabelsReturnsOnCall(i int, result1 map[string]string) {
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
 func (fake *FakeWorker) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -953,6 +1207,46 @@ func (fake *FakeWorker) ExpiresAtReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeWorker) DrainStartedAt() time.Time {
+	fake.drainStartedAtMutex.Lock()
+	ret, specificReturn := fake.drainStartedAtReturnsOnCall[len(fake.drainStartedAtArgsForCall)]
+	fake.drainStartedAtArgsForCall = append(fake.drainStartedAtArgsForCall, struct{}{})
+	fake.recordInvocation("DrainStartedAt", []interface{}{})
+	fake.drainStartedAtMutex.Unlock()
+	if fake.DrainStartedAtStub != nil {
+		return fake.DrainStartedAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.drainStartedAtReturns.result1
+}
+
+func (fake *FakeWorker) DrainStartedAtCallCount() int {
+	fake.drainStartedAtMutex.RLock()
+	defer fake.drainStartedAtMutex.RUnlock()
+	return len(fake.drainStartedAtArgsForCall)
+}
+
+func (fake *FakeWorker) DrainStartedAtReturns(result1 time.Time) {
+	fake.DrainStartedAtStub = nil
+	fake.drainStartedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeWorker) DrainStartedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.DrainStartedAtStub = nil
+	if fake.drainStartedAtReturnsOnCall == nil {
+		fake.drainStartedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.drainStartedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeWorker) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1181,12 +1475,22 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.noProxyMutex.RUnlock()
 	fake.activeContainersMutex.RLock()
 	defer fake.activeContainersMutex.RUnlock()
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	fake.reservedMemoryMutex.RLock()
+	defer fake.reservedMemoryMutex.RUnlock()
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	fake.maxBuildContainersMutex.RLock()
+	defer fake.maxBuildContainersMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.platformMutex.RLock()
 	defer fake.platformMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
@@ -1195,6 +1499,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.expiresAtMutex.RLock()
 	defer fake.expiresAtMutex.RUnlock()
+	fake.drainStartedAtMutex.RLock()
+	defer fake.drainStartedAtMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.landMutex.RLock()
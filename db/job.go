@@ -32,6 +32,7 @@ type Job interface {
 
 	CreateBuild() (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch, Page) ([]BuildLogSearchResult, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
@@ -124,7 +125,14 @@ func (j *job) Pause() error {
 }
 
 func (j *job) Unpause() error {
-	return j.updatePausedJob(false)
+	err := j.updatePausedJob(false)
+	if err != nil {
+		return err
+	}
+
+	notifyScheduler(j.conn, j.pipelineID)
+
+	return nil
 }
 
 func (j *job) FinishedAndNextBuild() (Build, Build, error) {
@@ -260,6 +268,10 @@ func (j *job) Builds(page Page) ([]Build, Pagination, error) {
 	return builds, pagination, nil
 }
 
+func (j *job) SearchBuildLogs(search BuildLogSearch, page Page) ([]BuildLogSearchResult, Pagination, error) {
+	return searchBuildLogs(j.conn, j.lockFactory, sq.Eq{"b.job_id": j.id}, search, page)
+}
+
 func (j *job) Build(name string) (Build, bool, error) {
 	var query sq.SelectBuilder
 
@@ -0,0 +1,82 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add log search index", func() {
+	const preMigrationVersion = 1524400000
+	const postMigrationVersion = 1524500000
+
+	var (
+		db *sql.DB
+	)
+
+	setupEventsTables := func(db *sql.DB) {
+		_, err := db.Exec(`
+			CREATE TABLE team_build_events_1 () INHERITS (build_events);
+			CREATE TABLE pipeline_build_events_1 () INHERITS (build_events);
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	validLogSearchIndexes := func(db *sql.DB) []string {
+		rows, err := db.Query(`
+			SELECT c.relname
+			FROM pg_index i
+			JOIN pg_class c ON c.oid = i.indexrelid
+			WHERE c.relname LIKE '%_log_search'
+			AND i.indisvalid
+			ORDER BY c.relname
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		indexes := []string{}
+		for rows.Next() {
+			var index string
+			err := rows.Scan(&index)
+			Expect(err).NotTo(HaveOccurred())
+
+			indexes = append(indexes, index)
+		}
+
+		return indexes
+	}
+
+	Context("Up", func() {
+		It("indexes the log events of every build events table", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+			setupEventsTables(db)
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+			indexes := validLogSearchIndexes(db)
+			_ = db.Close()
+
+			Expect(indexes).To(Equal([]string{
+				"pipeline_build_events_1_log_search",
+				"team_build_events_1_log_search",
+			}))
+		})
+	})
+
+	Context("Down", func() {
+		It("drops the indexes", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+			setupEventsTables(db)
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+			indexes := validLogSearchIndexes(db)
+			_ = db.Close()
+
+			Expect(indexes).To(BeEmpty())
+		})
+	})
+})
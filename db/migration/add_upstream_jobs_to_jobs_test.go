@@ -0,0 +1,69 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add upstream jobs to jobs", func() {
+	const preMigrationVersion = 1524700000
+	const postMigrationVersion = 1524800000
+
+	var (
+		db *sql.DB
+	)
+
+	Context("Up", func() {
+		It("stores the jobs of other pipelines in the passed constraints of every active job", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			_, err := db.Exec(`
+				INSERT INTO teams(id, name) VALUES
+				(1, 'some-team')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO pipelines(id, team_id, name) VALUES
+				(1, 1, 'pipeline1'),
+				(2, 1, 'pipeline2')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO jobs(id, pipeline_id, name, config, active) VALUES
+				(1, 1, 'job1', '{"name":"job1"}', true),
+				(2, 2, 'job1', '{"name":"job1","plan":[{"get":"a","passed":["pipeline1/job1","job2"]},{"aggregate":[{"get":"b","passed":["pipeline1/job1","pipeline3/job1"]}]}]}', true),
+				(3, 2, 'job2', '{"name":"job2","plan":[{"get":"a","passed":["pipeline1/job1"]}]}', false)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+
+			rows, err := db.Query(`SELECT id, upstream_jobs FROM jobs`)
+			Expect(err).NotTo(HaveOccurred())
+
+			upstreamJobs := map[int][]string{}
+			for rows.Next() {
+				var id int
+				var jobs []string
+
+				err := rows.Scan(&id, pq.Array(&jobs))
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamJobs[id] = jobs
+			}
+
+			_ = db.Close()
+
+			Expect(upstreamJobs[1]).To(BeEmpty())
+			Expect(upstreamJobs[2]).To(ConsistOf("pipeline1/job1", "pipeline3/job1"))
+			Expect(upstreamJobs[3]).To(BeEmpty())
+		})
+	})
+})
@@ -24,6 +24,34 @@
 // db/migration/migrations/1522176230_add_tags_to_jobs.up.sql
 // db/migration/migrations/1522178770_add_job_tags.down.sql
 // db/migration/migrations/1522178770_add_job_tags.up.go
+// db/migration/migrations/1523372418_create_team_vars.down.sql
+// db/migration/migrations/1523372418_create_team_vars.up.sql
+// db/migration/migrations/1523887200_create_audit_events.up.sql
+// db/migration/migrations/1523887200_create_audit_events.down.sql
+// db/migration/migrations/1523973600_create_pipeline_configs.down.sql
+// db/migration/migrations/1524060000_add_worker_load_columns.up.sql
+// db/migration/migrations/1524060000_add_worker_load_columns.down.sql
+// db/migration/migrations/1524120000_add_container_quotas.down.sql
+// db/migration/migrations/1524120000_add_container_quotas.up.sql
+// db/migration/migrations/1524180000_add_priority_to_jobs.down.sql
+// db/migration/migrations/1524180000_add_priority_to_jobs.up.sql
+// db/migration/migrations/1524240000_add_pinned_version_to_resources.down.sql
+// db/migration/migrations/1524240000_add_pinned_version_to_resources.up.sql
+// db/migration/migrations/1524300000_add_check_order_index_to_versioned_resources.down.sql
+// db/migration/migrations/1524300000_add_check_order_index_to_versioned_resources.up.sql
+// db/migration/migrations/1524400000_add_events_archived_to_builds.down.sql
+// db/migration/migrations/1524400000_add_events_archived_to_builds.up.sql
+// db/migration/migrations/1524600000_add_drain_started_at_to_workers.down.sql
+// db/migration/migrations/1524600000_add_drain_started_at_to_workers.up.sql
+// db/migration/migrations/1524700000_add_labels_to_workers.down.sql
+// db/migration/migrations/1524700000_add_labels_to_workers.up.sql
+// db/migration/migrations/1523973600_create_pipeline_configs.up.go
+// db/migration/migrations/1524500000_add_log_search_index_to_build_events.down.go
+// db/migration/migrations/1524500000_add_log_search_index_to_build_events.up.go
+// db/migration/migrations/1524800000_add_upstream_jobs_to_jobs.up.go
+// db/migration/migrations/1524800000_add_upstream_jobs_to_jobs.down.sql
+// db/migration/migrations/1524900000_add_events_archive_attempts_to_builds.up.sql
+// db/migration/migrations/1524900000_add_events_archive_attempts_to_builds.down.sql
 // DO NOT EDIT!
 
 package migration
@@ -571,6 +599,566 @@ func _1522178770_add_job_tagsUpGo() (*asset, error) {
 	return a, nil
 }
 
+var __1523372418_create_team_varsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x2a\x49\x4d\xcc\x8d\x2f\x4b\x2c\x2a\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xfa\xfc\xe4\xc5\x29\x00\x00\x00")
+
+func _1523372418_create_team_varsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523372418_create_team_varsDownSql,
+		"1523372418_create_team_vars.down.sql",
+	)
+}
+
+func _1523372418_create_team_varsDownSql() (*asset, error) {
+	bytes, err := _1523372418_create_team_varsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523372418_create_team_vars.down.sql", size: 41, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523372418_create_team_varsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x8d\x90\xcf\x0a\xc2\x30\x0c\xc6\xef\x7b\x8a\xd0\x93\x83\xbd\xc1\x4e\xb5\x46\x29\x6e\xa9\x76\xdd\xc1\xd3\x28\x5a\x65\xa8\x13\xb6\x29\xfa\xf6\xee\xaf\x0c\x44\x30\xc7\xef\x97\xe4\xfb\x92\x39\xae\x24\x85\x1e\x80\xd0\xc8\x0d\x82\xe1\xf3\x08\x81\xd5\xce\x5e\xb3\x87\x2d\x2b\x06\xb3\x06\xb6\xc5\xf2\x03\x83\xca\x95\xb9\xbd\x04\xa3\xd4\xb5\xb5\x7a\x5e\xd4\xee\xe4\x4a\x20\x65\x80\xd2\x28\xfa\x74\x14\xf6\xea\x18\xd4\xee\x59\x7f\xb3\x87\xbd\xdc\x7f\xc2\xe2\x56\xec\x07\x38\x6a\x1b\x2d\x63\xae\x77\xb0\xc6\x1d\xcc\xda\x38\xfe\x48\x84\xa2\xc4\x68\x2e\xc9\x4c\xa2\x67\x43\xba\xec\x78\x76\x2f\x06\x4b\xa5\x51\xae\x68\x98\x1e\x93\xfb\xa0\x71\x89\x1a\x49\x60\xd2\xcf\x56\xac\xdf\x0d\x8a\x60\x81\x11\x36\x4f\x11\x3c\x11\x7c\x81\xff\xb9\xb5\x17\x67\x9d\x63\x4a\x72\x9b\xe2\xc4\x2c\x18\xfe\xe1\x37\x8b\xfc\xd0\x13\x2a\x8e\xa5\x09\xbd\x37\x0b\xe9\xb2\xad\x84\x01\x00\x00")
+
+func _1523372418_create_team_varsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523372418_create_team_varsUpSql,
+		"1523372418_create_team_vars.up.sql",
+	)
+}
+
+func _1523372418_create_team_varsUpSql() (*asset, error) {
+	bytes, err := _1523372418_create_team_varsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523372418_create_team_vars.up.sql", size: 388, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523887200_create_audit_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x7d\x90\xcd\x6a\xc3\x30\x10\x84\xef\x7e\x8a\x41\x27\x1b\xfa\x06\x3e\x39\x89\x5a\x4c\x6c\x39\x18\x05\x9a\x93\x51\xf1\xe2\x28\xd4\x72\xb1\xd7\x49\xe8\xd3\x57\x0a\x34\x3f\x84\x56\x07\x81\xf4\xcd\x0c\x3b\xbb\x90\x6f\xb9\x4a\x23\x60\x59\xcb\x4c\x4b\xe8\x6c\x51\x48\x08\x33\xb7\x96\x1b\x3a\x92\xe3\x49\x20\xf6\x3c\x1c\x61\x5b\x81\x0f\xdb\x4d\x34\x5a\xf3\xf9\xf2\xfb\xcb\xb6\x27\x81\x70\x4f\x6c\xfa\x2f\x9c\x2c\xef\x2f\x4f\x7c\x0f\x8e\xa0\x2a\x0d\xb5\x2d\x0a\xac\xe4\x6b\xb6\x2d\x34\xdc\x70\x8a\x93\xab\x7b\xf6\x69\x8d\x33\x97\x08\x3a\xf3\x55\x7e\x8b\x27\xd3\xff\x2b\x18\x87\x99\xff\x76\x9b\xb1\x23\x16\x38\x4c\x83\x7b\xa6\x7e\x60\x9e\x7d\x43\xeb\x98\x3a\x1a\x9f\x04\x9b\x3a\x2f\xb3\x7a\x87\xb5\xdc\x21\x0e\xf5\x13\x0f\x92\x34\xba\x2d\x2c\x57\x2b\xf9\x8e\xfb\x7d\x35\xa1\x7a\x63\xdb\x33\x2a\xf5\x00\x10\x07\xe2\xdd\xcb\xaa\x2c\x73\x9d\x46\x3f\x64\xc1\x64\xdb\x7d\x01\x00\x00")
+
+func _1523887200_create_audit_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523887200_create_audit_eventsUpSql,
+		"1523887200_create_audit_events.up.sql",
+	)
+}
+
+func _1523887200_create_audit_eventsUpSql() (*asset, error) {
+	bytes, err := _1523887200_create_audit_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523887200_create_audit_events.up.sql", size: 381, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523887200_create_audit_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\x2c\x4d\xc9\x2c\x89\x4f\x2d\x4b\xcd\x2b\x29\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x79\xac\xb4\xc6\x2c\x00\x00\x00")
+
+func _1523887200_create_audit_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523887200_create_audit_eventsDownSql,
+		"1523887200_create_audit_events.down.sql",
+	)
+}
+
+func _1523887200_create_audit_eventsDownSql() (*asset, error) {
+	bytes, err := _1523887200_create_audit_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523887200_create_audit_events.down.sql", size: 44, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523973600_create_pipeline_configsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x2a\xc8\x2c\x48\xcd\xc9\xcc\x4b\x8d\x4f\xce\xcf\x4b\xcb\x4c\x2f\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xde\xb1\x99\xce\x30\x00\x00\x00")
+
+func _1523973600_create_pipeline_configsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523973600_create_pipeline_configsDownSql,
+		"1523973600_create_pipeline_configs.down.sql",
+	)
+}
+
+func _1523973600_create_pipeline_configsDownSql() (*asset, error) {
+	bytes, err := _1523973600_create_pipeline_configsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523973600_create_pipeline_configs.down.sql", size: 48, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524060000_add_worker_load_columnsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x5d\xcc\x4b\x0a\xc3\x20\x14\x05\xd0\xb9\xab\xb8\x4b\xe8\xdc\x91\x89\xb6\x04\xfc\x40\xd1\xb1\xd8\xf0\x08\xd2\x18\xc1\xd8\x94\xee\xbe\x9d\x15\x72\x16\x70\x06\x75\x9b\x2c\x67\x80\xd0\x5e\xdd\xe1\xc5\xa0\x15\xde\xb5\x3d\xa9\xed\x10\x52\x62\x74\x3a\x18\x8b\x34\xf7\x7c\x50\x3c\xea\xfa\x2a\xb4\x23\x6f\x9d\x16\x6a\x90\xea\x2a\x82\xf6\xb8\x70\x76\x3a\xe6\xba\xf5\x94\xb7\x53\x53\xa8\xa7\x58\xa8\xd4\xf6\x89\x6b\x2e\xb9\xe3\x91\x97\x5f\xf6\x8f\x60\x9d\x87\x0d\x5a\x73\x36\x3a\x63\x26\xcf\xd9\x17\x3d\xe8\xe3\x17\xa4\x00\x00\x00")
+
+func _1524060000_add_worker_load_columnsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524060000_add_worker_load_columnsUpSql,
+		"1524060000_add_worker_load_columns.up.sql",
+	)
+}
+
+func _1524060000_add_worker_load_columnsUpSql() (*asset, error) {
+	bytes, err := _1524060000_add_worker_load_columnsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524060000_add_worker_load_columns.up.sql", size: 164, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524060000_add_worker_load_columnsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x5d\xcb\x4b\x0a\x80\x20\x10\x00\xd0\xbd\xa7\x98\x7b\xb8\xea\x23\x11\xf8\x09\xb1\xb5\x48\xcc\x42\x6a\x14\x6c\x2a\xba\x7d\xfb\xde\xfe\xf5\x6a\x9a\xad\x14\x00\x9d\x0e\xca\x43\xe8\x7a\xad\x60\xab\x85\x53\x2e\xd8\x4e\x18\xbd\x5b\x60\x70\x7a\x35\x16\x08\x39\x45\x42\xaa\xed\x8d\x47\xa6\xcc\x52\xfc\xe2\x53\xdb\xfe\x5f\x69\xe3\x7c\x63\xbc\xeb\x71\x11\x9e\x52\x0c\xce\x98\x39\x48\xf1\x01\xb2\xf7\x5d\x81\x7a\x00\x00\x00")
+
+func _1524060000_add_worker_load_columnsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524060000_add_worker_load_columnsDownSql,
+		"1524060000_add_worker_load_columns.down.sql",
+	)
+}
+
+func _1524060000_add_worker_load_columnsDownSql() (*asset, error) {
+	bytes, err := _1524060000_add_worker_load_columnsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524060000_add_worker_load_columns.down.sql", size: 122, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524120000_add_container_quotasDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4d\xac\x88\x4f\x2a\xcd\xcc\x49\x89\x4f\xce\xcf\x2b\x49\xcc\xcc\x03\x2a\xb0\xe6\x42\xd3\x58\x92\x9a\x98\x8b\xa9\x0d\x59\x83\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x00\x26\xb0\x55\x73\x78\x00\x00\x00")
+
+func _1524120000_add_container_quotasDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524120000_add_container_quotasDownSql,
+		"1524120000_add_container_quotas.down.sql",
+	)
+}
+
+func _1524120000_add_container_quotasDownSql() (*asset, error) {
+	bytes, err := _1524120000_add_container_quotasDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524120000_add_container_quotas.down.sql", size: 120, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524120000_add_container_quotasUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4d\xac\x88\x4f\x2a\xcd\xcc\x49\x89\x4f\xce\xcf\x2b\x49\xcc\xcc\x03\xc9\x67\xe6\x95\xa4\xa6\xa7\x16\x29\xb8\xb8\xba\x39\x86\xfa\x84\x28\x18\x28\xf8\xf9\x87\x28\xf8\x85\xfa\xf8\x58\x73\xa1\x19\x59\x92\x9a\x98\x8b\x61\x20\x91\x46\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x63\xf7\xb2\xeb\xac\x00\x00\x00")
+
+func _1524120000_add_container_quotasUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524120000_add_container_quotasUpSql,
+		"1524120000_add_container_quotas.up.sql",
+	)
+}
+
+func _1524120000_add_container_quotasUpSql() (*asset, error) {
+	bytes, err := _1524120000_add_container_quotasUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524120000_add_container_quotas.up.sql", size: 172, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524180000_add_priority_to_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xca\x4f\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x28\xca\xcc\x2f\xca\x2c\xa9\xb4\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xeb\x3a\xf9\x8e\x38\x00\x00\x00")
+
+func _1524180000_add_priority_to_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524180000_add_priority_to_jobsDownSql,
+		"1524180000_add_priority_to_jobs.down.sql",
+	)
+}
+
+func _1524180000_add_priority_to_jobsDownSql() (*asset, error) {
+	bytes, err := _1524180000_add_priority_to_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524180000_add_priority_to_jobs.down.sql", size: 56, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524180000_add_priority_to_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x05\xc1\x4d\x0a\x80\x20\x10\x06\xd0\xbd\xa7\xf8\x8e\xd0\xde\x95\x3f\x53\x08\xa3\x42\x8c\x17\x08\x24\x6c\x91\x61\x6e\xba\x7d\xef\x59\xda\x42\xd2\x0a\x30\x2c\xb4\x43\x8c\x65\xc2\xd5\x8f\x17\xc6\x7b\xb8\xcc\x25\x26\x3c\xa3\xf5\xd1\xe6\x87\x76\xcf\x7a\xd6\x01\x4f\xab\x29\x2c\x58\x90\xb2\x20\x15\x66\xad\x5c\x8e\x31\x88\x56\x3f\x51\xd5\xc7\xaf\x52\x00\x00\x00")
+
+func _1524180000_add_priority_to_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524180000_add_priority_to_jobsUpSql,
+		"1524180000_add_priority_to_jobs.up.sql",
+	)
+}
+
+func _1524180000_add_priority_to_jobsUpSql() (*asset, error) {
+	bytes, err := _1524180000_add_priority_to_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524180000_add_priority_to_jobs.up.sql", size: 82, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524240000_add_pinned_version_to_resourcesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x06\x8a\x2b\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x14\x64\xe6\xe5\xa5\xa6\xc4\x97\xa5\x16\x15\x67\xe6\xe7\xc5\x67\xa6\xe8\xe0\x52\x92\x54\x89\x55\x2a\x3e\x39\x3f\x37\x37\x35\xaf\xc4\x9a\xcb\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\xa7\x9b\x45\x87\x82\x00\x00\x00")
+
+func _1524240000_add_pinned_version_to_resourcesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524240000_add_pinned_version_to_resourcesDownSql,
+		"1524240000_add_pinned_version_to_resources.down.sql",
+	)
+}
+
+func _1524240000_add_pinned_version_to_resourcesDownSql() (*asset, error) {
+	bytes, err := _1524240000_add_pinned_version_to_resourcesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524240000_add_pinned_version_to_resources.down.sql", size: 130, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524240000_add_pinned_version_to_resourcesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x75\x8d\xb1\x0a\xc2\x30\x14\x45\xf7\x7c\xc5\x1d\x15\xfc\x83\x4e\x6d\xf2\x94\x42\x9a\x42\x9a\xce\x01\xdb\x87\x64\x68\x2a\x69\x14\xfd\x7b\x83\x88\x93\xae\xf7\x1c\xce\x6d\xe8\xd4\x9a\x4a\x00\xb5\x76\x64\xe1\xea\x46\x13\x12\x6f\xeb\x2d\x4d\xbc\x95\xbd\x10\xa5\x20\x7b\x3d\x76\x06\xd7\x10\x23\xcf\xfe\xce\x69\x0b\x6b\xf4\x61\x46\x88\x99\x2f\x9c\x60\xe9\x48\x96\x8c\xa4\x01\x1f\x5a\xbc\x6f\x07\xbb\x30\xef\xd1\x1b\x28\xd2\xe4\x08\x03\x39\x98\x51\xeb\xc3\x9f\x83\xf3\x13\x99\x1f\xf9\x17\xf6\xd3\xba\x2c\x1c\xf3\x5b\xa8\x84\xec\xbb\xae\x75\x95\x78\x01\x0c\x1d\x96\x39\xc8\x00\x00\x00")
+
+func _1524240000_add_pinned_version_to_resourcesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524240000_add_pinned_version_to_resourcesUpSql,
+		"1524240000_add_pinned_version_to_resources.up.sql",
+	)
+}
+
+func _1524240000_add_pinned_version_to_resourcesUpSql() (*asset, error) {
+	bytes, err := _1524240000_add_pinned_version_to_resourcesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524240000_add_pinned_version_to_resources.up.sql", size: 200, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524300000_add_check_order_index_to_versioned_resourcesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x28\x4b\x2d\x2a\xce\xcc\xcf\x4b\x4d\x89\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x86\xb3\xe2\x33\x53\xe2\x93\x33\x52\x93\xb3\xe3\xf3\x8b\x52\x52\x8b\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x0e\x3f\x29\xb6\x49\x00\x00\x00")
+
+func _1524300000_add_check_order_index_to_versioned_resourcesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524300000_add_check_order_index_to_versioned_resourcesDownSql,
+		"1524300000_add_check_order_index_to_versioned_resources.down.sql",
+	)
+}
+
+func _1524300000_add_check_order_index_to_versioned_resourcesDownSql() (*asset, error) {
+	bytes, err := _1524300000_add_check_order_index_to_versioned_resourcesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524300000_add_check_order_index_to_versioned_resources.down.sql", size: 73, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524300000_add_check_order_index_to_versioned_resourcesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x28\x4b\x2d\x2a\xce\xcc\xcf\x4b\x4d\x89\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x86\xb3\xe2\x33\x53\xe2\x93\x33\x52\x93\xb3\xe3\xf3\x8b\x52\x52\x8b\x14\xfc\xfd\xb0\x29\x57\xd0\x40\x52\xaf\xa3\x80\xac\xc1\xc5\x35\xd8\x59\xd3\x9a\xcb\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\x2e\x22\x25\xda\x82\x00\x00\x00")
+
+func _1524300000_add_check_order_index_to_versioned_resourcesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524300000_add_check_order_index_to_versioned_resourcesUpSql,
+		"1524300000_add_check_order_index_to_versioned_resources.up.sql",
+	)
+}
+
+func _1524300000_add_check_order_index_to_versioned_resourcesUpSql() (*asset, error) {
+	bytes, err := _1524300000_add_check_order_index_to_versioned_resourcesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524300000_add_check_order_index_to_versioned_resources.up.sql", size: 130, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524400000_add_events_archived_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\x4f\xce\xcf\x2d\xc8\x49\x2d\x49\x4d\x89\x2f\xcd\x4b\x2c\x4a\xce\xc8\x2c\x03\x32\x53\xcb\x52\xf3\x4a\x8a\xad\xb9\x80\x3a\x1c\x7d\x42\x5c\x83\x14\x42\x1c\x9d\x7c\x5c\xa1\x5a\x20\x86\x38\xfb\xfb\x84\xfa\xfa\x29\x40\x54\xc6\xc3\x74\x5a\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x95\x3c\x4d\x7d\x73\x00\x00\x00")
+
+func _1524400000_add_events_archived_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524400000_add_events_archived_to_buildsDownSql,
+		"1524400000_add_events_archived_to_builds.down.sql",
+	)
+}
+
+func _1524400000_add_events_archived_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524400000_add_events_archived_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524400000_add_events_archived_to_builds.down.sql", size: 115, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524400000_add_events_archived_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x5d\x8e\x39\x0e\xc2\x30\x14\x05\xfb\x9c\xe2\x95\x70\x06\x57\x26\xfe\x40\x24\xc7\x96\x82\x23\xe8\xac\x2c\x1f\x11\x29\x9b\xb2\x9d\x1f\x08\x84\x82\xf6\x49\x6f\x66\x0e\x74\x8a\x8c\x08\x00\xa9\x1d\x25\x70\xf2\xa0\x09\xf9\x5c\xd5\xe5\x08\xa9\x14\x42\xab\xd3\xd8\x80\x17\x6e\xa7\xd1\x67\x43\xf1\xa8\x16\x2e\x91\x77\x5d\xcd\x59\x0b\x63\x1d\x4c\xaa\x35\x14\x1d\x65\xaa\x1d\xee\x59\x3d\xb2\x08\x5e\xc0\x30\x21\xe9\x08\x91\x51\x74\xfb\x12\x7d\xd1\x35\x7d\xcd\x13\x97\x7e\x6e\x37\x96\xff\xb0\x61\xcd\xe6\xdd\x55\xe5\x1e\xd7\x33\x25\x84\xdf\x01\xd2\xa8\xd5\xf6\x5f\xf2\xde\x07\xce\x7a\x3f\x55\x0d\x23\xba\xac\x39\x22\x08\x6d\x1c\x47\x4e\x04\x4f\xf0\xf9\x93\x40\xe0\x00\x00\x00")
+
+func _1524400000_add_events_archived_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524400000_add_events_archived_to_buildsUpSql,
+		"1524400000_add_events_archived_to_builds.up.sql",
+	)
+}
+
+func _1524400000_add_events_archived_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524400000_add_events_archived_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524400000_add_events_archived_to_builds.up.sql", size: 224, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524600000_add_drain_started_at_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x29\x4a\xcc\xcc\x8b\x2f\x2e\x49\x2c\x2a\x49\x4d\x89\x4f\x2c\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x57\xa1\x2b\x04\x43\x00\x00\x00")
+
+func _1524600000_add_drain_started_at_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524600000_add_drain_started_at_to_workersDownSql,
+		"1524600000_add_drain_started_at_to_workers.down.sql",
+	)
+}
+
+func _1524600000_add_drain_started_at_to_workersDownSql() (*asset, error) {
+	bytes, err := _1524600000_add_drain_started_at_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524600000_add_drain_started_at_to_workers.down.sql", size: 67, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524600000_add_drain_started_at_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x65\xcc\x41\x0b\x82\x30\x18\xc6\xf1\xfb\x3e\xc5\x73\x53\xa1\x6f\x30\x3a\x4c\x1d\x35\xd0\x19\x36\xe9\x28\x03\x47\x8d\x72\xc6\x7c\x41\xe8\xd3\xb7\xba\x74\xe8\xf6\xfc\x79\xe0\x57\xca\x83\xd2\x9c\x01\xa2\x31\xb2\x87\x11\x65\x23\xb1\x2d\xf1\xee\xe2\x0a\x51\xd7\xa8\xba\x66\x68\x35\xa6\x68\x7d\x18\x57\xb2\x91\xdc\x34\x5a\x02\xf9\xd9\xa5\x9c\x9f\xd8\x3c\xdd\xbe\x89\xd7\x12\x1c\x67\x09\x1b\x4e\xb5\x30\x3f\xe7\x2c\xcd\x3f\xb0\x47\x58\xb6\xbc\xc0\xe5\x28\x7b\x89\x74\x90\x83\xd2\xc8\xb3\x87\x0d\x93\x0f\xd7\x6c\x87\x2c\x3a\xf2\xf1\xb3\x0b\xce\xaa\xae\x6d\x95\xe1\xec\x0d\x49\x45\xb8\xed\xb2\x00\x00\x00")
+
+func _1524600000_add_drain_started_at_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524600000_add_drain_started_at_to_workersUpSql,
+		"1524600000_add_drain_started_at_to_workers.up.sql",
+	)
+}
+
+func _1524600000_add_drain_started_at_to_workersUpSql() (*asset, error) {
+	bytes, err := _1524600000_add_drain_started_at_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524600000_add_drain_started_at_to_workers.up.sql", size: 178, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524700000_add_labels_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x49\x4c\x4a\xcd\x29\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xcb\xa9\x7c\x42\x39\x00\x00\x00")
+
+func _1524700000_add_labels_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524700000_add_labels_to_workersDownSql,
+		"1524700000_add_labels_to_workers.down.sql",
+	)
+}
+
+func _1524700000_add_labels_to_workersDownSql() (*asset, error) {
+	bytes, err := _1524700000_add_labels_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524700000_add_labels_to_workers.down.sql", size: 57, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524700000_add_labels_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x05\xc1\x4b\x0a\x80\x20\x14\x05\xd0\xb9\xab\xb8\x33\x17\xe1\xc8\x5f\x21\x3c\x15\xe2\xb9\x80\x02\x47\x09\x81\x0a\x05\xd1\xde\x3b\xc7\xf8\x35\x24\x25\x00\x4d\xec\x37\xb0\x36\xe4\x71\x5f\xfd\xac\x7d\x40\x3b\x07\x9b\xa9\xc4\x84\xb6\x1f\xb5\x0d\xcc\xfa\x4c\xa4\xcc\x48\x85\x08\xce\x2f\xba\x10\x43\xbe\x9f\x54\xc2\xe6\x18\x03\x2b\xf1\x03\x5a\xd7\xee\xfa\x53\x00\x00\x00")
+
+func _1524700000_add_labels_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524700000_add_labels_to_workersUpSql,
+		"1524700000_add_labels_to_workers.up.sql",
+	)
+}
+
+func _1524700000_add_labels_to_workersUpSql() (*asset, error) {
+	bytes, err := _1524700000_add_labels_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524700000_add_labels_to_workers.up.sql", size: 83, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523973600_create_pipeline_configsUpGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x9d\x56\x6d\x6f\xdb\x36\x10\xfe\x2c\xfd\x8a\x9b\x10\xa4\x72\x2b\x38\x4d\xb3\x17\xac\x80\x3f\x38\x8e\xd2\x79\x73\xe4\x56\xb6\x3b\x14\x45\xe1\x30\x12\xed\xb0\x95\x25\x8d\xa4\x93\x78\x45\xfe\xfb\x8e\xa4\x44\xdb\x72\x9a\x38\x0b\x20\x07\x7c\xb9\xb7\xe7\xee\x9e\x63\x49\x92\x6f\x64\x4e\x61\xc1\xe6\x9c\x48\x56\xe4\xc2\x75\xd9\xa2\x2c\xb8\x04\xdf\x75\xbc\x94\x48\x72\x45\x04\x3d\x12\xff\x64\x1e\xae\x69\x9e\x14\x29\xcb\xe7\x47\x5f\x45\x91\x7b\x6e\xcb\x75\xe5\xaa\xa4\x50\xb2\x92\x66\x2c\xa7\xbd\x22\x9f\xb1\x39\x08\xc9\x97\x89\x84\xef\xae\xf3\x8e\x17\xcb\x52\x40\xf5\xa7\x84\xda\x31\xb9\xbd\xa0\x42\x28\xa3\x00\x97\x6a\xeb\xad\x37\xd7\xd7\x82\x62\xc1\x24\x5d\x94\x72\xe5\x5d\xba\x4e\x4c\x45\xb1\xe4\x09\x35\xd2\x9f\xbf\x34\x85\x2b\x51\x5e\x5f\x7b\x58\x7a\x8c\xee\x89\xa7\xa5\xa7\x2a\x8c\x86\x8a\x3f\x8b\x2b\xeb\xf9\x23\x0e\x7c\xc5\x6b\x5b\x82\xf7\xae\x3b\x5b\xe6\x09\xf8\x82\x66\x33\x78\xb9\x46\xb6\x05\x93\x72\x7a\xfc\xcb\x9b\x93\xdf\x7f\x3b\xf9\xf5\xf5\x6b\xbf\x05\x94\xf3\x82\x2b\x9c\xe4\x5d\xa0\x16\xf0\xb6\x03\x4a\xaa\x7d\x76\xda\x3e\xa5\x73\x96\xfb\x2d\xd7\x61\x33\x7d\xf4\x53\x07\x72\x96\xa9\xcb\x0e\xa7\x72\xc9\x73\xb5\xeb\x3a\x68\xcd\x49\xe9\x8c\x72\x50\x46\x51\xa7\xba\x30\x85\x0e\xc8\xbb\x76\x5c\x64\xd9\x15\x26\x58\x69\xb9\xc7\x1f\xd7\x99\x1a\x33\xfa\x34\xbc\xa3\x89\x8f\x71\x3a\xbd\x38\xec\x8e\x43\x18\x77\x4f\x07\x21\x78\x75\x2e\xa7\x89\x4e\xa6\xf0\x54\x21\x38\x8e\xc7\x52\x0f\x7d\xe3\x8c\x64\x81\x5e\xdb\x7b\xea\x80\xe5\x92\xce\xd1\x87\x68\x38\x86\x68\x32\x18\x98\x2b\x37\x94\x0b\x0c\xdc\x83\x2b\x86\xb1\xc8\xc6\xa9\xd1\xef\x81\xa4\x77\xcd\xa3\xbc\xc8\x13\x6a\x4e\xcc\x06\x59\xca\xeb\x82\x3f\x78\x37\xe1\x94\x48\x9a\x4e\x89\xc4\x63\xb6\xa0\x42\x92\x45\x09\xb7\x4c\x5e\xeb\x25\xfc\x5b\xe4\xd4\x8a\xc0\x59\x78\xde\x9d\x0c\xc6\x90\x17\xb7\x7e\x4b\x2b\x78\x1f\xf7\x2f\xba\xf1\x27\xf8\x2b\xfc\x04\xbe\x8a\xd2\x6c\xf7\x86\xd1\x68\x1c\x77\xfb\xd1\x78\x17\x92\xe9\x46\xec\xd3\xd9\x37\xba\xf2\xe0\x7c\x18\x87\xfd\x77\x51\xa5\x65\x13\x9b\x16\xc4\xe1\x79\x18\x87\x51\x2f\x1c\xad\x55\x09\xcf\xd8\x82\x61\x84\x3e\x0d\x42\xc4\xbf\xd7\x1d\xf5\xba\x67\xe1\xf3\xac\x57\x10\x4f\xb5\x13\x93\xa8\xff\x61\x12\x36\xec\x07\x60\xf3\x80\x65\xe0\xe0\x77\xb9\x57\x51\xf1\xe2\x56\xd8\xa2\xc4\x72\xf9\xb0\xa4\x7c\xe5\x7b\x23\x74\xb6\x37\x06\x96\x06\x50\xa9\x0d\xc0\xb4\x2f\x9c\xc7\xc3\x0b\x58\x07\xb8\x97\x95\x2d\xfa\xd8\x20\x0e\x87\xa5\xa6\xef\xb0\x6e\x70\x55\x99\xaa\x56\x06\x08\x68\xd0\x8e\x51\x68\xed\x2b\xb7\x3f\x7f\xa9\x97\xdf\xef\x5d\x67\x86\xbd\xa6\xa2\x6a\x47\x58\x45\x55\xa7\xdc\x10\x0e\xa5\x55\x54\x6d\x54\xf1\x20\xe7\xb5\xa3\x65\x96\x8d\x24\x47\xca\x43\xdd\x8e\xe9\x1d\xad\x63\x94\x90\xdc\x3f\x2c\xdb\x0a\x08\xfc\x67\xb1\x38\x34\xc2\x0a\xea\xdd\xe8\x75\x63\x6a\xf1\x5e\x56\x08\xaa\xfa\x72\x1b\x12\x1d\x82\x12\x34\x5a\xda\x1f\x49\x86\x40\x1c\x1e\xd6\x6b\xe3\x8a\x52\xe9\xe5\xe8\x99\x67\xb4\x96\x6d\x83\x48\xbb\xa2\xdb\x4e\x93\x69\xfd\x2d\xf1\x56\x6d\x67\x8d\x55\x07\x48\x59\xd2\x3c\xf5\xed\x56\x00\x65\xcb\x20\xba\x11\xb4\xf5\xfa\xe9\xc4\x1e\x1d\x81\xbc\xa6\x90\x2c\x39\xa7\xd8\xfa\x55\xca\x0a\x94\x23\xc9\xf5\x3a\xe3\x0c\x61\x26\x37\x34\x05\x22\x80\x49\x01\x33\xc6\x85\x5c\x57\x96\x28\x2a\x4d\x44\xe2\x31\x20\xe6\x70\x45\x21\x65\xb3\x99\x12\x99\x13\x96\xe3\x6d\x6d\xc7\xf4\x46\xa5\x6c\xc6\x8b\x85\x6a\x71\x28\x72\x93\x76\xe4\xbd\x52\x15\x04\x27\xf9\x7c\x5d\x6f\x42\x7b\x6e\xd1\xb3\x13\xa7\x26\x49\x4d\xc5\x24\x91\xec\xa6\x2a\x31\xe1\x2b\xa2\x5e\xcf\x1c\x6c\x2e\x55\x01\x3f\x48\xf6\x6e\x62\x77\x4c\xe9\xf1\xb4\xaf\x39\x33\xa4\xfe\xb7\x4d\x35\xcf\x9e\x32\xa5\x86\xd9\xb3\x0d\x90\x55\x56\x90\xd4\x72\x85\xae\xbd\x0b\xc2\xc5\x35\xc9\xfc\xda\xfa\xde\xda\xf0\x85\xc1\x57\x25\x12\xfa\xfb\x5a\xad\x1e\x06\xdb\xe3\x31\x34\x97\xfc\xca\xf4\xde\xca\x1f\x1c\x7f\x4e\x3f\x1a\x85\xf1\x18\x90\x6c\x87\xd0\x24\x5b\xf0\x37\xb8\x74\x83\xf2\xcc\xb1\x75\xce\xcc\x27\xdd\xce\x1f\xbb\x83\x09\x32\xbd\x7f\x70\x1c\xc0\xc1\x1b\xfc\x4e\xf0\xfb\x39\x80\x17\x2f\xd4\xf1\xa5\x01\x57\xfd\x5a\x5d\x3f\x08\x79\xbf\xa8\x0c\x57\x9b\x3d\x8c\xaa\x57\x2c\xf0\x29\x82\x2d\xfa\xc8\x2b\xa4\x99\x77\x78\xa9\x98\x6e\x8c\xf9\xc7\xc7\x5e\xa6\x59\x18\x59\x22\xb0\x58\xf4\xcf\x14\xef\xb6\xc0\xdf\x79\x03\x05\xe6\x05\xa3\xa9\xf4\xe1\x79\xa1\x00\xae\x46\xc6\x16\x64\xb8\xad\xc7\xc5\xe5\x2b\x6d\xf3\x95\xba\xf7\xf7\x1f\x38\x26\x61\x03\x6f\x4c\xd4\xc1\xb1\x8a\xb9\x1b\x9d\x55\x5e\xab\xdc\xf1\xa5\x12\x1f\xc6\x67\x61\x0c\xa7\x9f\x70\x14\x01\x4e\x4e\x57\x43\x6b\x3d\x7e\x8c\xa3\x70\x19\x34\x1f\x4f\x5b\xf4\xe6\x3a\x75\xf6\xf5\x0c\x69\x04\xfd\xd8\x28\x49\xec\x03\x58\x4d\x0c\xb3\xa7\xe3\xdd\x67\x98\xd4\x00\x1d\xee\x91\xfe\x75\x0c\xa6\xb2\xad\xa1\x5c\x50\xcc\x67\x6d\x1f\x15\xe8\xdd\x6a\x90\x68\x0d\xf6\x5a\xa7\xb2\x54\x8d\x85\x5a\x55\x4a\xab\x72\x6c\x3c\x49\xcd\xb6\xbf\x95\x46\x54\xf3\x3c\x3f\x6b\x60\xed\xc0\xa9\x36\x82\x9d\x91\x65\xdd\x68\xb5\xb6\x8a\xdc\x0a\x68\xe4\x42\xce\x75\xb1\xff\x07\xfa\x65\x96\x41\xc8\x0c\x00\x00")
+
+func _1523973600_create_pipeline_configsUpGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1523973600_create_pipeline_configsUpGo,
+		"1523973600_create_pipeline_configs.up.go",
+	)
+}
+
+func _1523973600_create_pipeline_configsUpGo() (*asset, error) {
+	bytes, err := _1523973600_create_pipeline_configsUpGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523973600_create_pipeline_configs.up.go", size: 3272, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524500000_add_log_search_index_to_build_eventsDownGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x6d\x8e\x5d\x4f\x83\x30\x14\x86\xaf\x39\xbf\xe2\xc8\x15\xb8\x85\xa9\x71\x37\x26\xbb\x71\x60\x42\x62\x98\x32\x4c\xe6\x15\x2b\xac\xb0\xc6\xd2\xb2\x52\xd4\xc4\xec\xbf\xaf\xed\x96\x18\x13\xcf\xe5\x7b\xde\x8f\xa7\x27\xf5\x07\x69\x29\x76\xac\x55\x44\x33\x29\x06\x00\xd6\xf5\x52\x69\xf4\x5b\xa6\xf7\x63\x15\xd5\xb2\x9b\x71\x56\xcd\xfa\x83\x0f\xd0\x8c\xa2\xc6\x60\xa0\xbc\xc1\xeb\xdf\x4c\x88\xb1\xfc\x12\xe5\xed\xfc\xee\x7e\x7e\x63\x2f\x08\x91\x2a\x25\x15\xfe\x80\xa7\x49\xc5\xe9\x30\xb5\x02\x3e\x2c\xd0\x66\xa3\x6a\x64\x7c\x97\x7c\x52\xa1\x87\xc2\xbd\x83\x10\x3c\xd6\x38\xcf\xd5\x02\x05\xe3\x36\xe9\x29\xaa\x47\x25\xac\x0a\xde\x11\xc0\x6b\x4c\x63\x39\x45\xd7\x68\xbb\x14\x11\x86\xfd\x3c\xe0\x02\xe5\x79\xe6\xb2\x12\x3f\x46\xc9\x37\xad\x83\x6d\x9c\xaf\x5e\x30\xcd\xe2\x64\x83\xcb\x55\xb6\x7c\xcb\xf3\x24\x2b\x9e\xdf\x31\x7d\xc2\x64\x93\xae\x8b\x35\x6e\x71\x82\xfd\x21\x7a\x1d\xa5\xa6\xe9\xce\x70\xb1\x86\x51\x15\xb8\xea\x89\x5f\x72\xd9\x96\x03\x25\xaa\xde\xfb\xa1\x21\xfd\x07\xf5\x0f\xab\x81\x75\xbc\x17\xcd\x78\xe0\x08\x27\x06\x53\xf0\x16\x6b\x01\x00\x00")
+
+func _1524500000_add_log_search_index_to_build_eventsDownGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1524500000_add_log_search_index_to_build_eventsDownGo,
+		"1524500000_add_log_search_index_to_build_events.down.go",
+	)
+}
+
+func _1524500000_add_log_search_index_to_build_eventsDownGo() (*asset, error) {
+	bytes, err := _1524500000_add_log_search_index_to_build_eventsDownGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524500000_add_log_search_index_to_build_events.down.go", size: 363, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524500000_add_log_search_index_to_build_eventsUpGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x95\x54\x6d\x4f\x13\x41\x10\xfe\xdc\xfb\x15\x23\x31\xf4\xaa\xf5\x2a\x46\xbe\x34\x29\x89\x96\x53\x6b\xb0\xd5\x52\xa2\xc6\x98\xb2\xbd\x9b\x6b\x57\xb6\xbb\xc7\xee\xb6\x40\x08\xff\xdd\xd9\xdd\x3b\x5a\x04\x34\xf2\x85\xde\xec\xbc\x3e\xf3\xcc\x53\xb2\xec\x8c\xcd\x11\x96\x7c\xae\x99\xe5\x4a\x9a\x28\xe2\xcb\x52\x69\x0b\x71\xd4\xd8\xc9\x99\x65\x33\x66\xb0\x63\xce\xc5\x4e\x44\x86\x39\xb7\x8b\xd5\x2c\xc9\xd4\xb2\x23\xf8\xac\x53\x9e\xef\x44\xad\x28\xea\x74\xe0\xa4\x9c\xee\xed\xbf\x7a\xbd\xff\xd2\xfd\x01\x97\x39\x5e\xa2\x01\xbb\x40\x10\x6a\x0e\xb8\x46\x69\x0d\xa8\xc2\xfd\xd2\x57\x30\x5b\x71\x91\xd7\x56\x2a\x21\x10\x0a\xa5\xa1\x58\x09\xe1\x92\x59\xbc\xb4\x60\x90\xe9\x6c\x91\xc0\x84\x72\xd4\xf9\x98\x46\x1f\x6b\x21\x53\x32\x5b\x69\x4d\x09\xc4\x15\x18\x45\x95\x98\x0d\x69\x0d\x64\x4c\xc2\x19\x62\xe9\x52\x19\xb6\xe6\xf2\xb6\x81\x25\x32\x79\xb1\xe0\x02\xdb\x40\xff\xb2\x85\x73\x6d\x52\x1c\x42\xae\xa4\x2b\x03\x0c\xac\x66\xd2\xb0\xcc\x81\x91\x44\xc5\x4a\x66\x10\x1b\x14\x05\x3c\xdb\x60\xd4\xba\x3b\x6e\xdc\x02\xd4\x9a\xfa\xbf\x8e\x1a\x7e\x18\xd3\x76\x06\xe8\xf6\xc0\x45\x26\xbe\xad\xd4\x77\x30\xf1\xcf\x71\x2b\x6a\xf0\xc2\xfb\x3c\xe9\x81\xe4\xc2\x45\x36\x34\xda\x95\x96\xce\x1a\x35\x6e\x08\x6c\x87\xc8\xb4\x5d\xc1\x43\xb9\xa8\x2f\xda\x54\x28\xe0\x03\x3c\x2a\xee\x25\xb8\x3c\x87\x9d\x29\x81\x3d\x0d\xc0\xb9\x75\x35\xd6\x4c\xc3\x9a\x09\x9e\xc3\x4c\x29\x41\x86\xed\xb6\x0e\xdf\x26\x5f\x56\xb4\x8d\xb1\xba\x88\x4f\xe9\xad\x71\x9c\x1e\xa5\xfd\x09\xf0\x84\x12\x73\xe3\xe3\x9c\xf9\xdd\x78\xf4\x09\xca\xf9\x34\x94\xe3\xce\xf4\x71\x34\x18\x3a\x53\x26\x98\x21\xbc\x61\x34\x84\x2c\x51\x54\xa6\x17\xa2\xf1\x52\x63\x15\xfd\xf5\x43\x3a\x4e\xe9\x95\x0c\x92\x2d\x91\x3c\x9e\xee\x91\xfd\xb4\x1d\x96\xda\x4a\x8e\x69\x07\xf1\xae\xaf\xd6\x72\x43\xdd\xc1\x65\x77\xb7\xfe\x22\x06\x26\xa9\xd6\x43\x45\xed\x86\xf1\xef\x00\xe6\x11\xab\x83\x7b\xb7\xc1\x61\x78\xef\x4d\x8c\xb1\x5c\xae\xb0\xf6\x25\x72\xb0\x2d\x1a\x55\x9c\x0c\xb4\x28\x18\x71\x24\x07\x81\x6c\xed\x58\x27\xa9\xd7\x90\x29\x60\x30\xc3\x05\xfd\xb8\x57\xcf\x97\x99\x86\xdd\x6f\x30\x4e\x2f\x31\x8b\x4f\x0f\xc7\xa3\xcf\x30\x18\x1e\xa6\xdf\xa0\x3f\x1a\xf6\x4f\xc6\xe3\x74\x38\x39\xfa\x0e\xa7\xb4\xb6\xf2\x9c\x16\xa1\x2c\x0e\x72\xea\x83\x17\x1c\x75\x1c\xb0\x71\x78\x3c\x40\x94\x3f\x26\xa7\x71\xaa\x91\x1e\xa9\xed\x7c\xfa\xe3\xf4\xcd\x24\xfd\xef\x0e\xe8\xcd\x87\xd3\x82\x1f\xf1\xf3\xdc\xbb\xf5\x3b\x39\x1e\x0c\xdf\xc3\x9c\x0e\x29\xb6\x6a\x6a\xcd\x1a\x33\xab\x74\xdc\x34\x24\x2a\x02\x9b\x6d\x88\x4b\x76\x25\x14\xcb\xbb\xdd\x5f\x46\xc9\x59\xeb\xc5\xc1\x41\xb3\x32\x35\xc3\xbc\x81\x30\xf6\xaa\x74\x5c\x69\x12\xa1\x9b\x8e\x2e\xf7\xa9\xf1\x10\x05\x3c\x0c\x95\x8d\x7c\x22\xfa\x7c\xec\x82\x1f\x38\x4a\x88\x7f\xfc\x34\x56\x93\x5e\xb4\xc3\x41\xb7\x5c\x11\x4d\x7c\xbb\x7b\xcf\xf5\xe1\x78\x64\xab\xa3\xb9\x25\x78\xb4\x7d\x30\x0b\xd4\x9c\x64\xc7\xdd\xcc\x3f\x4e\x66\x51\x1f\x4c\x18\xdf\x9b\x4a\xe6\x89\x49\x28\xf8\x66\xa7\x41\xc4\x9a\xdd\xae\xc6\xb9\x4f\x13\x79\x5c\x1e\x55\x12\xfa\x6c\x6f\xe4\x24\xc7\x02\x35\xb8\x69\x92\xbe\x50\x06\x49\x84\x6a\xb9\x72\x93\xd5\xa3\x5f\xdf\x04\xe5\xf1\x8e\x43\x12\xe2\xd8\xa3\xe0\xa5\x24\xe8\x4c\xf0\xab\xc4\xa4\x17\x1c\xc3\x11\x07\x2e\xfc\x75\x53\x9b\x9e\x02\x65\xab\x06\x7a\xc0\xca\x12\x65\x1e\xd7\xfa\x59\xa7\xda\x5a\x68\xfd\xe4\x0b\x92\x14\xd0\x00\x37\xd1\x6f\xaf\x6d\x07\xcb\xc1\x06\x00\x00")
+
+func _1524500000_add_log_search_index_to_build_eventsUpGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1524500000_add_log_search_index_to_build_eventsUpGo,
+		"1524500000_add_log_search_index_to_build_events.up.go",
+	)
+}
+
+func _1524500000_add_log_search_index_to_build_eventsUpGo() (*asset, error) {
+	bytes, err := _1524500000_add_log_search_index_to_build_eventsUpGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524500000_add_log_search_index_to_build_events.up.go", size: 1729, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524800000_add_upstream_jobs_to_jobsUpGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xa5\x56\x6d\x6f\x1a\x47\x10\xfe\xcc\xfd\x8a\x09\xaa\xc8\x11\x9d\x8e\x24\x6a\xa5\x2a\x12\x95\x88\xb9\xb4\x89\x08\x76\x0d\xb4\x95\x10\xc2\xcb\xdd\x70\x6c\x7c\xec\x9e\x77\xf7\x6c\x23\x8b\xff\xde\xd9\xdd\xc3\x80\x43\x5a\x4b\xf6\x07\x60\x5f\xe6\x99\x67\x67\x9e\x99\x71\xc9\xd2\x6b\x96\x23\xac\x79\xae\x98\xe1\x52\xe8\x20\xe0\xeb\x52\x2a\x03\x61\xd0\x68\x66\xcc\xb0\x05\xd3\xd8\xd1\x37\x45\x93\xd6\x28\x52\x99\x71\x91\x77\xbe\x69\x29\xec\x86\x36\x8a\x96\xba\x19\xd0\xef\x9c\x9b\x55\xb5\x88\x53\xb9\xee\x14\x7c\xd1\x29\x6f\x9a\x41\x3b\x08\x3a\x1d\x98\x94\xf3\x77\xbf\xbc\xff\xf9\xd7\xb7\xf6\x0f\xb4\x91\x0a\x35\x98\x15\xc2\x37\xb9\xd0\x20\x97\x20\x69\xa1\xa0\xe4\x25\x16\x5c\xd0\x99\x60\x6b\xcc\x80\x0b\x77\xa9\x64\x5a\x63\x66\x71\x52\xa2\x67\x14\xe3\xc2\x38\x2b\xbc\x45\xb5\xb1\x18\x11\x68\x49\x57\x99\x81\x25\x17\x5c\xaf\x88\x11\x30\x58\x54\xbc\xc8\x20\x65\x02\x0a\x29\xaf\xa1\x2a\x2d\x9a\x85\xd9\xfb\xc9\xe4\x9d\x45\x44\xb6\xb6\x78\x9c\x60\x09\x0d\xee\xe8\x1d\xb2\x32\x90\x61\xaa\x36\xa5\xb1\x68\x64\xc9\x95\xf5\xbf\xe4\xb9\x8e\x83\x65\x25\x52\x08\x35\x16\x4b\x78\xb3\x8f\x5c\xfb\xf8\xa1\x61\x1b\x50\x29\xa9\xe0\x21\x68\x98\xfb\xc8\x2e\xe0\x43\x17\xac\x55\xdc\xff\x18\x7f\xc4\x9c\x8b\xb0\x1d\x34\xf8\xd2\x1d\xbd\xea\x82\xe0\x85\xbd\xdc\x50\x68\x2a\x25\xec\x6e\xd0\xd8\x52\x64\x33\x5c\x52\x78\xac\x53\xc2\xb4\x17\xe6\xd0\x05\x73\x1f\x5f\xca\xa2\x58\x50\x02\x2d\xca\x96\x3e\x82\xc6\xdc\xbb\x71\xa7\xc9\x3d\xa6\xe1\x55\x6f\x30\x4e\x2e\x61\xdc\xfb\x38\x48\x7c\xb8\x7b\xfd\x3e\x9c\x9d\x0f\x26\x5f\x87\x14\x12\xff\xf8\xb9\x3b\x30\x78\x6f\xa6\x33\x18\x9e\x8f\x61\x38\x19\x0c\xa0\x9f\x7c\xea\x4d\x06\x63\x78\xfd\xb0\x7d\x7d\xf5\x2c\x9e\xdf\x7b\x3f\xbb\x4c\x7a\xe3\x04\x3e\x0f\xfb\xc9\x3f\xce\xfd\xfc\xc8\xe7\x9c\x67\xf7\x70\x3e\xf4\xc4\x26\xa3\xcf\xc3\xdf\x81\x82\x02\xe1\xd1\xa5\xf6\xf3\x9c\x2b\x79\xa7\x1f\x83\x4c\x04\xfe\xac\x48\x1d\xe1\xd5\x28\x19\x24\x67\x63\xe0\x59\x54\xa7\x2f\x02\x21\x45\x8a\xf0\xe9\xf2\xfc\xab\x77\xfc\xf7\x1f\xc9\x65\x02\x2c\x35\xfc\x16\x2d\x79\x55\xe1\xf3\x5c\xee\x68\x7e\xb1\x28\xe4\x75\xcd\xca\x29\x89\x73\x36\x9d\xf9\xb2\x78\xd8\x06\x8d\x25\x09\xc0\x52\x8b\x87\x14\xde\x3a\x7d\xb7\x4c\x11\x21\xd2\xb7\xa9\x17\x9e\x19\x78\xab\x7a\xcf\xb3\xa4\xba\x8b\x87\x55\x51\x8c\xfc\x11\x9d\xf9\x08\x3b\xc8\x11\x89\x3b\x6c\xd9\xa7\xb5\x76\x6f\x6b\x39\x33\x62\x7f\x82\xbe\x93\x8d\x33\x3c\x2b\xa4\x46\xab\x9a\xe3\x37\xb9\x47\xed\x9d\x0b\x8d\xf0\xe6\x91\x13\xe1\xb9\xdd\xf8\x2f\x56\x10\x79\x07\xf8\x78\xad\x5b\x3b\x8e\x47\xbb\xeb\x0e\xaa\xae\x20\xcc\x9e\xa8\xdf\x6f\x87\x47\x09\x21\x98\x17\xd3\x2e\xd9\xa6\x90\xcc\x45\x16\xd5\x92\xa5\x68\x33\x50\x47\xcc\x76\xac\x78\x22\xd6\x4c\xe9\x15\x2b\xc2\x03\x6a\xad\xda\xec\x45\xee\x7d\x97\xb2\x4f\x4c\x95\xd4\xfa\xa2\xee\x31\x17\x6e\x3b\xac\x3d\x44\x4e\x22\x3e\xa4\xb3\x85\x94\xc5\xc3\xb6\x76\x5a\xa0\x08\x3d\x44\x1b\x7e\x83\xb7\xde\xf3\xa1\xbe\xa6\x3c\x9b\x11\x91\xba\x19\x5a\xa7\xce\xef\x81\x1a\x1e\xe9\xfd\xbf\x70\xad\x28\xad\x6c\xf6\xa4\x15\x13\x34\x05\x8e\x04\xed\x1a\xcd\x77\x15\x3d\xb9\xe8\xdb\x8a\x76\x85\x33\x4a\xc6\x4f\x7a\x48\x17\x7e\x7a\x57\xd7\x13\x69\x84\x56\xef\xaf\xc8\xcb\x4d\xdc\x53\x8a\x6d\x76\x0f\x8c\xe8\xec\x07\xb1\x7e\x12\x57\x5f\xd8\x7e\x8f\x18\x9c\xc9\xf5\x9a\x53\x11\x05\x5b\x37\x56\x4e\x04\x9a\x2a\xa9\x28\x30\x35\x7e\xbc\x34\x77\x9d\xbe\x43\xe4\x9a\x6e\xaa\xe8\xff\x9e\x2a\x4b\x59\x89\x0c\x98\xd8\xdc\xd1\x40\xc2\xdd\xdd\x9c\x1a\x83\x70\xa3\x61\xa7\xd8\xbb\x15\x4f\x57\xb4\xa2\x09\xa4\x21\x47\x63\x71\xb4\xc1\x92\x46\x17\xd2\xb7\x9b\x5d\x99\x8c\x80\xe5\xb9\xc2\x9c\x19\x8c\xa8\xad\x6c\x08\x38\x83\x15\x0d\x23\x0d\x54\x43\xd7\x58\x0f\x92\x53\x82\xa9\x3b\xc2\x81\x90\x69\xc8\x21\xb1\x78\x22\xa0\x36\xec\x9a\x8d\x0d\xa0\x7f\x21\xa5\xf3\xa0\x03\x05\x0d\x4d\x03\xcd\xb2\x75\xe2\x74\xb8\x71\x68\x36\x25\xba\x66\x94\xd2\x74\x3f\x04\x3d\xf0\xf8\x81\x72\x60\x95\x72\x8d\x9b\x08\x6e\x59\x51\xe1\x5e\x29\xa9\xcf\x17\xa5\x90\x4e\xa1\xdb\xa5\x58\x3b\xe2\x4d\xbf\x5f\x97\x43\x04\x73\x6b\xe2\x6c\xe3\x70\x7a\x08\xee\xca\xc8\xc1\x93\xc6\xca\x3d\x70\x2d\x49\x8f\xe2\x1e\x14\x01\x4d\x6f\x3a\x2f\xe3\xd0\x73\xf4\xa6\xd6\x37\x1d\xb4\x5a\x75\xdb\xa4\x02\x90\xc2\x50\x1a\x75\xe8\xad\x9a\x9d\x66\xdb\x1e\xbf\xb2\x71\x9b\xda\xbd\xd9\x0e\xb6\x71\xb0\xe5\x1b\x7e\xbd\xef\x03\xd8\x05\x56\x96\x28\x32\x07\x44\x43\xc5\x7e\xd5\x4e\xb7\x81\xff\x74\x5f\x14\x4c\xfa\xef\xc0\x1b\xfb\xad\xd3\xf6\xa7\x12\xec\x62\xe2\x73\xda\x8e\xe3\xb8\xed\xf5\xee\xb2\x31\x3d\x95\x84\xf9\x8f\x52\xf0\x52\x97\x07\x25\xe6\x6c\xa9\xb8\xfe\x05\x39\x49\xd2\x6b\x15\x0a\x00\x00")
+
+func _1524800000_add_upstream_jobs_to_jobsUpGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1524800000_add_upstream_jobs_to_jobsUpGo,
+		"1524800000_add_upstream_jobs_to_jobs.up.go",
+	)
+}
+
+func _1524800000_add_upstream_jobs_to_jobsUpGo() (*asset, error) {
+	bytes, err := _1524800000_add_upstream_jobs_to_jobsUpGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524800000_add_upstream_jobs_to_jobs.up.go", size: 2581, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524800000_add_upstream_jobs_to_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xc8\xca\x4f\x2a\x8e\x2f\x2d\x28\x2e\x29\x4a\x4d\xcc\x8d\x07\xf3\x32\x53\x2a\x40\x8a\x1c\x7d\x42\x5c\x83\x14\x42\x1c\x9d\x7c\x5c\xc1\xaa\x20\xba\x9c\xfd\x7d\x42\x7d\xfd\x14\x50\x74\x58\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x9c\x90\x64\x69\x62\x00\x00\x00")
+
+func _1524800000_add_upstream_jobs_to_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524800000_add_upstream_jobs_to_jobsDownSql,
+		"1524800000_add_upstream_jobs_to_jobs.down.sql",
+	)
+}
+
+func _1524800000_add_upstream_jobs_to_jobsDownSql() (*asset, error) {
+	bytes, err := _1524800000_add_upstream_jobs_to_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524800000_add_upstream_jobs_to_jobs.down.sql", size: 98, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524900000_add_events_archive_attempts_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x8d\x8f\x3d\x0b\xc2\x30\x14\x45\xf7\xfe\x8a\x3b\x2a\x38\xb8\x77\x8a\xcd\x53\x0b\x69\x22\x35\x45\xb7\x50\xed\x43\x03\x6d\x2d\x6d\xf4\xf7\x2b\xf5\x03\x1c\x04\xd7\x0b\xe7\x70\xee\x82\x56\xa9\x8e\x23\x40\x28\x4b\x39\xac\x58\x28\xc2\xe1\xea\xeb\x6a\x80\x90\x12\x89\x51\x45\xa6\xc1\x37\x6e\xc3\xe0\xca\xfe\x78\xf6\x37\x76\x65\x08\xdc\x74\x61\x80\x6f\x03\x9f\xb8\x87\x36\x16\xba\x50\x0a\x92\x96\xa2\x50\x16\xf3\x38\x7a\x48\x65\x6e\x36\x48\xb5\xa4\xfd\xcb\xe9\x8e\x97\xa6\xab\x39\x70\xe5\xae\xed\xcb\x56\xb9\xa7\x7d\x24\x92\x9c\x84\xa5\xbf\x19\x18\xfd\xae\x9d\xfc\x68\x9c\xc1\x57\x53\xec\xd6\x94\x13\x3e\x26\x08\x2d\xc7\xe8\x6f\xe8\xb9\xf7\x5c\x76\x2e\xf8\x86\x91\x6e\xc7\x57\x71\x94\x98\x2c\x4b\x6d\x1c\xdd\x01\xf6\xad\x5d\xde\x2f\x01\x00\x00")
+
+func _1524900000_add_events_archive_attempts_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524900000_add_events_archive_attempts_to_buildsUpSql,
+		"1524900000_add_events_archive_attempts_to_builds.up.sql",
+	)
+}
+
+func _1524900000_add_events_archive_attempts_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524900000_add_events_archive_attempts_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524900000_add_events_archive_attempts_to_builds.up.sql", size: 303, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524900000_add_events_archive_attempts_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\x8d\x8f\xc1\x0e\x82\x30\x10\x44\xef\x7c\xc5\x1e\xf5\x1b\x38\x15\xd8\x68\x93\xb2\x35\xb5\x44\x6f\x0d\xc2\x26\x36\x01\x24\x50\xf8\x7e\x11\xc5\x83\x27\x6f\x93\xc9\xbc\xc9\x4c\x82\x07\x49\x71\x04\x90\x19\x7d\x02\x49\x19\x5e\xe1\x36\xf9\xa6\x1e\x5d\xf5\x68\xfb\x86\x03\xd7\x6e\xea\xca\xa1\xba\xfb\x79\x91\x3c\x73\x17\xc6\x38\x5a\x88\xd4\xa0\xb0\xf8\x37\x03\x9a\x3e\x29\xd8\xf9\x7a\x0f\x97\x23\x1a\x84\x2f\x00\x82\x32\x20\x6d\xe1\x9d\x76\x1b\xbd\xfa\x03\x97\xbd\x0b\xbe\x65\x90\x67\xa0\x42\xa9\x75\x80\x50\x16\x0d\x58\x91\x28\xdc\x9a\xd7\x17\xa9\x56\x45\x4e\x3f\x45\xae\x0c\x81\xdb\xfe\xb5\x3d\xd5\x79\x2e\x6d\x1c\x3d\x01\xff\xcc\x7e\x35\xfc\x00\x00\x00")
+
+func _1524900000_add_events_archive_attempts_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524900000_add_events_archive_attempts_to_buildsDownSql,
+		"1524900000_add_events_archive_attempts_to_builds.down.sql",
+	)
+}
+
+func _1524900000_add_events_archive_attempts_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524900000_add_events_archive_attempts_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524900000_add_events_archive_attempts_to_builds.down.sql", size: 252, mode: os.FileMode(420), modTime: time.Unix(1523372418, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -647,6 +1235,34 @@ var _bindata = map[string]func() (*asset, error){
 	"1522176230_add_tags_to_jobs.up.sql": _1522176230_add_tags_to_jobsUpSql,
 	"1522178770_add_job_tags.down.sql": _1522178770_add_job_tagsDownSql,
 	"1522178770_add_job_tags.up.go": _1522178770_add_job_tagsUpGo,
+	"1523372418_create_team_vars.down.sql": _1523372418_create_team_varsDownSql,
+	"1523372418_create_team_vars.up.sql": _1523372418_create_team_varsUpSql,
+	"1523887200_create_audit_events.up.sql": _1523887200_create_audit_eventsUpSql,
+	"1523887200_create_audit_events.down.sql": _1523887200_create_audit_eventsDownSql,
+	"1523973600_create_pipeline_configs.down.sql": _1523973600_create_pipeline_configsDownSql,
+	"1524060000_add_worker_load_columns.up.sql": _1524060000_add_worker_load_columnsUpSql,
+	"1524060000_add_worker_load_columns.down.sql": _1524060000_add_worker_load_columnsDownSql,
+	"1524120000_add_container_quotas.down.sql": _1524120000_add_container_quotasDownSql,
+	"1524120000_add_container_quotas.up.sql": _1524120000_add_container_quotasUpSql,
+	"1524180000_add_priority_to_jobs.down.sql": _1524180000_add_priority_to_jobsDownSql,
+	"1524180000_add_priority_to_jobs.up.sql": _1524180000_add_priority_to_jobsUpSql,
+	"1524240000_add_pinned_version_to_resources.down.sql": _1524240000_add_pinned_version_to_resourcesDownSql,
+	"1524240000_add_pinned_version_to_resources.up.sql": _1524240000_add_pinned_version_to_resourcesUpSql,
+	"1524300000_add_check_order_index_to_versioned_resources.down.sql": _1524300000_add_check_order_index_to_versioned_resourcesDownSql,
+	"1524300000_add_check_order_index_to_versioned_resources.up.sql": _1524300000_add_check_order_index_to_versioned_resourcesUpSql,
+	"1524400000_add_events_archived_to_builds.down.sql": _1524400000_add_events_archived_to_buildsDownSql,
+	"1524400000_add_events_archived_to_builds.up.sql": _1524400000_add_events_archived_to_buildsUpSql,
+	"1524600000_add_drain_started_at_to_workers.down.sql": _1524600000_add_drain_started_at_to_workersDownSql,
+	"1524600000_add_drain_started_at_to_workers.up.sql": _1524600000_add_drain_started_at_to_workersUpSql,
+	"1524700000_add_labels_to_workers.down.sql": _1524700000_add_labels_to_workersDownSql,
+	"1524700000_add_labels_to_workers.up.sql": _1524700000_add_labels_to_workersUpSql,
+	"1523973600_create_pipeline_configs.up.go": _1523973600_create_pipeline_configsUpGo,
+	"1524500000_add_log_search_index_to_build_events.down.go": _1524500000_add_log_search_index_to_build_eventsDownGo,
+	"1524500000_add_log_search_index_to_build_events.up.go": _1524500000_add_log_search_index_to_build_eventsUpGo,
+	"1524800000_add_upstream_jobs_to_jobs.up.go": _1524800000_add_upstream_jobs_to_jobsUpGo,
+	"1524800000_add_upstream_jobs_to_jobs.down.sql": _1524800000_add_upstream_jobs_to_jobsDownSql,
+	"1524900000_add_events_archive_attempts_to_builds.up.sql": _1524900000_add_events_archive_attempts_to_buildsUpSql,
+	"1524900000_add_events_archive_attempts_to_builds.down.sql": _1524900000_add_events_archive_attempts_to_buildsDownSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -713,6 +1329,34 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1522176230_add_tags_to_jobs.up.sql": &bintree{_1522176230_add_tags_to_jobsUpSql, map[string]*bintree{}},
 	"1522178770_add_job_tags.down.sql": &bintree{_1522178770_add_job_tagsDownSql, map[string]*bintree{}},
 	"1522178770_add_job_tags.up.go": &bintree{_1522178770_add_job_tagsUpGo, map[string]*bintree{}},
+	"1523372418_create_team_vars.down.sql": &bintree{_1523372418_create_team_varsDownSql, map[string]*bintree{}},
+	"1523372418_create_team_vars.up.sql": &bintree{_1523372418_create_team_varsUpSql, map[string]*bintree{}},
+	"1523887200_create_audit_events.up.sql": &bintree{_1523887200_create_audit_eventsUpSql, map[string]*bintree{}},
+	"1523887200_create_audit_events.down.sql": &bintree{_1523887200_create_audit_eventsDownSql, map[string]*bintree{}},
+	"1523973600_create_pipeline_configs.down.sql": &bintree{_1523973600_create_pipeline_configsDownSql, map[string]*bintree{}},
+	"1524060000_add_worker_load_columns.up.sql": &bintree{_1524060000_add_worker_load_columnsUpSql, map[string]*bintree{}},
+	"1524060000_add_worker_load_columns.down.sql": &bintree{_1524060000_add_worker_load_columnsDownSql, map[string]*bintree{}},
+	"1524120000_add_container_quotas.down.sql": &bintree{_1524120000_add_container_quotasDownSql, map[string]*bintree{}},
+	"1524120000_add_container_quotas.up.sql": &bintree{_1524120000_add_container_quotasUpSql, map[string]*bintree{}},
+	"1524180000_add_priority_to_jobs.down.sql": &bintree{_1524180000_add_priority_to_jobsDownSql, map[string]*bintree{}},
+	"1524180000_add_priority_to_jobs.up.sql": &bintree{_1524180000_add_priority_to_jobsUpSql, map[string]*bintree{}},
+	"1524240000_add_pinned_version_to_resources.down.sql": &bintree{_1524240000_add_pinned_version_to_resourcesDownSql, map[string]*bintree{}},
+	"1524240000_add_pinned_version_to_resources.up.sql": &bintree{_1524240000_add_pinned_version_to_resourcesUpSql, map[string]*bintree{}},
+	"1524300000_add_check_order_index_to_versioned_resources.down.sql": &bintree{_1524300000_add_check_order_index_to_versioned_resourcesDownSql, map[string]*bintree{}},
+	"1524300000_add_check_order_index_to_versioned_resources.up.sql": &bintree{_1524300000_add_check_order_index_to_versioned_resourcesUpSql, map[string]*bintree{}},
+	"1524400000_add_events_archived_to_builds.down.sql": &bintree{_1524400000_add_events_archived_to_buildsDownSql, map[string]*bintree{}},
+	"1524400000_add_events_archived_to_builds.up.sql": &bintree{_1524400000_add_events_archived_to_buildsUpSql, map[string]*bintree{}},
+	"1524600000_add_drain_started_at_to_workers.down.sql": &bintree{_1524600000_add_drain_started_at_to_workersDownSql, map[string]*bintree{}},
+	"1524600000_add_drain_started_at_to_workers.up.sql": &bintree{_1524600000_add_drain_started_at_to_workersUpSql, map[string]*bintree{}},
+	"1524700000_add_labels_to_workers.down.sql": &bintree{_1524700000_add_labels_to_workersDownSql, map[string]*bintree{}},
+	"1524700000_add_labels_to_workers.up.sql": &bintree{_1524700000_add_labels_to_workersUpSql, map[string]*bintree{}},
+	"1523973600_create_pipeline_configs.up.go": &bintree{_1523973600_create_pipeline_configsUpGo, map[string]*bintree{}},
+	"1524500000_add_log_search_index_to_build_events.down.go": &bintree{_1524500000_add_log_search_index_to_build_eventsDownGo, map[string]*bintree{}},
+	"1524500000_add_log_search_index_to_build_events.up.go": &bintree{_1524500000_add_log_search_index_to_build_eventsUpGo, map[string]*bintree{}},
+	"1524800000_add_upstream_jobs_to_jobs.up.go": &bintree{_1524800000_add_upstream_jobs_to_jobsUpGo, map[string]*bintree{}},
+	"1524800000_add_upstream_jobs_to_jobs.down.sql": &bintree{_1524800000_add_upstream_jobs_to_jobsDownSql, map[string]*bintree{}},
+	"1524900000_add_events_archive_attempts_to_builds.up.sql": &bintree{_1524900000_add_events_archive_attempts_to_buildsUpSql, map[string]*bintree{}},
+	"1524900000_add_events_archive_attempts_to_builds.down.sql": &bintree{_1524900000_add_events_archive_attempts_to_buildsDownSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,99 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create pipeline configs", func() {
+	const preMigrationVersion = 1523887200
+	const postMigrationVersion = 1523973600
+
+	var (
+		db *sql.DB
+	)
+
+	Context("Up", func() {
+		It("saves the current config of every pipeline as its first version", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			_, err := db.Exec(`
+				INSERT INTO teams(id, name) VALUES
+				(1, 'some-team')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO pipelines(id, team_id, name, version, groups) VALUES
+				(1, 1, 'pipeline1', 3, '[{"name":"group1","jobs":["job1"]}]'),
+				(2, 1, 'pipeline2', 1, NULL)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO jobs(id, pipeline_id, name, config, active) VALUES
+				(1, 1, 'job1', '{"name":"job1"}', true),
+				(2, 1, 'job2', '{"name":"job2"}', false),
+				(3, 2, 'job1', '{"name":"job1"}', true)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO resources(id, pipeline_id, name, config, active) VALUES
+				(1, 1, 'resource1', '{"name":"resource1","type":"git"}', true)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO resource_types(id, pipeline_id, name, type, config, active) VALUES
+				(1, 1, 'type1', 'docker-image', '{"name":"type1","type":"docker-image"}', true)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+
+			rows, err := db.Query(`SELECT pipeline_id, version, config, author FROM pipeline_configs ORDER BY pipeline_id`)
+			Expect(err).NotTo(HaveOccurred())
+
+			type savedConfig struct {
+				pipelineID int
+				version    int
+				config     string
+				author     string
+			}
+
+			configs := []savedConfig{}
+			for rows.Next() {
+				var config savedConfig
+				err := rows.Scan(&config.pipelineID, &config.version, &config.config, &config.author)
+				Expect(err).NotTo(HaveOccurred())
+
+				configs = append(configs, config)
+			}
+
+			_ = db.Close()
+
+			Expect(configs).To(HaveLen(2))
+
+			Expect(configs[0].pipelineID).To(Equal(1))
+			Expect(configs[0].version).To(Equal(3))
+			Expect(configs[0].author).To(BeEmpty())
+			Expect(configs[0].config).To(MatchJSON(`{
+				"groups": [{"name":"group1","jobs":["job1"]}],
+				"resources": [{"name":"resource1","type":"git"}],
+				"resource_types": [{"name":"type1","type":"docker-image"}],
+				"jobs": [{"name":"job1"}]
+			}`))
+
+			Expect(configs[1].pipelineID).To(Equal(2))
+			Expect(configs[1].version).To(Equal(1))
+			Expect(configs[1].config).To(MatchJSON(`{
+				"jobs": [{"name":"job1"}]
+			}`))
+		})
+	})
+})
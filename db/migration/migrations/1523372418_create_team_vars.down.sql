@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE "team_vars";
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+  CREATE TABLE "team_vars" (
+      "id" serial,
+      "team_id" integer NOT NULL,
+      "name" text NOT NULL,
+      "value" text NOT NULL,
+      "nonce" text,
+      PRIMARY KEY ("id"),
+      CONSTRAINT "team_vars_team_id_fkey" FOREIGN KEY ("team_id") REFERENCES "teams"("id") ON DELETE CASCADE,
+      CONSTRAINT "team_vars_team_id_name_key" UNIQUE ("team_id", "name")
+  );
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE "audit_events";
+COMMIT;
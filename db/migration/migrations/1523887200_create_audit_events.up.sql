@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE "audit_events" (
+      "id" bigserial,
+      "time" timestamp with time zone NOT NULL DEFAULT now(),
+      "user_name" text NOT NULL,
+      "team_name" text NOT NULL,
+      "route" text NOT NULL,
+      "target" json NOT NULL,
+      "status" integer NOT NULL,
+      PRIMARY KEY ("id")
+  );
+
+  CREATE INDEX audit_events_time_idx ON audit_events (time);
+COMMIT;
@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type pipelineConfig struct {
+	Groups        json.RawMessage   `json:"groups,omitempty"`
+	Resources     []json.RawMessage `json:"resources,omitempty"`
+	ResourceTypes []json.RawMessage `json:"resource_types,omitempty"`
+	Jobs          []json.RawMessage `json:"jobs,omitempty"`
+}
+
+func (self *migrations) Up_1523973600() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`
+		CREATE TABLE "pipeline_configs" (
+			"id" serial,
+			"pipeline_id" integer NOT NULL,
+			"version" bigint NOT NULL,
+			"config" text NOT NULL,
+			"nonce" text,
+			"author" text NOT NULL,
+			"created_at" timestamp with time zone NOT NULL DEFAULT now(),
+			PRIMARY KEY ("id"),
+			CONSTRAINT "pipeline_configs_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "pipelines"("id") ON DELETE CASCADE,
+			CONSTRAINT "pipeline_configs_pipeline_id_version_key" UNIQUE ("pipeline_id", "version")
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query("SELECT id, version, groups FROM pipelines")
+	if err != nil {
+		return err
+	}
+
+	type pipeline struct {
+		id      int
+		version int
+		config  pipelineConfig
+	}
+
+	pipelines := []pipeline{}
+	for rows.Next() {
+		var p pipeline
+		var groups sql.NullString
+
+		err = rows.Scan(&p.id, &p.version, &groups)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		if groups.Valid && groups.String != "null" {
+			p.config.Groups = json.RawMessage(groups.String)
+		}
+
+		pipelines = append(pipelines, p)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	// the current config of each pipeline is saved as its first version, so
+	// that it can be diffed against the configs saved from now on
+	for _, p := range pipelines {
+		p.config.Resources, err = self.activeConfigs(tx, "resources", p.id)
+		if err != nil {
+			return err
+		}
+
+		p.config.ResourceTypes, err = self.activeConfigs(tx, "resource_types", p.id)
+		if err != nil {
+			return err
+		}
+
+		p.config.Jobs, err = self.activeConfigs(tx, "jobs", p.id)
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(p.config)
+		if err != nil {
+			return err
+		}
+
+		encryptedPayload, nonce, err := self.Encrypt(payload)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO pipeline_configs (pipeline_id, version, config, nonce, author)
+			VALUES ($1, $2, $3, $4, '')
+		`, p.id, p.version, encryptedPayload, nonce)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (self *migrations) activeConfigs(tx *sql.Tx, table string, pipelineID int) ([]json.RawMessage, error) {
+	rows, err := tx.Query(`
+		SELECT config, nonce
+		FROM `+table+`
+		WHERE pipeline_id = $1
+			AND active = true
+		ORDER BY id ASC
+	`, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	configs := []json.RawMessage{}
+	for rows.Next() {
+		var config string
+		var nonce sql.NullString
+
+		err = rows.Scan(&config, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decrypted, err := self.Decrypt(config, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, json.RawMessage(decrypted))
+	}
+
+	return configs, rows.Err()
+}
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE containers DROP COLUMN meta_memory_limit;
+
+  ALTER TABLE workers DROP COLUMN active_volumes;
+COMMIT;
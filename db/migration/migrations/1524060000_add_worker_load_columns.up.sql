@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN active_volumes integer DEFAULT 0;
+
+  ALTER TABLE containers ADD COLUMN meta_memory_limit bigint DEFAULT 0 NOT NULL;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE workers DROP COLUMN max_build_containers;
+
+  ALTER TABLE teams DROP COLUMN max_containers;
+COMMIT;
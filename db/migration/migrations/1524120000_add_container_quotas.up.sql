@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN max_build_containers integer DEFAULT 0 NOT NULL;
+
+  ALTER TABLE teams ADD COLUMN max_containers integer DEFAULT 0 NOT NULL;
+COMMIT;
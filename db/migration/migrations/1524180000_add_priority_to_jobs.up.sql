@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE jobs ADD COLUMN priority integer DEFAULT 0 NOT NULL;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE resources
+    DROP COLUMN pinned_version_id,
+    DROP COLUMN pinned_by,
+    DROP COLUMN pin_comment;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP INDEX versioned_resources_resource_id_check_order;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  CREATE INDEX versioned_resources_resource_id_check_order ON versioned_resources (resource_id, check_order DESC);
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  DROP INDEX builds_completed_unarchived_events;
+
+  ALTER TABLE builds DROP COLUMN events_archived;
+COMMIT;
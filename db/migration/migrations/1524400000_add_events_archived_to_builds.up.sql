@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN events_archived boolean NOT NULL DEFAULT false;
+
+  CREATE INDEX builds_completed_unarchived_events ON builds (id) WHERE completed AND NOT events_archived AND reap_time IS NULL;
+COMMIT;
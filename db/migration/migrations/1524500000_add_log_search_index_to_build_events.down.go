@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/lib/pq"
+
+func (self *migrations) Down_1524500000() error {
+	tables, err := self.buildEventsTables()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		_, err = self.DB.Exec(`DROP INDEX CONCURRENTLY IF EXISTS ` + pq.QuoteIdentifier(table+"_log_search"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
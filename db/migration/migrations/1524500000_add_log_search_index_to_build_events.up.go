@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// Up_1524500000 indexes the log events of every build events table for full
+// text search. The indexes are built concurrently so that builds can keep
+// saving events meanwhile, which can't be done in a transaction.
+func (self *migrations) Up_1524500000() error {
+	tables, err := self.buildEventsTables()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		index := table + "_log_search"
+
+		var valid bool
+		err := self.DB.QueryRow(`
+			SELECT i.indisvalid
+			FROM pg_index i
+			JOIN pg_class c ON c.oid = i.indexrelid
+			WHERE c.relname = $1
+		`, index).Scan(&valid)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if err == nil && valid {
+			continue
+		}
+
+		// a concurrent build which failed leaves an invalid index behind
+		if err == nil {
+			_, err = self.DB.Exec(`DROP INDEX CONCURRENTLY ` + pq.QuoteIdentifier(index))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = self.DB.Exec(`
+			CREATE INDEX CONCURRENTLY ` + pq.QuoteIdentifier(index) + `
+			ON ` + pq.QuoteIdentifier(table) + `
+			USING gin (to_tsvector('simple', (payload::jsonb)->>'payload'))
+			WHERE type = 'log'
+		`)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *migrations) buildEventsTables() ([]string, error) {
+	rows, err := self.DB.Query(`
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'build_events'::regclass
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		err = rows.Scan(&table)
+		if err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, rows.Err()
+}
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN drain_started_at timestamp with time zone;
+
+  UPDATE workers SET drain_started_at = now() WHERE state IN ('landing', 'retiring');
+COMMIT;
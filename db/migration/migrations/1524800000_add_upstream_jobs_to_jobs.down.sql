@@ -0,0 +1,4 @@
+BEGIN;
+  DROP INDEX jobs_upstream_jobs_idx;
+  ALTER TABLE jobs DROP COLUMN upstream_jobs;
+COMMIT;
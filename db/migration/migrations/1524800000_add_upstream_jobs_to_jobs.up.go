@@ -0,0 +1,121 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Up_1524800000 stores the jobs of other pipelines named in the passed
+// constraints of every job, so that finishing a build can look up the
+// pipelines downstream of its job without decrypting their configs.
+func (self *migrations) Up_1524800000() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`ALTER TABLE jobs ADD COLUMN upstream_jobs text[] NOT NULL DEFAULT '{}'`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX jobs_upstream_jobs_idx ON jobs USING gin (upstream_jobs)`)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`SELECT id, config, nonce FROM jobs WHERE active = true`)
+	if err != nil {
+		return err
+	}
+
+	upstreamJobs := map[int][]string{}
+	for rows.Next() {
+		var id int
+		var config string
+		var nonce sql.NullString
+
+		err = rows.Scan(&id, &config, &nonce)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decrypted, err := self.Decrypt(config, noncense)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		var payload interface{}
+		err = json.Unmarshal(decrypted, &payload)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		passed := crossPipelinePassed(payload, map[string]bool{})
+		if len(passed) > 0 {
+			upstreamJobs[id] = passed
+		}
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for id, passed := range upstreamJobs {
+		_, err = tx.Exec(`UPDATE jobs SET upstream_jobs = $1 WHERE id = $2`, pq.Array(passed), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// crossPipelinePassed collects the "pipeline/job" names in the passed
+// constraints found anywhere in the given job config, which covers get
+// steps nested in do, aggregate, try and hooks alike.
+func crossPipelinePassed(config interface{}, seen map[string]bool) []string {
+	names := []string{}
+
+	switch c := config.(type) {
+	case map[string]interface{}:
+		for key, value := range c {
+			if key == "passed" {
+				passed, _ := value.([]interface{})
+				for _, p := range passed {
+					name, ok := p.(string)
+					if ok && strings.Contains(name, "/") && !seen[name] {
+						seen[name] = true
+						names = append(names, name)
+					}
+				}
+
+				continue
+			}
+
+			names = append(names, crossPipelinePassed(value, seen)...)
+		}
+	case []interface{}:
+		for _, value := range c {
+			names = append(names, crossPipelinePassed(value, seen)...)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,7 @@
+BEGIN;
+  DROP INDEX builds_completed_unarchived_events;
+
+  CREATE INDEX builds_completed_unarchived_events ON builds (id) WHERE completed AND NOT events_archived AND reap_time IS NULL;
+
+  ALTER TABLE builds DROP COLUMN events_archive_attempts;
+COMMIT;
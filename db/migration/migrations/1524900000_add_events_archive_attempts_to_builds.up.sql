@@ -0,0 +1,7 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN events_archive_attempts integer NOT NULL DEFAULT 0;
+
+  DROP INDEX builds_completed_unarchived_events;
+
+  CREATE INDEX builds_completed_unarchived_events ON builds (events_archive_attempts, id) WHERE completed AND NOT events_archived AND reap_time IS NULL;
+COMMIT;
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"sync"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/lib/pq"
 )
 
@@ -15,6 +16,7 @@ type NotificationsBus interface {
 }
 
 type notificationsBus struct {
+	logger   lager.Logger
 	listener *pq.Listener
 	conn     *sql.DB
 
@@ -22,8 +24,9 @@ type notificationsBus struct {
 	notificationsL sync.Mutex
 }
 
-func NewNotificationsBus(listener *pq.Listener, conn *sql.DB) NotificationsBus {
+func NewNotificationsBus(logger lager.Logger, listener *pq.Listener, conn *sql.DB) NotificationsBus {
 	bus := &notificationsBus{
+		logger:   logger,
 		listener: listener,
 		conn:     conn,
 
@@ -69,6 +72,10 @@ func (bus *notificationsBus) Listen(channel string) (chan bool, error) {
 
 func (bus *notificationsBus) Notify(channel string) error {
 	_, err := bus.conn.Exec("NOTIFY " + channel)
+	if err != nil {
+		bus.logger.Error("failed-to-notify", err, lager.Data{"channel": channel})
+	}
+
 	return err
 }
 
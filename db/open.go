@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/blobstore"
 	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migration"
@@ -24,6 +25,7 @@ import (
 type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() encryption.Strategy
+	BuildLogStore() blobstore.Store
 
 	Ping() error
 	Driver() driver.Driver
@@ -96,7 +98,7 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 		return &db{
 			DB: sqlDb,
 
-			bus:        NewNotificationsBus(listener, sqlDb),
+			bus:        NewNotificationsBus(logger.Session("notifications-bus"), listener, sqlDb),
 			encryption: strategy,
 			name:       connectionName,
 		}, nil
@@ -116,11 +118,13 @@ func shouldRetry(err error) bool {
 }
 
 var encryptedColumns = map[string]string{
-	"teams":          "auth",
-	"resources":      "config",
-	"jobs":           "config",
-	"resource_types": "config",
-	"builds":         "engine_metadata",
+	"teams":            "auth",
+	"resources":        "config",
+	"jobs":             "config",
+	"resource_types":   "config",
+	"builds":           "engine_metadata",
+	"team_vars":        "value",
+	"pipeline_configs": "config",
 }
 
 func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) error {
@@ -347,6 +351,12 @@ func (db *db) EncryptionStrategy() encryption.Strategy {
 	return db.encryption
 }
 
+// BuildLogStore returns nil; build events are only archived when the
+// connection is wrapped with WithBuildLogStore.
+func (db *db) BuildLogStore() blobstore.Store {
+	return nil
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	multierror "github.com/hashicorp/go-multierror"
 )
 
 type ErrResourceNotFound struct {
@@ -45,6 +46,9 @@ type Pipeline interface {
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
 
+	ConfigVersions() ([]PipelineConfig, error)
+	ConfigAtVersion(ConfigVersion) (PipelineConfig, bool, error)
+
 	Causality(versionedResourceID int) ([]Cause, error)
 
 	SetResourceCheckError(Resource, error) error
@@ -62,10 +66,13 @@ type Pipeline interface {
 	GetBuildsWithVersionAsInput(versionedResourceID int) ([]Build, error)
 	GetBuildsWithVersionAsOutput(versionedResourceID int) ([]Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch, Page) ([]BuildLogSearchResult, Pagination, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
 	AcquireSchedulingLock(lager.Logger, time.Duration) (lock.Lock, bool, error)
+	AcquireUpstreamSchedulingLocks(lager.Logger) (lock.Lock, bool, error)
+	SchedulingNotifier() (Notifier, error)
 
 	AcquireResourceCheckingLockWithIntervalCheck(
 		logger lager.Logger,
@@ -117,8 +124,8 @@ type pipeline struct {
 	paused        bool
 	public        bool
 
-	cachedAt   time.Time
-	versionsDB *algorithm.VersionsDB
+	versionsDB       *algorithm.VersionsDB
+	versionsDBCursor versionsDBCursor
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -341,7 +348,7 @@ func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 			"j.active":      true,
 			"b.pipeline_id": p.id,
 		}).
-		OrderBy("b.id").
+		OrderBy("j.priority DESC", "b.id").
 		RunWith(p.conn).
 		Query()
 	if err != nil {
@@ -371,6 +378,7 @@ func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []at
 
 	defer Rollback(tx)
 
+	var changed bool
 	for _, version := range versions {
 		vr := VersionedResource{
 			Resource: config.Name,
@@ -394,18 +402,29 @@ func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []at
 			return err
 		}
 
-		_, _, err = p.saveVersionedResource(tx, resourceID, vr)
+		_, created, err := p.saveVersionedResource(tx, resourceID, vr)
 		if err != nil {
 			return err
 		}
 
-		err = p.incrementCheckOrderWhenNewerVersion(tx, resourceID, vr.Type, string(versionJSON))
+		incremented, err := p.incrementCheckOrderWhenNewerVersion(tx, resourceID, vr.Type, string(versionJSON))
 		if err != nil {
 			return err
 		}
+
+		changed = changed || created || incremented
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		notifyScheduler(p.conn, p.id)
+	}
+
+	return nil
 }
 
 func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error) {
@@ -772,6 +791,10 @@ func (p *pipeline) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id}), page, p.conn, p.lockFactory)
 }
 
+func (p *pipeline) SearchBuildLogs(search BuildLogSearch, page Page) ([]BuildLogSearchResult, Pagination, error) {
+	return searchBuildLogs(p.conn, p.lockFactory, sq.Eq{"b.pipeline_id": p.id}, search, page)
+}
+
 func (p *pipeline) Resources() (Resources, error) {
 	rows, err := resourcesQuery.Where(sq.Eq{"r.pipeline_id": p.id}).RunWith(p.conn).Query()
 	if err != nil {
@@ -955,8 +978,13 @@ func (p *pipeline) Unpause() error {
 		}).
 		RunWith(p.conn).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	notifyScheduler(p.conn, p.id)
+
+	return nil
 }
 
 func (p *pipeline) Hide() error {
@@ -1003,6 +1031,11 @@ func (p *pipeline) Destroy() error {
 
 	defer Rollback(tx)
 
+	archivedBuildIDs, err := findArchivedBuildIDs(tx, sq.Eq{"pipeline_id": p.id})
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP TABLE pipeline_build_events_%d
 	`, p.id))
@@ -1017,170 +1050,12 @@ func (p *pipeline) Destroy() error {
 		return err
 	}
 
-	return tx.Commit()
-}
-
-func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := p.getLatestModifiedTime()
-	if err != nil {
-		return nil, err
-	}
-
-	if p.versionsDB != nil && p.cachedAt.Equal(latestModifiedTime) {
-		return p.versionsDB, nil
-	}
-
-	db := &algorithm.VersionsDB{
-		BuildOutputs:     []algorithm.BuildOutput{},
-		BuildInputs:      []algorithm.BuildInput{},
-		ResourceVersions: []algorithm.ResourceVersion{},
-		JobIDs:           map[string]int{},
-		ResourceIDs:      map[string]int{},
-	}
-
-	rows, err := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
-		From("build_outputs o, builds b, versioned_resources v, resources r").
-		Where(sq.Expr("v.id = o.versioned_resource_id")).
-		Where(sq.Expr("b.id = o.build_id")).
-		Where(sq.Expr("r.id = v.resource_id")).
-		Where(sq.Eq{
-			"v.enabled":     true,
-			"b.status":      BuildStatusSucceeded,
-			"r.pipeline_id": p.id,
-		}).
-		RunWith(p.conn).
-		Query()
-	if err != nil {
-		return nil, err
-	}
-
-	defer Close(rows)
-
-	for rows.Next() {
-		var output algorithm.BuildOutput
-		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
-		if err != nil {
-			return nil, err
-		}
-
-		output.ResourceVersion.CheckOrder = output.CheckOrder
-
-		db.BuildOutputs = append(db.BuildOutputs, output)
-	}
-
-	rows, err = psql.Select("v.id, v.check_order, r.id, i.build_id, i.name, b.job_id, b.status = 'succeeded'").
-		From("build_inputs i, builds b, versioned_resources v, resources r").
-		Where(sq.Expr("v.id = i.versioned_resource_id")).
-		Where(sq.Expr("b.id = i.build_id")).
-		Where(sq.Expr("r.id = v.resource_id")).
-		Where(sq.Eq{
-			"v.enabled":     true,
-			"r.pipeline_id": p.id,
-		}).
-		RunWith(p.conn).
-		Query()
-	if err != nil {
-		return nil, err
-	}
-
-	defer Close(rows)
-
-	for rows.Next() {
-		var succeeded bool
-
-		var input algorithm.BuildInput
-		err = rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID, &succeeded)
-		if err != nil {
-			return nil, err
-		}
-
-		input.ResourceVersion.CheckOrder = input.CheckOrder
-
-		db.BuildInputs = append(db.BuildInputs, input)
-
-		if succeeded {
-			// implicit output
-			db.BuildOutputs = append(db.BuildOutputs, algorithm.BuildOutput{
-				ResourceVersion: input.ResourceVersion,
-				JobID:           input.JobID,
-				BuildID:         input.BuildID,
-			})
-		}
-	}
-
-	rows, err = psql.Select("v.id, v.check_order, r.id").
-		From("versioned_resources v, resources r").
-		Where(sq.Expr("r.id = v.resource_id")).
-		Where(sq.Eq{
-			"v.enabled":     true,
-			"r.pipeline_id": p.id,
-		}).
-		RunWith(p.conn).
-		Query()
-	if err != nil {
-		return nil, err
-	}
-
-	defer Close(rows)
-
-	for rows.Next() {
-		var output algorithm.ResourceVersion
-		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID)
-		if err != nil {
-			return nil, err
-		}
-
-		db.ResourceVersions = append(db.ResourceVersions, output)
-	}
-
-	rows, err = psql.Select("j.name, j.id").
-		From("jobs j").
-		Where(sq.Eq{"j.pipeline_id": p.id}).
-		RunWith(p.conn).
-		Query()
-	if err != nil {
-		return nil, err
-	}
-
-	defer Close(rows)
-
-	for rows.Next() {
-		var name string
-		var id int
-		err = rows.Scan(&name, &id)
-		if err != nil {
-			return nil, err
-		}
-
-		db.JobIDs[name] = id
-	}
-
-	rows, err = psql.Select("r.name, r.id").
-		From("resources r").
-		Where(sq.Eq{"r.pipeline_id": p.id}).
-		RunWith(p.conn).
-		Query()
+	err = tx.Commit()
 	if err != nil {
-		return nil, err
-	}
-
-	defer Close(rows)
-
-	for rows.Next() {
-		var name string
-		var id int
-		err = rows.Scan(&name, &id)
-		if err != nil {
-			return nil, err
-		}
-
-		db.ResourceIDs[name] = id
+		return err
 	}
 
-	p.versionsDB = db
-	p.cachedAt = latestModifiedTime
-
-	return db, nil
+	return deleteBuildEventsArchives(p.conn, archivedBuildIDs)
 }
 
 func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
@@ -1213,9 +1088,14 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		return err
 	}
 
+	archivedBuildIDs, err := findArchivedBuildIDs(tx, sq.Eq{"id": buildIDs})
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(`
 		UPDATE builds
-		SET reap_time = now()
+		SET reap_time = now(), events_archived = false
 		WHERE id IN (`+strings.Join(indexStrings, ",")+`)
 	`, interfaceBuildIDs...)
 	if err != nil {
@@ -1223,7 +1103,11 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	}
 
 	err = tx.Commit()
-	return err
+	if err != nil {
+		return err
+	}
+
+	return deleteBuildEventsArchives(p.conn, archivedBuildIDs)
 }
 
 func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
@@ -1275,6 +1159,97 @@ func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Dura
 	return lock, true, nil
 }
 
+// AcquireUpstreamSchedulingLocks acquires the scheduling locks of the other
+// pipelines with jobs named in this pipeline's passed constraints, so that
+// their versions are not loaded while they are being scheduled. It returns
+// false if any of them is held.
+func (p *pipeline) AcquireUpstreamSchedulingLocks(logger lager.Logger) (lock.Lock, bool, error) {
+	upstreamJobIDs, err := p.upstreamJobIDs()
+	if err != nil {
+		return nil, false, err
+	}
+
+	locks := upstreamLocks{}
+
+	if len(upstreamJobIDs) == 0 {
+		return locks, true, nil
+	}
+
+	jobIDs := []int{}
+	for _, jobID := range upstreamJobIDs {
+		jobIDs = append(jobIDs, jobID)
+	}
+
+	rows, err := psql.Select("DISTINCT j.pipeline_id").
+		From("jobs j").
+		Where(sq.Eq{"j.id": jobIDs}).
+		OrderBy("j.pipeline_id").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	pipelineIDs := []int{}
+	for rows.Next() {
+		var pipelineID int
+		err = rows.Scan(&pipelineID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		upstreamLock, acquired, err := p.lockFactory.Acquire(
+			logger.Session("lock", lager.Data{
+				"pipeline":          p.name,
+				"upstream-pipeline": pipelineID,
+			}),
+			lock.NewPipelineSchedulingLockLockID(pipelineID),
+		)
+		if err != nil || !acquired {
+			releaseErr := locks.Release()
+			if releaseErr != nil {
+				logger.Error("failed-to-release-lock", releaseErr)
+			}
+
+			return nil, false, err
+		}
+
+		locks = append(locks, upstreamLock)
+	}
+
+	return locks, true, nil
+}
+
+type upstreamLocks []lock.Lock
+
+func (locks upstreamLocks) Release() error {
+	var errs error
+	for _, l := range locks {
+		err := l.Release()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// SchedulingNotifier returns a Notifier which fires whenever something which
+// may affect scheduling changes in the pipeline: new resource versions are
+// saved, versions are enabled, disabled or pinned, builds finish, jobs or the
+// pipeline are unpaused, or the pipeline's config is saved.
+func (p *pipeline) SchedulingNotifier() (Notifier, error) {
+	return newConditionNotifier(p.conn.Bus(), pipelineSchedulingChannel(p.id), func() (bool, error) {
+		return false, nil
+	})
+}
+
 func (p *pipeline) saveOutput(buildID int, vr VersionedResource) error {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -1309,7 +1284,7 @@ func (p *pipeline) saveOutput(buildID int, vr VersionedResource) error {
 			return err
 		}
 
-		err = p.incrementCheckOrderWhenNewerVersion(tx, resourceID, vr.Type, string(versionJSON))
+		_, err = p.incrementCheckOrderWhenNewerVersion(tx, resourceID, vr.Type, string(versionJSON))
 		if err != nil {
 			return err
 		}
@@ -1475,8 +1450,8 @@ func (p *pipeline) saveVersionedResource(tx Tx, resourceID int, vr VersionedReso
 	}, created, nil
 }
 
-func (p *pipeline) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, resourceType string, version string) error {
-	_, err := tx.Exec(`
+func (p *pipeline) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, resourceType string, version string) (bool, error) {
+	result, err := tx.Exec(`
 		WITH max_checkorder AS (
 			SELECT max(check_order) co
 			FROM versioned_resources
@@ -1485,13 +1460,22 @@ func (p *pipeline) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, re
 		)
 
 		UPDATE versioned_resources
-		SET check_order = mc.co + 1
+		SET check_order = mc.co + 1, modified_time = now()
 		FROM max_checkorder mc
 		WHERE resource_id = $1
 		AND type = $2
 		AND version = $3
-		AND check_order <= mc.co;`, resourceID, resourceType, version)
-	return err
+		AND (check_order < mc.co OR check_order = 0);`, resourceID, resourceType, version)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected != 0, nil
 }
 
 func (p *pipeline) toggleVersionedResource(versionedResourceID int, enable bool) error {
@@ -1514,41 +1498,73 @@ func (p *pipeline) toggleVersionedResource(versionedResourceID int, enable bool)
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
+	notifyScheduler(p.conn, p.id)
+
 	return nil
 }
 
-func (p *pipeline) getLatestModifiedTime() (time.Time, error) {
-	var maxModifiedTime time.Time
-
-	err := p.conn.QueryRow(`
-	SELECT
-		CASE
-			WHEN b_max > vr_max AND b_max > bi_max THEN b_max
-			WHEN bi_max > vr_max THEN bi_max
-			ELSE vr_max
-		END
-	FROM
-		(
-			SELECT COALESCE(MAX(b.end_time), 'epoch') as b_max
-			FROM builds b
-			WHERE b.pipeline_id = $1
-		) bo,
-		(
-			SELECT COALESCE(MAX(bi.modified_time), 'epoch') as bi_max
-			FROM build_inputs bi
-			LEFT OUTER JOIN versioned_resources v ON v.id = bi.versioned_resource_id
-			LEFT OUTER JOIN resources r ON r.id = v.resource_id
-			WHERE r.pipeline_id = $1
-		) bi,
-		(
-			SELECT COALESCE(MAX(vr.modified_time), 'epoch') as vr_max
-			FROM versioned_resources vr
-			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
-			WHERE r.pipeline_id = $1
-		) vr
-	`, p.id).Scan(&maxModifiedTime)
-
-	return maxModifiedTime, err
+// upstreamJobIDs returns the IDs of the jobs in other pipelines of the team
+// which are named in the passed constraints of this pipeline's jobs, keyed by
+// the name they are referred to by.
+func (p *pipeline) upstreamJobIDs() (map[string]int, error) {
+	jobs, err := p.Jobs()
+	if err != nil {
+		return nil, err
+	}
+
+	localJobs := map[string]bool{}
+	for _, job := range jobs {
+		localJobs[job.Name()] = true
+	}
+
+	passedJobs := sq.Or{}
+	for _, job := range jobs {
+		for _, input := range job.Config().Inputs() {
+			for _, passed := range input.Passed {
+				passedJob := atc.ParsePassedJob(passed)
+				if localJobs[passed] || !passedJob.IsCrossPipeline() {
+					continue
+				}
+
+				passedJobs = append(passedJobs, sq.Eq{
+					"p.name": passedJob.PipelineName,
+					"j.name": passedJob.JobName,
+				})
+			}
+		}
+	}
+
+	jobIDs := map[string]int{}
+	if len(passedJobs) == 0 {
+		return jobIDs, nil
+	}
+
+	rows, err := psql.Select("p.name, j.name, j.id").
+		From("jobs j, pipelines p").
+		Where(sq.Expr("j.pipeline_id = p.id")).
+		Where(sq.Eq{"p.team_id": p.teamID}).
+		Where(sq.NotEq{"p.id": p.id}).
+		Where(passedJobs).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var pipelineName, jobName string
+		var id int
+		err = rows.Scan(&pipelineName, &jobName, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		jobIDs[pipelineName+"/"+jobName] = id
+	}
+
+	return jobIDs, nil
 }
 
 func (p *pipeline) getBuildsFrom(view string) (map[string]Build, error) {
@@ -1587,3 +1603,15 @@ func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int,
 	`, jobName, pipelineID).Scan(&buildName, &jobID)
 	return buildName, jobID, err
 }
+
+func pipelineSchedulingChannel(pipelineID int) string {
+	return fmt.Sprintf("pipeline_scheduling_%d", pipelineID)
+}
+
+// notifyScheduler wakes up the scheduler of the pipeline once a change to it
+// has been committed. A failed notification is logged by the bus and not
+// returned, as the change has already been made and the scheduler picks it
+// up on its next interval anyway.
+func notifyScheduler(conn Conn, pipelineID int) {
+	_ = conn.Bus().Notify(pipelineSchedulingChannel(pipelineID))
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+// PipelineConfig is a pipeline's config as of a given version, along with who
+// saved it and when.
+type PipelineConfig struct {
+	Version   ConfigVersion
+	Config    atc.Config
+	Author    string
+	CreatedAt time.Time
+}
+
+var pipelineConfigsQuery = psql.Select("version", "config", "nonce", "author", "created_at").
+	From("pipeline_configs")
+
+func (p *pipeline) ConfigVersions() ([]PipelineConfig, error) {
+	rows, err := pipelineConfigsQuery.
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	configs := []PipelineConfig{}
+
+	for rows.Next() {
+		config, err := scanPipelineConfig(p.conn, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+func (p *pipeline) ConfigAtVersion(version ConfigVersion) (PipelineConfig, bool, error) {
+	config, err := scanPipelineConfig(
+		p.conn,
+		pipelineConfigsQuery.
+			Where(sq.Eq{
+				"pipeline_id": p.id,
+				"version":     version,
+			}).
+			RunWith(p.conn).
+			QueryRow(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfig{}, false, nil
+		}
+
+		return PipelineConfig{}, false, err
+	}
+
+	return config, true, nil
+}
+
+func savePipelineConfig(tx Tx, conn Conn, pipelineID int, version ConfigVersion, config atc.Config, author string) error {
+	configPayload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := conn.EncryptionStrategy().Encrypt(configPayload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("pipeline_configs").
+		Columns("pipeline_id", "version", "config", "nonce", "author").
+		Values(pipelineID, version, encryptedPayload, nonce, author).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func scanPipelineConfig(conn Conn, row scannable) (PipelineConfig, error) {
+	var (
+		pipelineConfig PipelineConfig
+		configBlob     []byte
+		nonce          sql.NullString
+	)
+
+	err := row.Scan(&pipelineConfig.Version, &configBlob, &nonce, &pipelineConfig.Author, &pipelineConfig.CreatedAt)
+	if err != nil {
+		return PipelineConfig{}, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := conn.EncryptionStrategy().Decrypt(string(configBlob), noncense)
+	if err != nil {
+		return PipelineConfig{}, err
+	}
+
+	err = json.Unmarshal(decryptedConfig, &pipelineConfig.Config)
+	if err != nil {
+		return PipelineConfig{}, err
+	}
+
+	return pipelineConfig, nil
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -71,8 +74,9 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 				{
-					Name:   "some-other-job",
-					Serial: true,
+					Name:     "some-other-job",
+					Serial:   true,
+					Priority: 5,
 				},
 				{
 					Name: "a-job",
@@ -107,7 +111,7 @@ var _ = Describe("Pipeline", func() {
 			},
 		}
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+		pipeline, created, err = team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused, "")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -186,6 +190,66 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Config versions", func() {
+		var firstVersion db.ConfigVersion
+
+		BeforeEach(func() {
+			firstVersion = pipeline.ConfigVersion()
+
+			updatedConfig := pipelineConfig
+			updatedConfig.Jobs = atc.JobConfigs{{Name: "some-other-job"}}
+
+			var err error
+			pipeline, _, err = team.SavePipeline("fake-pipeline", updatedConfig, firstVersion, db.PipelineNoChange, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps every saved config, newest first", func() {
+			configs, err := pipeline.ConfigVersions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configs).To(HaveLen(2))
+
+			Expect(configs[0].Version).To(Equal(pipeline.ConfigVersion()))
+			Expect(configs[0].Author).To(Equal("some-user"))
+			Expect(configs[0].CreatedAt).ToNot(BeZero())
+			Expect(configs[0].Config.Jobs).To(HaveLen(1))
+			Expect(configs[0].Config.Jobs[0].Name).To(Equal("some-other-job"))
+
+			Expect(configs[1].Version).To(Equal(firstVersion))
+			Expect(configs[1].Author).To(BeEmpty())
+			Expect(configs[1].Config.Jobs[0].Name).To(Equal("job-name"))
+		})
+
+		It("can look up the config at a given version", func() {
+			config, found, err := pipeline.ConfigAtVersion(firstVersion)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(config.Version).To(Equal(firstVersion))
+			Expect(config.Config.Groups).To(Equal(pipelineConfig.Groups))
+			Expect(config.Config.Resources).To(HaveLen(2))
+		})
+
+		It("does not find versions of other pipelines", func() {
+			otherPipeline, _, err := team.SavePipeline("other-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := pipeline.ConfigAtVersion(otherPipeline.ConfigVersion())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the save fails the version comparison", func() {
+			It("does not record the config", func() {
+				_, _, err := team.SavePipeline("fake-pipeline", pipelineConfig, firstVersion, db.PipelineNoChange, "some-user")
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+				configs, err := pipeline.ConfigVersions()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configs).To(HaveLen(2))
+			})
+		})
+	})
+
 	Describe("Rename", func() {
 		JustBeforeEach(func() {
 			Expect(pipeline.Rename("oopsies")).To(Succeed())
@@ -221,7 +285,7 @@ var _ = Describe("Pipeline", func() {
 						},
 					},
 				}
-				pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+				pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).ToNot(HaveOccurred())
 
 				resource, _, err := pipeline.Resource("some-resource")
@@ -557,7 +621,7 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, _, err = pipeline.Resource("some-resource")
@@ -575,6 +639,21 @@ var _ = Describe("Pipeline", func() {
 			}
 		})
 
+		It("does not change the check_order of the latest version when it is saved again", func() {
+			err := pipeline.SaveResourceVersions(resourceConfig, originalVersionSlice)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pipeline.SaveResourceVersions(resourceConfig, []atc.Version{{"ref": "v3"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			latestVR, found, err := pipeline.GetLatestVersionedResource(resource.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(latestVR.Version).To(Equal(db.ResourceVersion{"ref": "v3"}))
+			Expect(latestVR.CheckOrder).To(Equal(2))
+		})
+
 		It("ensures versioned resources have the correct check_order", func() {
 			err := pipeline.SaveResourceVersions(resourceConfig, originalVersionSlice)
 			Expect(err).ToNot(HaveOccurred())
@@ -647,7 +726,7 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			err = pipeline.SaveResourceVersions(
@@ -909,10 +988,10 @@ var _ = Describe("Pipeline", func() {
 			}
 
 			var err error
-			dbPipeline, _, err = team.SavePipeline("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+			dbPipeline, _, err = team.SavePipeline("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
-			otherDBPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherDBPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, _, err = dbPipeline.Resource(resourceName)
@@ -1593,7 +1672,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			pipelineDB, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipelineDB, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -1816,6 +1895,255 @@ var _ = Describe("Pipeline", func() {
 				Expect(pendingBuilds).To(HaveLen(1))
 				Expect(pendingBuilds["job-name"]).ToNot(BeNil())
 			})
+
+			It("has the priority of its job", func() {
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds[0].Priority()).To(Equal(0))
+			})
+		})
+
+		Context("when builds are created for jobs with a priority", func() {
+			BeforeEach(func() {
+				_, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				otherJob, found, err := pipeline.Job("some-other-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = otherJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the builds with the priority of their job", func() {
+				pendingBuilds, err := pipeline.GetAllPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(2))
+				Expect(pendingBuilds["job-name"][0].Priority()).To(Equal(0))
+				Expect(pendingBuilds["some-other-job"][0].Priority()).To(Equal(5))
+			})
+		})
+	})
+
+	Describe("SchedulingNotifier", func() {
+		var notifier db.Notifier
+
+		BeforeEach(func() {
+			var err error
+			notifier, err = pipeline.SchedulingNotifier()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(notifier.Close()).To(Succeed())
+		})
+
+		It("does not notify when nothing has changed", func() {
+			Consistently(notifier.Notify()).ShouldNot(Receive())
+		})
+
+		Context("when a new resource version is saved", func() {
+			BeforeEach(func() {
+				err := pipeline.SaveResourceVersions(atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("notifies", func() {
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			Context("when the same version is saved again", func() {
+				BeforeEach(func() {
+					Eventually(notifier.Notify()).Should(Receive())
+
+					err := pipeline.SaveResourceVersions(atc.ResourceConfig{
+						Name: "some-resource",
+						Type: "some-type",
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("does not notify", func() {
+					Consistently(notifier.Notify()).ShouldNot(Receive())
+				})
+			})
+		})
+
+		Context("when a build of a job finishes", func() {
+			BeforeEach(func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("notifies", func() {
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+
+		Context("when a build of a job that another pipeline has a passed constraint on finishes", func() {
+			var otherNotifier db.Notifier
+
+			BeforeEach(func() {
+				otherPipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-type",
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "other-job",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "some-resource",
+									Passed: []string{"fake-pipeline/job-name"},
+								},
+							},
+						},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+				Expect(err).ToNot(HaveOccurred())
+
+				otherNotifier, err = otherPipeline.SchedulingNotifier()
+				Expect(err).ToNot(HaveOccurred())
+
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(otherNotifier.Close()).To(Succeed())
+			})
+
+			It("notifies the other pipeline", func() {
+				Eventually(otherNotifier.Notify()).Should(Receive())
+			})
+		})
+
+		Context("when a build finishes of a job other than the one another pipeline has a passed constraint on", func() {
+			var otherNotifier db.Notifier
+
+			BeforeEach(func() {
+				otherPipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-type",
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "other-job",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "some-resource",
+									Passed: []string{"fake-pipeline/some-other-job"},
+								},
+							},
+						},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+				Expect(err).ToNot(HaveOccurred())
+
+				otherNotifier, err = otherPipeline.SchedulingNotifier()
+				Expect(err).ToNot(HaveOccurred())
+
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(otherNotifier.Close()).To(Succeed())
+			})
+
+			It("does not notify the other pipeline", func() {
+				Consistently(otherNotifier.Notify()).ShouldNot(Receive())
+			})
+		})
+
+		Context("when a build of a job in another pipeline without passed constraints on it finishes", func() {
+			BeforeEach(func() {
+				otherPipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "other-job",
+						},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+				Expect(err).ToNot(HaveOccurred())
+
+				otherJob, found, err := otherPipeline.Job("other-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := otherJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not notify", func() {
+				Consistently(notifier.Notify()).ShouldNot(Receive())
+			})
+		})
+
+		Context("when a job is unpaused", func() {
+			BeforeEach(func() {
+				err := job.Unpause()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("notifies", func() {
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+
+		Context("when the pipeline is unpaused", func() {
+			BeforeEach(func() {
+				err := pipeline.Unpause()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("notifies", func() {
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+
+		Context("when the pipeline is saved", func() {
+			BeforeEach(func() {
+				_, _, err := team.SavePipeline("fake-pipeline", pipelineConfig, pipeline.ConfigVersion(), db.PipelineNoChange, "")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("notifies", func() {
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+
+		Context("when another pipeline is saved", func() {
+			BeforeEach(func() {
+				_, _, err := team.SavePipeline("other-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused, "")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not notify", func() {
+				Consistently(notifier.Notify()).ShouldNot(Receive())
+			})
 		})
 	})
 
@@ -1839,7 +2167,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1996,6 +2324,484 @@ var _ = Describe("Pipeline", func() {
 				})
 			})
 		})
+		Context("when rows are saved after the VersionsDB is loaded", func() {
+			var (
+				job            db.Job
+				resourceConfig atc.ResourceConfig
+				savedVR        db.SavedVersionedResource
+			)
+
+			BeforeEach(func() {
+				var found bool
+				var err error
+				job, found, err = pipeline.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				resourceConfig = atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}
+
+				err = pipeline.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				savedVR, found, err = pipeline.GetLatestVersionedResource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			loadFromScratch := func() *algorithm.VersionsDB {
+				reloadedPipeline, found, err := team.Pipeline(pipeline.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versionsDB, err := reloadedPipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				return versionsDB
+			}
+
+			expectFullyLoaded := func(versionsDB *algorithm.VersionsDB) {
+				fullyLoaded := loadFromScratch()
+
+				Expect(versionsDB.ResourceVersions).To(ConsistOf(fullyLoaded.ResourceVersions))
+				Expect(versionsDB.BuildInputs).To(ConsistOf(fullyLoaded.BuildInputs))
+				Expect(versionsDB.BuildOutputs).To(ConsistOf(fullyLoaded.BuildOutputs))
+				Expect(versionsDB.JobIDs).To(Equal(fullyLoaded.JobIDs))
+				Expect(versionsDB.ResourceIDs).To(Equal(fullyLoaded.ResourceIDs))
+			}
+
+			It("loads the versions and builds saved since", func() {
+				_, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pipeline.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "2"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveInput(db.BuildInput{
+					Name:              "some-input",
+					VersionedResource: savedVR.VersionedResource,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.ResourceVersions).To(HaveLen(2))
+				Expect(versionsDB.BuildInputs).To(HaveLen(1))
+				Expect(versionsDB.BuildOutputs).To(HaveLen(1))
+
+				expectFullyLoaded(versionsDB)
+			})
+
+			It("reloads the inputs and outputs of builds which were still running", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveInput(db.BuildInput{
+					Name:              "some-input",
+					VersionedResource: savedVR.VersionedResource,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.BuildInputs).To(HaveLen(1))
+				Expect(versionsDB.BuildOutputs).To(BeEmpty())
+
+				err = build.SaveOutput(savedVR.VersionedResource)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				versionsDB, err = pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.BuildInputs).To(HaveLen(1))
+				Expect(versionsDB.BuildOutputs).To(HaveLen(2))
+
+				expectFullyLoaded(versionsDB)
+			})
+
+			It("does not modify a VersionsDB it has already returned", func() {
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+
+				err = pipeline.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "2"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+			})
+
+			It("reloads the VersionsDB once a loaded version is disabled", func() {
+				_, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pipeline.DisableVersionedResource(savedVR.ID)
+				Expect(err).ToNot(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.ResourceVersions).To(BeEmpty())
+
+				expectFullyLoaded(versionsDB)
+			})
+
+			It("reloads the VersionsDB once an older version is checked again", func() {
+				err := pipeline.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "2"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pipeline.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				latest, found := versionsDB.LatestVersionOfResource(versionsDB.ResourceIDs["some-resource"])
+				Expect(found).To(BeTrue())
+				Expect(latest.VersionID).To(Equal(savedVR.ID))
+
+				expectFullyLoaded(versionsDB)
+			})
+		})
+	})
+
+	Describe("LoadVersionsDB with passed constraints on jobs in other pipelines", func() {
+		var (
+			upstreamPipeline   db.Pipeline
+			downstreamPipeline db.Pipeline
+			upstreamJob        db.Job
+			upstreamBuild      db.Build
+			upstreamResource   db.Resource
+			downstreamResource db.Resource
+		)
+
+		BeforeEach(func() {
+			var err error
+			upstreamPipeline, _, err = team.SavePipeline("upstream-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "upstream-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "upstream-job",
+						Plan: atc.PlanSequence{{Get: "upstream-resource"}},
+					},
+				},
+			}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			downstreamPipeline, _, err = team.SavePipeline("downstream-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "downstream-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "downstream-job",
+						Plan: atc.PlanSequence{
+							{
+								Get:    "downstream-resource",
+								Passed: []string{"upstream-pipeline/upstream-job"},
+							},
+						},
+					},
+				},
+			}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			upstreamJob, found, err = upstreamPipeline.Job("upstream-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			upstreamResource, found, err = upstreamPipeline.Resource("upstream-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			downstreamResource, found, err = downstreamPipeline.Resource("downstream-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = upstreamPipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name:   "upstream-resource",
+				Type:   "some-base-resource-type",
+				Source: atc.Source{"some": "source"},
+			}, []atc.Version{{"version": "1"}, {"version": "2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = downstreamPipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name:   "downstream-resource",
+				Type:   "some-base-resource-type",
+				Source: atc.Source{"some": "source"},
+			}, []atc.Version{{"version": "1"}, {"version": "2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			upstreamBuild, err = upstreamJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			upstreamVR, found, err := upstreamPipeline.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "upstream-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = upstreamBuild.SaveOutput(upstreamVR.VersionedResource)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = upstreamBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("includes the upstream job in the job IDs", func() {
+			versionsDB, err := downstreamPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versionsDB.JobIDs).To(HaveKeyWithValue("upstream-pipeline/upstream-job", upstreamJob.ID()))
+		})
+
+		Context("when the resources have the same config", func() {
+			BeforeEach(func() {
+				resourceConfigCheckSession, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
+					logger,
+					"some-base-resource-type",
+					atc.Source{"some": "source"},
+					creds.NewVersionedResourceTypes(template.StaticVariables{}, atc.VersionedResourceTypes{}),
+					db.ContainerOwnerExpiries{},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(upstreamResource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+				Expect(downstreamResource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+			})
+
+			It("includes the versions which passed the upstream job as outputs of it", func() {
+				downstreamVR, found, err := downstreamPipeline.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "downstream-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.BuildOutputs).To(ConsistOf(algorithm.BuildOutput{
+					ResourceVersion: algorithm.ResourceVersion{
+						VersionID:  downstreamVR.ID,
+						ResourceID: downstreamResource.ID(),
+						CheckOrder: downstreamVR.CheckOrder,
+					},
+					BuildID: upstreamBuild.ID(),
+					JobID:   upstreamJob.ID(),
+				}))
+			})
+
+			It("does not cache the VersionsDB once another upstream build has completed", func() {
+				versionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				otherUpstreamBuild, err := upstreamJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = otherUpstreamBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				cachedVersionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB != cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
+			})
+
+			Context("when the version which passed the upstream job is disabled", func() {
+				var upstreamVR db.SavedVersionedResource
+
+				BeforeEach(func() {
+					_, err := downstreamPipeline.LoadVersionsDB()
+					Expect(err).ToNot(HaveOccurred())
+
+					var found bool
+					upstreamVR, found, err = upstreamPipeline.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "upstream-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = upstreamPipeline.DisableVersionedResource(upstreamVR.ID)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("no longer includes it", func() {
+					versionsDB, err := downstreamPipeline.LoadVersionsDB()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versionsDB.BuildOutputs).To(BeEmpty())
+				})
+
+				Context("when it is enabled again", func() {
+					BeforeEach(func() {
+						_, err := downstreamPipeline.LoadVersionsDB()
+						Expect(err).ToNot(HaveOccurred())
+
+						err = upstreamPipeline.EnableVersionedResource(upstreamVR.ID)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("includes it again", func() {
+						versionsDB, err := downstreamPipeline.LoadVersionsDB()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(versionsDB.BuildOutputs).To(HaveLen(1))
+					})
+				})
+			})
+
+			Context("when a version which passed the upstream job is saved after the VersionsDB was loaded", func() {
+				var otherUpstreamBuild db.Build
+
+				BeforeEach(func() {
+					err := upstreamPipeline.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "upstream-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "3"}})
+					Expect(err).ToNot(HaveOccurred())
+
+					upstreamVR, found, err := upstreamPipeline.GetVersionedResourceByVersion(atc.Version{"version": "3"}, "upstream-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					otherUpstreamBuild, err = upstreamJob.CreateBuild()
+					Expect(err).ToNot(HaveOccurred())
+
+					err = otherUpstreamBuild.SaveOutput(upstreamVR.VersionedResource)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = otherUpstreamBuild.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = downstreamPipeline.LoadVersionsDB()
+					Expect(err).ToNot(HaveOccurred())
+
+					err = downstreamPipeline.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "downstream-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "3"}})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("includes it as an output of the upstream job", func() {
+					downstreamVR, found, err := downstreamPipeline.GetVersionedResourceByVersion(atc.Version{"version": "3"}, "downstream-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					versionsDB, err := downstreamPipeline.LoadVersionsDB()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versionsDB.BuildOutputs).To(ContainElement(algorithm.BuildOutput{
+						ResourceVersion: algorithm.ResourceVersion{
+							VersionID:  downstreamVR.ID,
+							ResourceID: downstreamResource.ID(),
+							CheckOrder: downstreamVR.CheckOrder,
+						},
+						BuildID: otherUpstreamBuild.ID(),
+						JobID:   upstreamJob.ID(),
+					}))
+				})
+			})
+		})
+
+		Context("when the resources have not been checked yet", func() {
+			It("does not include the versions which passed the upstream job", func() {
+				versionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.BuildOutputs).To(BeEmpty())
+			})
+
+			Context("when they are checked after the VersionsDB was loaded", func() {
+				BeforeEach(func() {
+					_, err := downstreamPipeline.LoadVersionsDB()
+					Expect(err).ToNot(HaveOccurred())
+
+					resourceConfigCheckSession, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
+						logger,
+						"some-base-resource-type",
+						atc.Source{"some": "source"},
+						creds.NewVersionedResourceTypes(template.StaticVariables{}, atc.VersionedResourceTypes{}),
+						db.ContainerOwnerExpiries{},
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(upstreamResource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+					Expect(downstreamResource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+				})
+
+				It("includes the versions which passed the upstream job", func() {
+					versionsDB, err := downstreamPipeline.LoadVersionsDB()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versionsDB.BuildOutputs).To(HaveLen(1))
+				})
+			})
+		})
+
+		Describe("AcquireUpstreamSchedulingLocks", func() {
+			It("acquires the scheduling lock of the upstream pipeline", func() {
+				upstreamLock, acquired, err := downstreamPipeline.AcquireUpstreamSchedulingLocks(logger)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				_, acquired, err = lockFactory.Acquire(logger, lock.NewPipelineSchedulingLockLockID(upstreamPipeline.ID()))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				Expect(upstreamLock.Release()).To(Succeed())
+
+				heldLock, acquired, err := lockFactory.Acquire(logger, lock.NewPipelineSchedulingLockLockID(upstreamPipeline.ID()))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+				Expect(heldLock.Release()).To(Succeed())
+			})
+
+			Context("when the upstream pipeline is being scheduled", func() {
+				var heldLock lock.Lock
+
+				BeforeEach(func() {
+					var acquired bool
+					var err error
+					heldLock, acquired, err = lockFactory.Acquire(logger, lock.NewPipelineSchedulingLockLockID(upstreamPipeline.ID()))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+				})
+
+				AfterEach(func() {
+					Expect(heldLock.Release()).To(Succeed())
+				})
+
+				It("does not acquire the locks", func() {
+					_, acquired, err := downstreamPipeline.AcquireUpstreamSchedulingLocks(logger)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeFalse())
+				})
+			})
+
+			Context("when the pipeline has no upstream pipelines", func() {
+				It("acquires nothing", func() {
+					upstreamLock, acquired, err := upstreamPipeline.AcquireUpstreamSchedulingLocks(logger)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+					Expect(upstreamLock.Release()).To(Succeed())
+				})
+			})
+		})
 	})
 
 	Describe("Dashboard", func() {
@@ -2108,7 +2914,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(actualDashboard[0].FinishedBuild.ID()).To(Equal(secondJobBuild.ID()))
 
 			By("returning a job's transition build as nil when there are no builds")
-			otherPipeline, _, err := team.SavePipeline("other-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+			otherPipeline, _, err := team.SavePipeline("other-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			otherJob, found, err = otherPipeline.Job("random-job")
@@ -0,0 +1,599 @@
+package db
+
+import (
+	"reflect"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/lib/pq"
+)
+
+// versionsDBCursor records how much of the pipeline's versions and builds a
+// VersionsDB was loaded from, so that it can be brought up to date from only
+// the rows saved since.
+type versionsDBCursor struct {
+	// versionID is the highest ID of the versions loaded. versions and
+	// versionsModifiedAt are the number and latest modified time of the
+	// versions up to it, which change when a loaded version is changed or
+	// deleted, or when a version is committed out of order.
+	versionID          int
+	versions           int
+	versionsModifiedAt time.Time
+
+	// buildID is the highest ID of the builds loaded, and builds the number
+	// of builds up to it.
+	buildID int
+	builds  int
+
+	// runningBuildIDs are the builds loaded before they completed, whose
+	// inputs and outputs are reloaded until they do.
+	runningBuildIDs            []int
+	runningBuildRows           int
+	runningBuildRowsModifiedAt time.Time
+
+	// upstreamJobIDs are the jobs in other pipelines named in passed
+	// constraints. Their outputs are matched to this pipeline's versions by
+	// resource config, so the VersionsDB is reloaded whenever one of their
+	// builds completes, a version of their pipelines is changed, e.g.
+	// disabled, or the resource config of a resource on either side changes.
+	upstreamJobIDs             map[string]int
+	upstreamBuildsEndedAt      time.Time
+	upstreamVersionID          int
+	upstreamVersions           int
+	upstreamVersionsModifiedAt time.Time
+	resourceConfigIDs          string
+}
+
+// LoadVersionsDB returns the versions, build inputs and build outputs of the
+// pipeline. The VersionsDB is cached, and only the rows saved since it was
+// loaded are loaded on later calls.
+func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
+	upstreamJobIDs, err := p.upstreamJobIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.versionsDB != nil {
+		versionsDB, updated, err := p.updateVersionsDB(upstreamJobIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if updated {
+			return versionsDB, nil
+		}
+	}
+
+	return p.reloadVersionsDB(upstreamJobIDs)
+}
+
+func (p *pipeline) reloadVersionsDB(upstreamJobIDs map[string]int) (*algorithm.VersionsDB, error) {
+	cursor, _, err := p.nextVersionsDBCursor(versionsDBCursor{}, upstreamJobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &algorithm.VersionsDB{}
+
+	builds := sq.LtOrEq{"b.id": cursor.buildID}
+
+	db.BuildOutputs, err = p.loadBuildOutputs(builds)
+	if err != nil {
+		return nil, err
+	}
+
+	upstreamOutputs, err := p.loadUpstreamBuildOutputs(upstreamJobIDs, sq.LtOrEq{"v.id": cursor.versionID})
+	if err != nil {
+		return nil, err
+	}
+
+	db.BuildOutputs = append(db.BuildOutputs, upstreamOutputs...)
+
+	inputs, implicitOutputs, err := p.loadBuildInputs(builds)
+	if err != nil {
+		return nil, err
+	}
+
+	db.BuildInputs = inputs
+	db.BuildOutputs = append(db.BuildOutputs, implicitOutputs...)
+
+	db.ResourceVersions, err = p.loadResourceVersions(sq.LtOrEq{"v.id": cursor.versionID})
+	if err != nil {
+		return nil, err
+	}
+
+	db.JobIDs, err = p.loadJobIDs(upstreamJobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	db.ResourceIDs, err = p.loadResourceIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	p.versionsDB = db
+	p.versionsDBCursor = cursor
+
+	return db, nil
+}
+
+// updateVersionsDB brings the cached VersionsDB up to date with the versions
+// and builds saved since it was loaded. It returns false if any of the rows it
+// was loaded from have since changed, in which case it must be reloaded.
+func (p *pipeline) updateVersionsDB(upstreamJobIDs map[string]int) (*algorithm.VersionsDB, bool, error) {
+	cursor := p.versionsDBCursor
+
+	next, unchanged, err := p.nextVersionsDBCursor(cursor, upstreamJobIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !unchanged {
+		return nil, false, nil
+	}
+
+	jobIDs, err := p.loadJobIDs(upstreamJobIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resourceIDs, err := p.loadResourceIDs()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if next.versionID == cursor.versionID &&
+		next.buildID == cursor.buildID &&
+		reflect.DeepEqual(next.runningBuildIDs, cursor.runningBuildIDs) &&
+		next.runningBuildRows == cursor.runningBuildRows &&
+		next.runningBuildRowsModifiedAt.Equal(cursor.runningBuildRowsModifiedAt) &&
+		reflect.DeepEqual(jobIDs, p.versionsDB.JobIDs) &&
+		reflect.DeepEqual(resourceIDs, p.versionsDB.ResourceIDs) {
+		return p.versionsDB, true, nil
+	}
+
+	update := algorithm.VersionsDBUpdate{
+		BuildIDs:    algorithm.BuildSet{},
+		JobIDs:      jobIDs,
+		ResourceIDs: resourceIDs,
+	}
+
+	for _, buildID := range cursor.runningBuildIDs {
+		update.BuildIDs[buildID] = struct{}{}
+	}
+
+	update.ResourceVersions, err = p.loadResourceVersions(
+		sq.Gt{"v.id": cursor.versionID},
+		sq.LtOrEq{"v.id": next.versionID},
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	builds := sq.Or{
+		sq.And{
+			sq.Gt{"b.id": cursor.buildID},
+			sq.LtOrEq{"b.id": next.buildID},
+		},
+		sq.Eq{"b.id": cursor.runningBuildIDs},
+	}
+
+	update.BuildOutputs, err = p.loadBuildOutputs(builds)
+	if err != nil {
+		return nil, false, err
+	}
+
+	inputs, implicitOutputs, err := p.loadBuildInputs(builds)
+	if err != nil {
+		return nil, false, err
+	}
+
+	update.BuildInputs = inputs
+	update.BuildOutputs = append(update.BuildOutputs, implicitOutputs...)
+
+	// versions saved since may have already made it through upstream jobs
+	upstreamOutputs, err := p.loadUpstreamBuildOutputs(
+		upstreamJobIDs,
+		sq.Gt{"v.id": cursor.versionID},
+		sq.LtOrEq{"v.id": next.versionID},
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	update.BuildOutputs = append(update.BuildOutputs, upstreamOutputs...)
+
+	p.versionsDB = p.versionsDB.Update(update)
+	p.versionsDBCursor = next
+
+	return p.versionsDB, true, nil
+}
+
+// nextVersionsDBCursor returns a cursor for the pipeline's versions and builds
+// as they are now. It also returns whether the rows behind the given cursor
+// are unchanged.
+func (p *pipeline) nextVersionsDBCursor(cursor versionsDBCursor, upstreamJobIDs map[string]int) (versionsDBCursor, bool, error) {
+	next := versionsDBCursor{
+		upstreamJobIDs: upstreamJobIDs,
+	}
+
+	var versions int
+	var versionsModifiedAt time.Time
+
+	err := p.conn.QueryRow(`
+		SELECT
+			COALESCE(MAX(v.id), 0),
+			COUNT(v.id),
+			COALESCE(MAX(v.modified_time), 'epoch'),
+			COUNT(CASE WHEN v.id <= $2 THEN 1 END),
+			COALESCE(MAX(CASE WHEN v.id <= $2 THEN v.modified_time END), 'epoch')
+		FROM versioned_resources v
+		JOIN resources r ON r.id = v.resource_id
+		WHERE r.pipeline_id = $1
+	`, p.id, cursor.versionID).Scan(
+		&next.versionID,
+		&next.versions,
+		&next.versionsModifiedAt,
+		&versions,
+		&versionsModifiedAt,
+	)
+	if err != nil {
+		return versionsDBCursor{}, false, err
+	}
+
+	var builds int
+
+	err = p.conn.QueryRow(`
+		SELECT
+			COALESCE(MAX(b.id), 0),
+			COUNT(b.id),
+			COUNT(CASE WHEN b.id <= $2 THEN 1 END)
+		FROM builds b
+		WHERE b.pipeline_id = $1
+	`, p.id, cursor.buildID).Scan(&next.buildID, &next.builds, &builds)
+	if err != nil {
+		return versionsDBCursor{}, false, err
+	}
+
+	rows, err := psql.Select("b.id").
+		From("builds b").
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+			"b.completed":   false,
+		}).
+		Where(sq.LtOrEq{"b.id": next.buildID}).
+		OrderBy("b.id").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return versionsDBCursor{}, false, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var buildID int
+		err = rows.Scan(&buildID)
+		if err != nil {
+			return versionsDBCursor{}, false, err
+		}
+
+		next.runningBuildIDs = append(next.runningBuildIDs, buildID)
+	}
+
+	err = p.conn.QueryRow(`
+		SELECT COUNT(*), COALESCE(MAX(modified_time), 'epoch')
+		FROM (
+			SELECT modified_time FROM build_inputs WHERE build_id = ANY($1)
+			UNION ALL
+			SELECT modified_time FROM build_outputs WHERE build_id = ANY($1)
+		) rows
+	`, pq.Array(next.runningBuildIDs)).Scan(&next.runningBuildRows, &next.runningBuildRowsModifiedAt)
+	if err != nil {
+		return versionsDBCursor{}, false, err
+	}
+
+	var upstreamVersions int
+	var upstreamVersionsModifiedAt time.Time
+
+	if len(upstreamJobIDs) > 0 {
+		jobIDs := []int{}
+		for _, jobID := range upstreamJobIDs {
+			jobIDs = append(jobIDs, jobID)
+		}
+
+		err = psql.Select("COALESCE(MAX(b.end_time), 'epoch')").
+			From("builds b").
+			Where(sq.Eq{"b.job_id": jobIDs}).
+			RunWith(p.conn).
+			QueryRow().
+			Scan(&next.upstreamBuildsEndedAt)
+		if err != nil {
+			return versionsDBCursor{}, false, err
+		}
+
+		err = p.conn.QueryRow(`
+			SELECT
+				COALESCE(MAX(uv.id), 0),
+				COUNT(uv.id),
+				COALESCE(MAX(uv.modified_time), 'epoch'),
+				COUNT(CASE WHEN uv.id <= $2 THEN 1 END),
+				COALESCE(MAX(CASE WHEN uv.id <= $2 THEN uv.modified_time END), 'epoch')
+			FROM versioned_resources uv
+			JOIN resources ur ON ur.id = uv.resource_id
+			WHERE ur.pipeline_id IN (SELECT pipeline_id FROM jobs WHERE id = ANY($1))
+		`, pq.Array(jobIDs), cursor.upstreamVersionID).Scan(
+			&next.upstreamVersionID,
+			&next.upstreamVersions,
+			&next.upstreamVersionsModifiedAt,
+			&upstreamVersions,
+			&upstreamVersionsModifiedAt,
+		)
+		if err != nil {
+			return versionsDBCursor{}, false, err
+		}
+
+		// resources have no config until they are first checked
+		err = p.conn.QueryRow(`
+			SELECT COALESCE(string_agg(r.id || ':' || COALESCE(r.resource_config_id, 0), ',' ORDER BY r.id), '')
+			FROM resources r
+			WHERE r.pipeline_id = $1
+				OR r.pipeline_id IN (SELECT pipeline_id FROM jobs WHERE id = ANY($2))
+		`, p.id, pq.Array(jobIDs)).Scan(&next.resourceConfigIDs)
+		if err != nil {
+			return versionsDBCursor{}, false, err
+		}
+	}
+
+	unchanged := versions == cursor.versions &&
+		versionsModifiedAt.Equal(cursor.versionsModifiedAt) &&
+		builds == cursor.builds &&
+		reflect.DeepEqual(upstreamJobIDs, cursor.upstreamJobIDs) &&
+		next.upstreamBuildsEndedAt.Equal(cursor.upstreamBuildsEndedAt) &&
+		upstreamVersions == cursor.upstreamVersions &&
+		upstreamVersionsModifiedAt.Equal(cursor.upstreamVersionsModifiedAt) &&
+		next.resourceConfigIDs == cursor.resourceConfigIDs
+
+	return next, unchanged, nil
+}
+
+func (p *pipeline) loadResourceVersions(filters ...sq.Sqlizer) ([]algorithm.ResourceVersion, error) {
+	query := psql.Select("v.id, v.check_order, r.id").
+		From("versioned_resources v, resources r").
+		Where(sq.Expr("r.id = v.resource_id")).
+		Where(sq.Eq{
+			"v.enabled":     true,
+			"r.pipeline_id": p.id,
+		})
+
+	for _, filter := range filters {
+		query = query.Where(filter)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := []algorithm.ResourceVersion{}
+	for rows.Next() {
+		var version algorithm.ResourceVersion
+		err = rows.Scan(&version.VersionID, &version.CheckOrder, &version.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// loadBuildOutputs loads the explicit outputs of the pipeline's succeeded
+// builds.
+func (p *pipeline) loadBuildOutputs(filters ...sq.Sqlizer) ([]algorithm.BuildOutput, error) {
+	query := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
+		From("build_outputs o, builds b, versioned_resources v, resources r").
+		Where(sq.Expr("v.id = o.versioned_resource_id")).
+		Where(sq.Expr("b.id = o.build_id")).
+		Where(sq.Expr("r.id = v.resource_id")).
+		Where(sq.Eq{
+			"v.enabled":     true,
+			"b.status":      BuildStatusSucceeded,
+			"r.pipeline_id": p.id,
+		})
+
+	for _, filter := range filters {
+		query = query.Where(filter)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	outputs := []algorithm.BuildOutput{}
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+// loadUpstreamBuildOutputs loads the versions which made it through jobs in
+// other pipelines, matched to this pipeline's versions of resources with the
+// same config. Resources which have not been checked yet have no config, and
+// so match nothing.
+func (p *pipeline) loadUpstreamBuildOutputs(upstreamJobIDs map[string]int, filters ...sq.Sqlizer) ([]algorithm.BuildOutput, error) {
+	outputs := []algorithm.BuildOutput{}
+
+	if len(upstreamJobIDs) == 0 {
+		return outputs, nil
+	}
+
+	jobIDs := []int{}
+	for _, jobID := range upstreamJobIDs {
+		jobIDs = append(jobIDs, jobID)
+	}
+
+	query := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
+		From("(SELECT build_id, versioned_resource_id FROM build_outputs UNION SELECT build_id, versioned_resource_id FROM build_inputs) o, builds b, versioned_resources uv, resources ur, versioned_resources v, resources r").
+		Where(sq.Expr("uv.id = o.versioned_resource_id")).
+		Where(sq.Expr("b.id = o.build_id")).
+		Where(sq.Expr("ur.id = uv.resource_id")).
+		Where(sq.NotEq{"ur.resource_config_id": nil}).
+		Where(sq.Expr("r.resource_config_id = ur.resource_config_id")).
+		Where(sq.Expr("v.resource_id = r.id")).
+		Where(sq.Expr("v.version = uv.version")).
+		Where(sq.Eq{
+			"uv.enabled":    true,
+			"v.enabled":     true,
+			"b.status":      BuildStatusSucceeded,
+			"b.job_id":      jobIDs,
+			"r.pipeline_id": p.id,
+		})
+
+	for _, filter := range filters {
+		query = query.Where(filter)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+// loadBuildInputs loads the inputs of the pipeline's builds, along with the
+// implicit outputs of the succeeded ones.
+func (p *pipeline) loadBuildInputs(filters ...sq.Sqlizer) ([]algorithm.BuildInput, []algorithm.BuildOutput, error) {
+	query := psql.Select("v.id, v.check_order, r.id, i.build_id, i.name, b.job_id, b.status = 'succeeded'").
+		From("build_inputs i, builds b, versioned_resources v, resources r").
+		Where(sq.Expr("v.id = i.versioned_resource_id")).
+		Where(sq.Expr("b.id = i.build_id")).
+		Where(sq.Expr("r.id = v.resource_id")).
+		Where(sq.Eq{
+			"v.enabled":     true,
+			"r.pipeline_id": p.id,
+		})
+
+	for _, filter := range filters {
+		query = query.Where(filter)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer Close(rows)
+
+	inputs := []algorithm.BuildInput{}
+	implicitOutputs := []algorithm.BuildOutput{}
+	for rows.Next() {
+		var succeeded bool
+
+		var input algorithm.BuildInput
+		err = rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID, &succeeded)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		inputs = append(inputs, input)
+
+		if succeeded {
+			implicitOutputs = append(implicitOutputs, algorithm.BuildOutput{
+				ResourceVersion: input.ResourceVersion,
+				JobID:           input.JobID,
+				BuildID:         input.BuildID,
+			})
+		}
+	}
+
+	return inputs, implicitOutputs, nil
+}
+
+func (p *pipeline) loadJobIDs(upstreamJobIDs map[string]int) (map[string]int, error) {
+	rows, err := psql.Select("j.name, j.id").
+		From("jobs j").
+		Where(sq.Eq{"j.pipeline_id": p.id}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	jobIDs := map[string]int{}
+	for rows.Next() {
+		var name string
+		var id int
+		err = rows.Scan(&name, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		jobIDs[name] = id
+	}
+
+	for name, id := range upstreamJobIDs {
+		if _, found := jobIDs[name]; !found {
+			jobIDs[name] = id
+		}
+	}
+
+	return jobIDs, nil
+}
+
+func (p *pipeline) loadResourceIDs() (map[string]int, error) {
+	rows, err := psql.Select("r.name, r.id").
+		From("resources r").
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	resourceIDs := map[string]int{}
+	for rows.Next() {
+		var name string
+		var id int
+		err = rows.Scan(&name, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceIDs[name] = id
+	}
+
+	return resourceIDs, nil
+}
@@ -23,22 +23,35 @@ type Resource interface {
 	CheckEvery() string
 	LastChecked() time.Time
 	Tags() atc.Tags
+	LabelSelector() atc.LabelSelector
 	CheckError() error
 	Paused() bool
 	WebhookToken() string
 	FailingToCheck() bool
+	VersionHistoryLimit() int
+
+	PinnedVersionID() int
+	PinnedVersion() atc.Version
+	PinnedBy() string
+	PinComment() string
 
 	SetResourceConfig(int) error
 
+	PinVersion(versionID int, pinnedBy string, comment string) (bool, error)
+	UnpinVersion() error
+
+	PruneVersions(limit int, keep []atc.Version, upstreamJobs []atc.PassedJob) (int, error)
+
 	Pause() error
 	Unpause() error
 
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, p.name, r.nonce").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, p.name, r.nonce, r.pinned_version_id, pv.version, r.pinned_by, r.pin_comment").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
+	LeftJoin("versioned_resources pv ON pv.id = r.pinned_version_id").
 	Where(sq.Eq{"r.active": true})
 
 type resource struct {
@@ -51,10 +64,18 @@ type resource struct {
 	checkEvery   string
 	lastChecked  time.Time
 	tags         atc.Tags
+	selector     atc.LabelSelector
 	checkError   error
 	paused       bool
 	webhookToken string
 
+	versionHistoryLimit int
+
+	pinnedVersionID int
+	pinnedVersion   atc.Version
+	pinnedBy        string
+	pinComment      string
+
 	conn Conn
 }
 
@@ -89,6 +110,10 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 			Source:       r.Source(),
 			CheckEvery:   r.CheckEvery(),
 			Tags:         r.Tags(),
+
+			LabelSelector: r.LabelSelector(),
+
+			VersionHistoryLimit: r.VersionHistoryLimit(),
 		})
 	}
 
@@ -110,6 +135,13 @@ func (r *resource) WebhookToken() string   { return r.webhookToken }
 func (r *resource) FailingToCheck() bool {
 	return r.checkError != nil
 }
+func (r *resource) VersionHistoryLimit() int         { return r.versionHistoryLimit }
+func (r *resource) LabelSelector() atc.LabelSelector { return r.selector }
+
+func (r *resource) PinnedVersionID() int       { return r.pinnedVersionID }
+func (r *resource) PinnedVersion() atc.Version { return r.pinnedVersion }
+func (r *resource) PinnedBy() string           { return r.pinnedBy }
+func (r *resource) PinComment() string         { return r.pinComment }
 
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
@@ -165,20 +197,223 @@ func (r *resource) SetResourceConfig(resourceConfigID int) error {
 	return err
 }
 
+// PinVersion pins the resource to one of its versions, which is used by every
+// job's input of the resource until it is unpinned. It returns false if the
+// version is not a version of the resource.
+func (r *resource) PinVersion(versionID int, pinnedBy string, comment string) (bool, error) {
+	result, err := psql.Update("resources").
+		Set("pinned_version_id", versionID).
+		Set("pinned_by", pinnedBy).
+		Set("pin_comment", comment).
+		Where(sq.Eq{"id": r.id}).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM versioned_resources WHERE id = ? AND resource_id = ?)", versionID, r.id)).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected != 1 {
+		return false, nil
+	}
+
+	notifyScheduler(r.conn, r.pipelineID)
+
+	return true, nil
+}
+
+func (r *resource) UnpinVersion() error {
+	_, err := psql.Update("resources").
+		Set("pinned_version_id", nil).
+		Set("pinned_by", nil).
+		Set("pin_comment", nil).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	notifyScheduler(r.conn, r.pipelineID)
+
+	return nil
+}
+
+// PruneVersions deletes all but the limit most recently checked versions of
+// the resource. Versions which were used by a build, which were chosen for a
+// job's next build, which the resource is pinned to, or which are equal to one
+// of the keep versions are never deleted. Neither are versions which made it
+// through one of the upstream jobs, i.e. jobs in other pipelines of the team
+// named in passed constraints, with a resource of the same config. It returns
+// the number of versions deleted.
+func (r *resource) PruneVersions(limit int, keep []atc.Version, upstreamJobs []atc.PassedJob) (int, error) {
+	keepVersions := []string{}
+	for _, version := range keep {
+		versionJSON, err := json.Marshal(version)
+		if err != nil {
+			return 0, err
+		}
+
+		keepVersions = append(keepVersions, string(versionJSON))
+	}
+
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	query := psql.Delete("versioned_resources v").
+		Where(sq.Eq{"v.resource_id": r.id}).
+		Where(sq.Expr(`v.id NOT IN (
+			SELECT id FROM versioned_resources
+			WHERE resource_id = ?
+			ORDER BY check_order DESC
+			LIMIT ?
+		)`, r.id, limit)).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM build_inputs WHERE versioned_resource_id = v.id)")).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM build_outputs WHERE versioned_resource_id = v.id)")).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM next_build_inputs WHERE version_id = v.id)")).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM independent_build_inputs WHERE version_id = v.id)")).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resources WHERE pinned_version_id = v.id)"))
+
+	if len(keepVersions) > 0 {
+		query = query.Where(sq.NotEq{"v.version": keepVersions})
+	}
+
+	upstreamJobIDs, err := r.upstreamJobIDs(tx, upstreamJobs)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(upstreamJobIDs) > 0 {
+		query = query.Where(sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM versioned_resources uv
+			JOIN resources ur ON ur.id = uv.resource_id
+			JOIN (
+				SELECT build_id, versioned_resource_id FROM build_outputs
+				UNION ALL
+				SELECT build_id, versioned_resource_id FROM build_inputs
+			) o ON o.versioned_resource_id = uv.id
+			JOIN builds b ON b.id = o.build_id
+			WHERE b.job_id = ANY(?)
+				AND b.status = 'succeeded'
+				AND uv.version = v.version
+				AND ur.resource_config_id = (SELECT resource_config_id FROM resources WHERE id = ?)
+		)`, pq.Array(upstreamJobIDs), r.id))
+	}
+
+	result, err := query.RunWith(tx).Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if deleted > 0 {
+		// deleting versions does not change any modified times, so bump the
+		// latest version's to make pipelines reload their versions
+		_, err = psql.Update("versioned_resources").
+			Set("modified_time", sq.Expr("now()")).
+			Where(sq.Expr(`id = (
+				SELECT id FROM versioned_resources
+				WHERE resource_id = ?
+				ORDER BY check_order DESC
+				LIMIT 1
+			)`, r.id)).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func (r *resource) upstreamJobIDs(tx Tx, upstreamJobs []atc.PassedJob) ([]int, error) {
+	if len(upstreamJobs) == 0 {
+		return nil, nil
+	}
+
+	names := sq.Or{}
+	for _, job := range upstreamJobs {
+		names = append(names, sq.Eq{
+			"p.name": job.PipelineName,
+			"j.name": job.JobName,
+		})
+	}
+
+	rows, err := psql.Select("j.id").
+		From("jobs j, pipelines p").
+		Where(sq.Expr("j.pipeline_id = p.id")).
+		Where(sq.Expr("p.team_id = (SELECT team_id FROM pipelines WHERE id = ?)", r.pipelineID)).
+		Where(names).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	jobIDs := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		jobIDs = append(jobIDs, id)
+	}
+
+	return jobIDs, nil
+}
+
 func scanResource(r *resource, row scannable) error {
 	var (
-		configBlob      []byte
-		checkErr, nonce sql.NullString
-		lastChecked     pq.NullTime
+		configBlob                          []byte
+		checkErr, nonce                     sql.NullString
+		lastChecked                         pq.NullTime
+		pinnedVersionID                     sql.NullInt64
+		pinnedVersion, pinnedBy, pinComment sql.NullString
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &r.pipelineName, &nonce)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &r.pipelineName, &nonce, &pinnedVersionID, &pinnedVersion, &pinnedBy, &pinComment)
 	if err != nil {
 		return err
 	}
 
 	r.lastChecked = lastChecked.Time
 
+	r.pinnedVersionID = int(pinnedVersionID.Int64)
+	r.pinnedBy = pinnedBy.String
+	r.pinComment = pinComment.String
+
+	r.pinnedVersion = nil
+	if pinnedVersion.Valid {
+		err = json.Unmarshal([]byte(pinnedVersion.String), &r.pinnedVersion)
+		if err != nil {
+			return err
+		}
+	}
+
 	es := r.conn.EncryptionStrategy()
 
 	var noncense *string
@@ -201,7 +436,9 @@ func scanResource(r *resource, row scannable) error {
 	r.source = config.Source
 	r.checkEvery = config.CheckEvery
 	r.tags = config.Tags
+	r.selector = config.LabelSelector
 	r.webhookToken = config.WebhookToken
+	r.versionHistoryLimit = config.VersionHistoryLimit
 
 	if checkErr.Valid {
 		r.checkError = errors.New(checkErr.String)
@@ -123,6 +123,7 @@ var _ = Describe("ResourceCacheFactory", func() {
 			},
 			db.ConfigVersion(0),
 			db.PipelineUnpaused,
+			"",
 		)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -79,7 +79,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 							},
 						},
 					},
-				}, defaultPipeline.ConfigVersion(), db.PipelineUnpaused)
+				}, defaultPipeline.ConfigVersion(), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				By("cleaning up inactive sessions")
@@ -173,7 +173,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 						},
 					},
 					ResourceTypes: atc.ResourceTypes{},
-				}, defaultPipeline.ConfigVersion(), db.PipelineUnpaused)
+				}, defaultPipeline.ConfigVersion(), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				By("cleaning up inactive sessions")
@@ -1,7 +1,9 @@
 package db_test
 
 import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -36,9 +38,18 @@ var _ = Describe("Resource", func() {
 						Source: atc.Source{"some": "((secret-repository))"},
 					},
 				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{Get: "some-resource"},
+						},
+					},
+				},
 			},
 			0,
 			db.PipelineUnpaused,
+			"",
 		)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
@@ -167,4 +178,268 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("PinVersion", func() {
+		var (
+			resource      db.Resource
+			pinnedVersion db.SavedVersionedResource
+		)
+
+		BeforeEach(func() {
+			err := pipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name: "some-resource",
+				Type: "docker-image",
+			}, []atc.Version{{"version": "v1"}, {"version": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name: "some-other-resource",
+				Type: "git",
+			}, []atc.Version{{"ref": "abc"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.PinnedVersionID()).To(BeZero())
+
+			versions, _, found, err := pipeline.GetResourceVersions("some-resource", db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(versions).To(HaveLen(2))
+
+			pinnedVersion = versions[1]
+			Expect(pinnedVersion.Version).To(Equal(db.ResourceVersion{"version": "v1"}))
+		})
+
+		It("pins the resource to the version", func() {
+			pinned, err := resource.PinVersion(pinnedVersion.ID, "some-user", "broken in prod")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pinned).To(BeTrue())
+
+			found, err := resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.PinnedVersionID()).To(Equal(pinnedVersion.ID))
+			Expect(resource.PinnedVersion()).To(Equal(atc.Version{"version": "v1"}))
+			Expect(resource.PinnedBy()).To(Equal("some-user"))
+			Expect(resource.PinComment()).To(Equal("broken in prod"))
+		})
+
+		Context("when the version belongs to another resource", func() {
+			It("does not pin the resource", func() {
+				otherVersion, found, err := pipeline.GetLatestVersionedResource("some-other-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				pinned, err := resource.PinVersion(otherVersion.ID, "some-user", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pinned).To(BeFalse())
+
+				found, err = resource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(resource.PinnedVersionID()).To(BeZero())
+			})
+		})
+
+		Describe("UnpinVersion", func() {
+			BeforeEach(func() {
+				pinned, err := resource.PinVersion(pinnedVersion.ID, "some-user", "broken in prod")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pinned).To(BeTrue())
+			})
+
+			It("unpins the resource", func() {
+				err := resource.UnpinVersion()
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := resource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(resource.PinnedVersionID()).To(BeZero())
+				Expect(resource.PinnedVersion()).To(BeNil())
+				Expect(resource.PinnedBy()).To(BeEmpty())
+				Expect(resource.PinComment()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("PruneVersions", func() {
+		var (
+			resource db.Resource
+			versions map[string]db.SavedVersionedResource
+		)
+
+		BeforeEach(func() {
+			resourceConfig := atc.ResourceConfig{
+				Name: "some-resource",
+				Type: "docker-image",
+			}
+
+			err := pipeline.SaveResourceVersions(resourceConfig, []atc.Version{
+				{"version": "v1"},
+				{"version": "v2"},
+				{"version": "v3"},
+				{"version": "v4"},
+				{"version": "v5"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			savedVersions, _, found, err := pipeline.GetResourceVersions("some-resource", db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			versions = map[string]db.SavedVersionedResource{}
+			for _, savedVersion := range savedVersions {
+				versions[savedVersion.Version["version"]] = savedVersion
+			}
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveInput(db.BuildInput{
+				Name:              "some-resource",
+				VersionedResource: versions["v1"].VersionedResource,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			pinned, err := resource.PinVersion(versions["v2"].ID, "some-user", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pinned).To(BeTrue())
+		})
+
+		It("deletes old versions which are not used or pinned", func() {
+			deleted, err := resource.PruneVersions(1, []atc.Version{{"version": "v3"}}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(Equal(1))
+
+			savedVersions, _, found, err := pipeline.GetResourceVersions("some-resource", db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			remaining := []string{}
+			for _, savedVersion := range savedVersions {
+				remaining = append(remaining, savedVersion.Version["version"])
+			}
+
+			Expect(remaining).To(Equal([]string{"v5", "v3", "v2", "v1"}))
+		})
+
+		It("keeps versions within the limit", func() {
+			deleted, err := resource.PruneVersions(5, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeZero())
+		})
+
+		It("makes the pipeline reload its versions", func() {
+			versionsDB, err := pipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versionsDB.ResourceVersions).To(HaveLen(5))
+
+			_, err = resource.PruneVersions(1, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			versionsDB, err = pipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versionsDB.ResourceVersions).To(HaveLen(3))
+		})
+
+		Context("when a version made it through a job in another pipeline", func() {
+			remainingVersions := func() []string {
+				savedVersions, _, found, err := pipeline.GetResourceVersions("some-resource", db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				remaining := []string{}
+				for _, savedVersion := range savedVersions {
+					remaining = append(remaining, savedVersion.Version["version"])
+				}
+
+				return remaining
+			}
+
+			BeforeEach(func() {
+				upstreamResourceConfig := atc.ResourceConfig{
+					Name:   "upstream-resource",
+					Type:   "docker-image",
+					Source: atc.Source{"some": "repository"},
+				}
+
+				upstreamPipeline, _, err := defaultTeam.SavePipeline("upstream-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{upstreamResourceConfig},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "upstream-job",
+							Plan: atc.PlanSequence{{Get: "upstream-resource"}},
+						},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = upstreamPipeline.SaveResourceVersions(upstreamResourceConfig, []atc.Version{{"version": "v4"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				upstreamVR, found, err := upstreamPipeline.GetVersionedResourceByVersion(atc.Version{"version": "v4"}, "upstream-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				upstreamJob, found, err := upstreamPipeline.Job("upstream-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				upstreamBuild, err := upstreamJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = upstreamBuild.SaveOutput(upstreamVR.VersionedResource)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = upstreamBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceConfigCheckSession, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
+					logger,
+					"some-base-resource-type",
+					atc.Source{"some": "repository"},
+					creds.NewVersionedResourceTypes(template.StaticVariables{}, atc.VersionedResourceTypes{}),
+					db.ContainerOwnerExpiries{},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				upstreamResource, found, err := upstreamPipeline.Resource("upstream-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(upstreamResource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+				Expect(resource.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+			})
+
+			It("keeps it if a passed constraint names the job", func() {
+				deleted, err := resource.PruneVersions(1, nil, []atc.PassedJob{
+					{PipelineName: "upstream-pipeline", JobName: "upstream-job"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deleted).To(Equal(1))
+
+				Expect(remainingVersions()).To(Equal([]string{"v5", "v4", "v2", "v1"}))
+			})
+
+			It("deletes it if no passed constraint names the job", func() {
+				deleted, err := resource.PruneVersions(1, nil, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deleted).To(Equal(2))
+
+				Expect(remainingVersions()).To(Equal([]string{"v5", "v2", "v1"}))
+			})
+		})
+	})
 })
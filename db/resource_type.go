@@ -27,6 +27,7 @@ type ResourceType interface {
 	Source() atc.Source
 	Params() atc.Params
 	Tags() atc.Tags
+	LabelSelector() atc.LabelSelector
 
 	SetResourceConfig(int) error
 
@@ -50,6 +51,8 @@ func (resourceTypes ResourceTypes) Deserialize() atc.VersionedResourceTypes {
 				Params:     t.Params(),
 				Privileged: t.Privileged(),
 				Tags:       t.Tags(),
+
+				LabelSelector: t.LabelSelector(),
 			},
 			Version: t.Version(),
 		})
@@ -69,6 +72,8 @@ func (resourceTypes ResourceTypes) Configs() atc.ResourceTypes {
 			Params:     r.Params(),
 			Privileged: r.Privileged(),
 			Tags:       r.Tags(),
+
+			LabelSelector: r.LabelSelector(),
 		})
 	}
 
@@ -87,6 +92,7 @@ type resourceType struct {
 	source     atc.Source
 	params     atc.Params
 	tags       atc.Tags
+	selector   atc.LabelSelector
 	version    atc.Version
 
 	conn Conn
@@ -100,6 +106,8 @@ func (t *resourceType) Source() atc.Source { return t.source }
 func (t *resourceType) Params() atc.Params { return t.params }
 func (r *resourceType) Tags() atc.Tags     { return r.tags }
 
+func (t *resourceType) LabelSelector() atc.LabelSelector { return t.selector }
+
 func (t *resourceType) Version() atc.Version { return t.version }
 func (t *resourceType) SaveVersion(version atc.Version) error {
 	versionJSON, err := json.Marshal(version)
@@ -194,6 +202,7 @@ func scanResourceType(t *resourceType, row scannable) error {
 	t.params = config.Params
 	t.privileged = config.Privileged
 	t.tags = config.Tags
+	t.selector = config.LabelSelector
 
 	return nil
 }
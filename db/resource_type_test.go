@@ -41,6 +41,7 @@ var _ = Describe("ResourceType", func() {
 			},
 			0,
 			db.PipelineUnpaused,
+			"",
 		)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
@@ -105,6 +106,7 @@ var _ = Describe("ResourceType", func() {
 					},
 					pipeline.ConfigVersion(),
 					db.PipelineUnpaused,
+					"",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
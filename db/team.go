@@ -28,6 +28,7 @@ type Team interface {
 	Admin() bool
 
 	Auth() map[string]*json.RawMessage
+	MaxContainers() int
 
 	Delete() error
 	Rename(string) error
@@ -37,6 +38,7 @@ type Team interface {
 		config atc.Config,
 		from ConfigVersion,
 		pausedState PipelinePausedState,
+		author string,
 	) (Pipeline, bool, error)
 
 	Pipeline(pipelineName string) (Pipeline, bool, error)
@@ -48,6 +50,7 @@ type Team interface {
 	CreateOneOffBuild() (Build, error)
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch, Page) ([]BuildLogSearchResult, Pagination, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -65,6 +68,14 @@ type Team interface {
 	CreateContainer(workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 
 	UpdateProviderAuth(auth map[string]*json.RawMessage) error
+	UpdateMaxContainers(int) error
+
+	ContainerQuotaReached() (bool, error)
+
+	SaveVar(name string, value string) error
+	Var(name string) (string, bool, error)
+	VarNames() ([]string, error)
+	DeleteVar(name string) (bool, error)
 }
 
 type team struct {
@@ -76,6 +87,8 @@ type team struct {
 	admin bool
 
 	auth map[string]*json.RawMessage
+
+	maxContainers int
 }
 
 func (t *team) ID() int      { return t.id }
@@ -84,6 +97,10 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() map[string]*json.RawMessage { return t.auth }
 
+// MaxContainers is the number of containers the team may have at once. Zero
+// means there is no limit.
+func (t *team) MaxContainers() int { return t.maxContainers }
+
 func (t *team) Delete() error {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -92,6 +109,11 @@ func (t *team) Delete() error {
 
 	defer Rollback(tx)
 
+	archivedBuildIDs, err := findArchivedBuildIDs(tx, sq.Eq{"team_id": t.id})
+	if err != nil {
+		return err
+	}
+
 	_, err = psql.Delete("teams").
 		Where(sq.Eq{
 			"name": t.name,
@@ -111,7 +133,12 @@ func (t *team) Delete() error {
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return deleteBuildEventsArchives(t.conn, archivedBuildIDs)
 }
 
 func (t *team) Rename(name string) error {
@@ -397,6 +424,7 @@ func (t *team) SavePipeline(
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+	author string,
 ) (Pipeline, bool, error) {
 	groupsPayload, err := json.Marshal(config.Groups)
 	if err != nil {
@@ -475,6 +503,11 @@ func (t *team) SavePipeline(
 		if err != nil {
 			return nil, false, err
 		}
+
+		err = createBuildLogSearchIndex(tx, fmt.Sprintf("pipeline_build_events_%d", pipelineID))
+		if err != nil {
+			return nil, false, err
+		}
 	} else {
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
@@ -585,11 +618,18 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	err = savePipelineConfig(tx, t.conn, pipelineID, pipeline.ConfigVersion(), config, author)
+	if err != nil {
+		return nil, false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, false, err
 	}
 
+	notifyScheduler(t.conn, pipelineID)
+
 	return pipeline, created, nil
 }
 
@@ -752,6 +792,10 @@ func (t *team) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"t.id": t.id}), page, t.conn, t.lockFactory)
 }
 
+func (t *team) SearchBuildLogs(search BuildLogSearch, page Page) ([]BuildLogSearchResult, Pagination, error) {
+	return searchBuildLogs(t.conn, t.lockFactory, sq.Eq{"b.team_id": t.id}, search, page)
+}
+
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -789,12 +833,170 @@ func (t *team) UpdateProviderAuth(auth map[string]*json.RawMessage) error {
 		UPDATE teams
 		SET auth = $1, nonce = $3
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, max_containers
 	`
 	params := []interface{}{string(encryptedAuth), t.id, nonce}
 	return t.queryTeam(query, params)
 }
 
+func (t *team) UpdateMaxContainers(maxContainers int) error {
+	_, err := psql.Update("teams").
+		Set("max_containers", maxContainers).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.maxContainers = maxContainers
+
+	return nil
+}
+
+// ContainerQuotaReached returns true if the team has a container quota and
+// already has as many creating or created containers as it allows.
+func (t *team) ContainerQuotaReached() (bool, error) {
+	var reached bool
+	err := psql.Select("t.max_containers > 0 AND COUNT(c.id) >= t.max_containers").
+		From("teams t").
+		LeftJoin("containers c ON c.team_id = t.id AND c.state IN ('creating', 'created')").
+		Where(sq.Eq{"t.id": t.id}).
+		GroupBy("t.id").
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&reached)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return reached, nil
+}
+
+func (t *team) SaveVar(name string, value string) error {
+	es := t.conn.EncryptionStrategy()
+	encryptedValue, nonce, err := es.Encrypt([]byte(value))
+	if err != nil {
+		return err
+	}
+
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE team_vars
+		SET value = $3, nonce = $4
+		WHERE team_id = $1 AND name = $2
+	`, t.id, name, encryptedValue, nonce)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		_, err = tx.Exec(`
+			INSERT INTO team_vars (team_id, name, value, nonce)
+			VALUES ($1, $2, $3, $4)
+		`, t.id, name, encryptedValue, nonce)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (t *team) Var(name string) (string, bool, error) {
+	var (
+		encryptedValue string
+		nonce          sql.NullString
+	)
+
+	err := psql.Select("value", "nonce").
+		From("team_vars").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&encryptedValue, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	value, err := t.conn.EncryptionStrategy().Decrypt(encryptedValue, noncense)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(value), true, nil
+}
+
+func (t *team) VarNames() ([]string, error) {
+	rows, err := psql.Select("name").
+		From("team_vars").
+		Where(sq.Eq{
+			"team_id": t.id,
+		}).
+		OrderBy("name ASC").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		err := rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (t *team) DeleteVar(name string) (bool, error) {
+	result, err := psql.Delete("team_vars").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
 func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) error {
 	configPayload, err := json.Marshal(job)
 	if err != nil {
@@ -807,11 +1009,13 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 		return err
 	}
 
+	upstreamJobs := pq.Array(crossPipelinePassed(job))
+
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
-		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6
+		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6, priority = $7, upstream_jobs = $8
 		WHERE name = $1 AND pipeline_id = $2
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}")
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}", job.Priority, upstreamJobs)
 	if err != nil {
 		return err
 	}
@@ -821,13 +1025,33 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags)
-		VALUES ($1, $2, $3, $4, true, $5, $6)
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}")
+		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags, priority, upstream_jobs)
+		VALUES ($1, $2, $3, $4, true, $5, $6, $7, $8)
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}", job.Priority, upstreamJobs)
 
 	return swallowUniqueViolation(err)
 }
 
+// crossPipelinePassed returns the jobs of other pipelines named in the passed
+// constraints of the job, as they are stored in jobs.upstream_jobs.
+func crossPipelinePassed(job atc.JobConfig) []string {
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, input := range job.Inputs() {
+		for _, name := range input.Passed {
+			if !atc.ParsePassedJob(name).IsCrossPipeline() || seen[name] {
+				continue
+			}
+
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
 func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineID int) error {
 	_, err := tx.Exec(`
     INSERT INTO jobs_serial_groups (serial_group, job_id) VALUES
@@ -1019,6 +1243,7 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.maxContainers,
 	)
 	if err != nil {
 		return err
@@ -57,10 +57,15 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var maxContainers int
+	if t.MaxContainers != nil {
+		maxContainers = *t.MaxContainers
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, nonce, admin").
-		Values(t.Name, encryptedAuth, nonce, admin).
-		Suffix("RETURNING id, name, admin, auth, nonce").
+		Columns("name, auth, nonce, admin, max_containers").
+		Values(t.Name, encryptedAuth, nonce, admin, maxContainers).
+		Suffix("RETURNING id, name, admin, auth, nonce, max_containers").
 		RunWith(tx).
 		QueryRow()
 
@@ -82,6 +87,11 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	err = createBuildLogSearchIndex(tx, fmt.Sprintf("team_build_events_%d", team.ID()))
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -104,7 +114,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth, nonce").
+	row := psql.Select("id, name, admin, auth, nonce, max_containers").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -123,7 +133,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth, nonce").
+	rows, err := psql.Select("id, name, admin, auth, nonce, max_containers").
 		From("teams").
 		OrderBy("id ASC").
 		RunWith(factory.conn).
@@ -189,6 +199,7 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.maxContainers,
 	)
 
 	if providerAuth.Valid {
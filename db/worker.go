@@ -38,13 +38,19 @@ type Worker interface {
 	HTTPSProxyURL() string
 	NoProxy() string
 	ActiveContainers() int
+	ActiveVolumes() int
+	ReservedMemory() uint64
+	BuildContainers() int
+	MaxBuildContainers() int
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
+	Labels() map[string]string
 	TeamID() int
 	TeamName() string
 	StartTime() int64
 	ExpiresAt() time.Time
+	DrainStartedAt() time.Time
 
 	Reload() (bool, error)
 
@@ -57,23 +63,29 @@ type Worker interface {
 type worker struct {
 	conn Conn
 
-	name             string
-	version          *string
-	state            WorkerState
-	gardenAddr       *string
-	baggageclaimURL  *string
-	httpProxyURL     string
-	httpsProxyURL    string
-	noProxy          string
-	activeContainers int
-	resourceTypes    []atc.WorkerResourceType
-	platform         string
-	tags             []string
-	teamID           int
-	teamName         string
-	startTime        int64
-	expiresAt        time.Time
-	certsPath        *string
+	name               string
+	version            *string
+	state              WorkerState
+	gardenAddr         *string
+	baggageclaimURL    *string
+	httpProxyURL       string
+	httpsProxyURL      string
+	noProxy            string
+	activeContainers   int
+	activeVolumes      int
+	reservedMemory     uint64
+	buildContainers    int
+	maxBuildContainers int
+	resourceTypes      []atc.WorkerResourceType
+	platform           string
+	tags               []string
+	labels             map[string]string
+	teamID             int
+	teamName           string
+	startTime          int64
+	expiresAt          time.Time
+	drainStartedAt     time.Time
+	certsPath          *string
 }
 
 func (worker *worker) Name() string                            { return worker.name }
@@ -86,16 +98,34 @@ func (worker *worker) HTTPProxyURL() string                    { return worker.h
 func (worker *worker) HTTPSProxyURL() string                   { return worker.httpsProxyURL }
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
+func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
+func (worker *worker) Labels() map[string]string               { return worker.labels }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 
+// ReservedMemory is the sum of the memory limits declared for the worker's
+// containers.
+func (worker *worker) ReservedMemory() uint64 { return worker.reservedMemory }
+
+// BuildContainers is the number of containers on the worker which belong to
+// builds.
+func (worker *worker) BuildContainers() int { return worker.buildContainers }
+
+// MaxBuildContainers is the number of build containers the worker will accept,
+// as given at registration. Zero means there is no limit.
+func (worker *worker) MaxBuildContainers() int { return worker.maxBuildContainers }
+
 // TODO: normalize time values
 func (worker *worker) StartTime() int64     { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
 
+// DrainStartedAt is when the worker began landing or retiring. It is zero
+// while the worker is not draining.
+func (worker *worker) DrainStartedAt() time.Time { return worker.drainStartedAt }
+
 func (worker *worker) Reload() (bool, error) {
 	row := workersQuery.Where(sq.Eq{"w.name": worker.name}).
 		RunWith(worker.conn).
@@ -123,6 +153,7 @@ func (worker *worker) Land() error {
 
 	result, err := psql.Update("workers").
 		Set("state", sq.Expr("("+cSQL+")")).
+		Set("drain_started_at", sq.Expr("COALESCE(drain_started_at, now())")).
 		Where(sq.Eq{"name": worker.name}).
 		RunWith(worker.conn).
 		Exec()
@@ -146,7 +177,8 @@ func (worker *worker) Land() error {
 func (worker *worker) Retire() error {
 	result, err := psql.Update("workers").
 		SetMap(map[string]interface{}{
-			"state": string(WorkerStateRetiring),
+			"state":            string(WorkerStateRetiring),
+			"drain_started_at": sq.Expr("COALESCE(drain_started_at, now())"),
 		}).
 		Where(sq.Eq{"name": worker.name}).
 		RunWith(worker.conn).
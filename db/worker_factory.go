@@ -42,13 +42,19 @@ var workersQuery = psql.Select(`
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.active_volumes,
+		COALESCE((SELECT SUM(wc.meta_memory_limit) FROM containers wc WHERE wc.worker_name = w.name), 0)::bigint,
+		(SELECT COUNT(*) FROM containers bc WHERE bc.worker_name = w.name AND bc.build_id IS NOT NULL),
+		w.max_build_containers,
 		w.resource_types,
 		w.platform,
 		w.tags,
+		w.labels,
 		t.name,
 		w.team_id,
 		w.start_time,
-		w.expires
+		w.expires,
+		w.drain_started_at
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -128,10 +134,12 @@ func scanWorker(worker *worker, row scannable) error {
 		resourceTypes []byte
 		platform      sql.NullString
 		tags          []byte
+		labels        []byte
 		teamName      sql.NullString
 		teamID        sql.NullInt64
 		startTime     sql.NullInt64
 		expiresAt     *time.Time
+		drainStarted  *time.Time
 	)
 
 	err := row.Scan(
@@ -145,13 +153,19 @@ func scanWorker(worker *worker, row scannable) error {
 		&httpsProxyURL,
 		&noProxy,
 		&worker.activeContainers,
+		&worker.activeVolumes,
+		&worker.reservedMemory,
+		&worker.buildContainers,
+		&worker.maxBuildContainers,
 		&resourceTypes,
 		&platform,
 		&tags,
+		&labels,
 		&teamName,
 		&teamID,
 		&startTime,
 		&expiresAt,
+		&drainStarted,
 	)
 	if err != nil {
 		return err
@@ -183,6 +197,10 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.expiresAt = *expiresAt
 	}
 
+	if drainStarted != nil {
+		worker.drainStartedAt = *drainStarted
+	}
+
 	if httpProxyURL.Valid {
 		worker.httpProxyURL = httpProxyURL.String
 	}
@@ -212,7 +230,12 @@ func scanWorker(worker *worker, row scannable) error {
 		return err
 	}
 
-	return json.Unmarshal(tags, &worker.tags)
+	err = json.Unmarshal(tags, &worker.tags)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(labels, &worker.labels)
 }
 
 func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
@@ -265,6 +288,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("addr", sq.Expr("("+addrSQL+")")).
 		Set("baggageclaim_url", sq.Expr("("+bcSQL+")")).
 		Set("active_containers", atcWorker.ActiveContainers).
+		Set("active_volumes", atcWorker.ActiveVolumes).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -329,6 +353,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	labels, err := json.Marshal(atcWorker.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -359,8 +388,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					"addr",
 					"expires",
 					"active_containers",
+					"active_volumes",
+					"max_build_containers",
 					"resource_types",
 					"tags",
+					"labels",
 					"platform",
 					"baggageclaim_url",
 					"certs_path",
@@ -377,8 +409,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					atcWorker.GardenAddr,
 					sq.Expr(expires),
 					atcWorker.ActiveContainers,
+					atcWorker.ActiveVolumes,
+					atcWorker.MaxBuildContainers,
 					resourceTypes,
 					tags,
+					labels,
 					atcWorker.Platform,
 					atcWorker.BaggageclaimURL,
 					atcWorker.CertsPath,
@@ -409,8 +444,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			Set("addr", atcWorker.GardenAddr).
 			Set("expires", sq.Expr(expires)).
 			Set("active_containers", atcWorker.ActiveContainers).
+			Set("active_volumes", atcWorker.ActiveVolumes).
+			Set("max_build_containers", atcWorker.MaxBuildContainers).
 			Set("resource_types", resourceTypes).
 			Set("tags", tags).
+			Set("labels", labels).
 			Set("platform", atcWorker.Platform).
 			Set("baggageclaim_url", atcWorker.BaggageclaimURL).
 			Set("certs_path", atcWorker.CertsPath).
@@ -421,6 +459,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			Set("version", workerVersion).
 			Set("start_time", atcWorker.StartTime).
 			Set("state", string(workerState)).
+			Set("drain_started_at", nil).
 			Where(sq.Eq{
 				"name": atcWorker.Name,
 			}).
@@ -437,23 +476,26 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 	}
 
 	savedWorker := &worker{
-		name:             atcWorker.Name,
-		version:          workerVersion,
-		state:            workerState,
-		gardenAddr:       &atcWorker.GardenAddr,
-		baggageclaimURL:  &atcWorker.BaggageclaimURL,
-		certsPath:        atcWorker.CertsPath,
-		httpProxyURL:     atcWorker.HTTPProxyURL,
-		httpsProxyURL:    atcWorker.HTTPSProxyURL,
-		noProxy:          atcWorker.NoProxy,
-		activeContainers: atcWorker.ActiveContainers,
-		resourceTypes:    atcWorker.ResourceTypes,
-		platform:         atcWorker.Platform,
-		tags:             atcWorker.Tags,
-		teamName:         atcWorker.Team,
-		teamID:           workerTeamID,
-		startTime:        atcWorker.StartTime,
-		conn:             conn,
+		name:               atcWorker.Name,
+		version:            workerVersion,
+		state:              workerState,
+		gardenAddr:         &atcWorker.GardenAddr,
+		baggageclaimURL:    &atcWorker.BaggageclaimURL,
+		certsPath:          atcWorker.CertsPath,
+		httpProxyURL:       atcWorker.HTTPProxyURL,
+		httpsProxyURL:      atcWorker.HTTPSProxyURL,
+		noProxy:            atcWorker.NoProxy,
+		activeContainers:   atcWorker.ActiveContainers,
+		activeVolumes:      atcWorker.ActiveVolumes,
+		maxBuildContainers: atcWorker.MaxBuildContainers,
+		resourceTypes:      atcWorker.ResourceTypes,
+		platform:           atcWorker.Platform,
+		tags:               atcWorker.Tags,
+		labels:             atcWorker.Labels,
+		teamName:           atcWorker.Team,
+		teamID:             workerTeamID,
+		startTime:          atcWorker.StartTime,
+		conn:               conn,
 	}
 
 	workerBaseResourceTypeIDs := []int{}
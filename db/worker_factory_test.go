@@ -25,6 +25,10 @@ var _ = Describe("WorkerFactory", func() {
 			HTTPSProxyURL:    "some-https-proxy-url",
 			NoProxy:          "some-no-proxy",
 			ActiveContainers: 140,
+			ActiveVolumes:    550,
+
+			MaxBuildContainers: 100,
+
 			ResourceTypes: []atc.WorkerResourceType{
 				{
 					Type:       "some-resource-type",
@@ -41,6 +45,7 @@ var _ = Describe("WorkerFactory", func() {
 			},
 			Platform:  "some-platform",
 			Tags:      atc.Tags{"some", "tags"},
+			Labels:    map[string]string{"arch": "arm64", "zone": "us-east-1a"},
 			Name:      "some-name",
 			StartTime: 55,
 		}
@@ -81,6 +86,19 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(worker.ResourceTypes()).To(Equal(atcWorker.ResourceTypes))
 			})
 
+			It("replaces its labels", func() {
+				atcWorker.Labels = map[string]string{"arch": "amd64"}
+
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				worker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(found).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(worker.Labels()).To(Equal(map[string]string{"arch": "amd64"}))
+			})
+
 			It("removes old worker resource type", func() {
 				atcWorker.ResourceTypes = []atc.WorkerResourceType{
 					{
@@ -138,6 +156,46 @@ var _ = Describe("WorkerFactory", func() {
 				})
 			})
 
+			Context("when the worker is landing", func() {
+				BeforeEach(func() {
+					err := worker.Land()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("cancels the land", func() {
+					savedWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(savedWorker.State()).To(Equal(db.WorkerStateRunning))
+
+					foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
+					Expect(foundWorker.DrainStartedAt()).To(BeZero())
+				})
+			})
+
+			Context("when the worker has landed", func() {
+				BeforeEach(func() {
+					err := worker.Land()
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = workerLifecycle.LandFinishedLandingWorkers()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("makes it running again", func() {
+					savedWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(savedWorker.State()).To(Equal(db.WorkerStateRunning))
+
+					foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(foundWorker.DrainStartedAt()).To(BeZero())
+				})
+			})
+
 			Context("when the worker has a new version", func() {
 				BeforeEach(func() {
 					atcWorker.Version = "1.0.0"
@@ -202,6 +260,8 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.HTTPSProxyURL()).To(Equal("some-https-proxy-url"))
 				Expect(foundWorker.NoProxy()).To(Equal("some-no-proxy"))
 				Expect(foundWorker.ActiveContainers()).To(Equal(140))
+				Expect(foundWorker.ActiveVolumes()).To(Equal(550))
+				Expect(foundWorker.MaxBuildContainers()).To(Equal(100))
 				Expect(foundWorker.ResourceTypes()).To(Equal([]atc.WorkerResourceType{
 					{
 						Type:       "some-resource-type",
@@ -217,10 +277,64 @@ var _ = Describe("WorkerFactory", func() {
 				}))
 				Expect(foundWorker.Platform()).To(Equal("some-platform"))
 				Expect(foundWorker.Tags()).To(Equal([]string{"some", "tags"}))
+				Expect(foundWorker.Labels()).To(Equal(map[string]string{"arch": "arm64", "zone": "us-east-1a"}))
 				Expect(foundWorker.StartTime()).To(Equal(int64(55)))
 				Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
 			})
 
+			It("has no reserved memory", func() {
+				foundWorker, found, err := workerFactory.GetWorker("some-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(foundWorker.ReservedMemory()).To(BeZero())
+				Expect(foundWorker.BuildContainers()).To(BeZero())
+			})
+
+			Context("when containers with memory limits are on the worker", func() {
+				BeforeEach(func() {
+					build, err := defaultJob.CreateBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = defaultTeam.CreateContainer(
+						"some-name",
+						db.NewBuildStepContainerOwner(build.ID(), "some-plan"),
+						db.ContainerMetadata{MemoryLimit: 1024},
+					)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = defaultTeam.CreateContainer(
+						"some-name",
+						db.NewBuildStepContainerOwner(build.ID(), "some-other-plan"),
+						db.ContainerMetadata{MemoryLimit: 2048},
+					)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = defaultTeam.CreateContainer(
+						"some-name",
+						db.NewBuildStepContainerOwner(build.ID(), "yet-another-plan"),
+						db.ContainerMetadata{},
+					)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("sums their limits as the reserved memory", func() {
+					foundWorker, found, err := workerFactory.GetWorker("some-name")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(foundWorker.ReservedMemory()).To(Equal(uint64(3072)))
+				})
+
+				It("counts them as build containers", func() {
+					foundWorker, found, err := workerFactory.GetWorker("some-name")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(foundWorker.BuildContainers()).To(Equal(3))
+				})
+			})
+
 			Context("when worker is stalled", func() {
 				BeforeEach(func() {
 					_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
@@ -394,6 +508,7 @@ var _ = Describe("WorkerFactory", func() {
 
 			It("updates the expires field and the number of active containers", func() {
 				atcWorker.ActiveContainers = 1
+				atcWorker.ActiveVolumes = 2
 
 				now := time.Now()
 				By("current time")
@@ -409,6 +524,7 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.Name()).To(Equal(atcWorker.Name))
 				Expect(foundWorker.ExpiresAt()).To(BeTemporally("~", later, epsilon))
 				Expect(foundWorker.ActiveContainers()).To(And(Not(Equal(activeContainers)), Equal(1)))
+				Expect(foundWorker.ActiveVolumes()).To(Equal(2))
 				Expect(*foundWorker.GardenAddr()).To(Equal("some-garden-addr"))
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
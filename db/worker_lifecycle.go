@@ -14,6 +14,28 @@ type WorkerLifecycle interface {
 	DeleteFinishedRetiringWorkers() ([]string, error)
 }
 
+// drainBlockingBuild matches the builds which keep a landing or retiring
+// worker that they have containers on from finishing: those which have not
+// completed, unless their job is interruptible.
+var drainBlockingBuild = sq.And{
+	sq.Or{
+		sq.Eq{
+			"b.status": string(BuildStatusStarted),
+		},
+		sq.Eq{
+			"b.status": string(BuildStatusPending),
+		},
+	},
+	sq.Or{
+		sq.Eq{
+			"j.interruptible": false,
+		},
+		sq.Eq{
+			"b.job_id": nil,
+		},
+	},
+}
+
 type workerLifecycle struct {
 	conn Conn
 }
@@ -61,22 +83,7 @@ func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, err
 		Join("containers c ON b.id = c.build_id").
 		Join("workers w ON w.name = c.worker_name").
 		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Or{
-			sq.Eq{
-				"b.status": string(BuildStatusStarted),
-			},
-			sq.Eq{
-				"b.status": string(BuildStatusPending),
-			},
-		}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+		Where(drainBlockingBuild).ToSql()
 
 	if err != nil {
 		return []string{}, err
@@ -117,22 +124,7 @@ func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error)
 		Join("containers c ON b.id = c.build_id").
 		Join("workers w ON w.name = c.worker_name").
 		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Or{
-			sq.Eq{
-				"b.status": string(BuildStatusStarted),
-			},
-			sq.Eq{
-				"b.status": string(BuildStatusPending),
-			},
-		}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+		Where(drainBlockingBuild).ToSql()
 
 	if err != nil {
 		return nil, err
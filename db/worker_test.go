@@ -65,6 +65,24 @@ var _ = Describe("Worker", func() {
 				Expect(worker.State()).To(Equal(WorkerStateLanding))
 			})
 
+			It("records when the worker started draining", func() {
+				err := worker.Land()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+
+				drainStartedAt := worker.DrainStartedAt()
+				Expect(drainStartedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+				err = worker.Land()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(worker.DrainStartedAt()).To(Equal(drainStartedAt))
+			})
+
 			Context("when worker is already landed", func() {
 				BeforeEach(func() {
 					err := worker.Land()
@@ -114,6 +132,15 @@ var _ = Describe("Worker", func() {
 				Expect(worker.Name()).To(Equal(atcWorker.Name))
 				Expect(worker.State()).To(Equal(WorkerStateRetiring))
 			})
+
+			It("records when the worker started draining", func() {
+				err := worker.Retire()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(worker.DrainStartedAt()).To(BeTemporally("~", time.Now(), time.Minute))
+			})
 		})
 
 		Context("when the worker is not present", func() {
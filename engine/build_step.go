@@ -22,6 +22,20 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return agg
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("in-parallel")
+
+	steps := []exec.Step{}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		step := build.buildStep(logger, innerPlan)
+		steps = append(steps, step)
+	}
+
+	return exec.InParallel(steps, plan.InParallel.Limit, plan.InParallel.FailFast)
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("do")
 
@@ -53,6 +67,18 @@ func (build *execBuild) buildTryStep(logger lager.Logger, plan atc.Plan) exec.St
 	return exec.Try(step)
 }
 
+func (build *execBuild) buildConditionalStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	innerPlan := plan.Conditional.Step
+	innerPlan.Attempts = plan.Attempts
+	step := build.buildStep(logger, innerPlan)
+	return exec.Conditional(
+		plan.Conditional.Condition,
+		build.dbBuild.IsManuallyTriggered(),
+		step,
+		build.delegate.ConditionalDelegate(plan.ID),
+	)
+}
+
 func (build *execBuild) buildOnAbortStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	plan.OnAbort.Step.Attempts = plan.Attempts
 	step := build.buildStep(logger, plan.OnAbort.Step)
@@ -145,6 +171,19 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 	)
 }
 
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.SetPipelineDelegate(plan.ID),
+	)
+}
+
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("retry")
 
@@ -1,7 +1,10 @@
 package engine
 
 import (
+	"bytes"
 	"io"
+	"sort"
+	"strings"
 	"sync"
 	"unicode/utf8"
 
@@ -9,26 +12,38 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
+const RedactedCredential = "((redacted))"
+
 type BuildStepDelegate struct {
-	build  db.Build
-	planID atc.PlanID
-	clock  clock.Clock
+	build     db.Build
+	planID    atc.PlanID
+	clock     clock.Clock
+	variables *creds.TrackedVariables
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
+	lock   *sync.Mutex
 }
 
 func NewBuildStepDelegate(
 	build db.Build,
 	planID atc.PlanID,
 	clock clock.Clock,
+	variables *creds.TrackedVariables,
 ) *BuildStepDelegate {
 	return &BuildStepDelegate{
-		build:  build,
-		planID: planID,
-		clock:  clock,
+		build:     build,
+		planID:    planID,
+		clock:     clock,
+		variables: variables,
+
+		lock: &sync.Mutex{},
 	}
 }
 
@@ -36,29 +51,53 @@ func (delegate *BuildStepDelegate) ImageVersionDetermined(resourceCache *db.Used
 	return delegate.build.SaveImageResourceVersion(resourceCache)
 }
 
+// Variables returns the build's credentials. Any value resolved through them
+// is redacted from the output written to Stdout and Stderr.
+func (delegate *BuildStepDelegate) Variables() creds.Variables {
+	return delegate.variables
+}
+
 func (delegate *BuildStepDelegate) Stdout() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStdout,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	if delegate.stdout == nil {
+		delegate.stdout = newDBEventWriter(
+			delegate.build,
+			event.Origin{
+				Source: event.OriginSourceStdout,
+				ID:     event.OriginID(delegate.planID),
+			},
+			delegate.clock,
+			delegate.variables,
+		)
+	}
+
+	return delegate.stdout
 }
 
 func (delegate *BuildStepDelegate) Stderr() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStderr,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	if delegate.stderr == nil {
+		delegate.stderr = newDBEventWriter(
+			delegate.build,
+			event.Origin{
+				Source: event.OriginSourceStderr,
+				ID:     event.OriginID(delegate.planID),
+			},
+			delegate.clock,
+			delegate.variables,
+		)
+	}
+
+	return delegate.stderr
 }
 
 func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string) {
+	delegate.flush(logger)
+
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
 		Origin: event.Origin{
@@ -70,11 +109,30 @@ func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
-func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
+// flush saves any output held back by the writers while waiting to see if it
+// completes a credential. It must be called before the step's final event.
+func (delegate *BuildStepDelegate) flush(logger lager.Logger) {
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	for _, writer := range []*dbEventWriter{delegate.stdout, delegate.stderr} {
+		if writer == nil {
+			continue
+		}
+
+		err := writer.Flush()
+		if err != nil {
+			logger.Error("failed-to-flush-output", err)
+		}
+	}
+}
+
+func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock, variables *creds.TrackedVariables) *dbEventWriter {
 	return &dbEventWriter{
-		build:  build,
-		origin: origin,
-		clock:  clock,
+		build:     build,
+		origin:    origin,
+		clock:     clock,
+		variables: variables,
 	}
 }
 
@@ -85,10 +143,20 @@ type dbEventWriter struct {
 
 	dangling []byte
 
+	// a trailing portion of output which may be the start of a credential
+	pending string
+
 	clock clock.Clock
+
+	variables *creds.TrackedVariables
+
+	lock sync.Mutex
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
 	text := append(writer.dangling, data...)
 
 	checkEncoding, _ := utf8.DecodeLastRune(text)
@@ -99,11 +167,12 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 
 	writer.dangling = nil
 
-	err := writer.build.SaveEvent(event.Log{
-		Time:    writer.clock.Now().Unix(),
-		Payload: string(text),
-		Origin:  writer.origin,
-	})
+	payload := writer.redact(writer.pending+string(text), false)
+	if payload == "" {
+		return len(data), nil
+	}
+
+	err := writer.save(payload)
 	if err != nil {
 		return 0, err
 	}
@@ -111,6 +180,78 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// Flush saves any held back output, redacting it without waiting for more.
+func (writer *dbEventWriter) Flush() error {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	payload := writer.redact(writer.pending+string(writer.dangling), true)
+	writer.dangling = nil
+
+	if payload == "" {
+		return nil
+	}
+
+	return writer.save(payload)
+}
+
+// redact replaces every tracked credential in the text. Unless final, the
+// remainder of the text is held back as soon as it could be the start of a
+// credential, so that one split across writes is still caught.
+func (writer *dbEventWriter) redact(text string, final bool) string {
+	writer.pending = ""
+
+	if writer.variables == nil {
+		return text
+	}
+
+	secrets := writer.variables.Values()
+	if len(secrets) == 0 {
+		return text
+	}
+
+	// prefer the longest match, so that a credential containing another is
+	// redacted as a whole
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	redacted := new(bytes.Buffer)
+
+scan:
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		for _, secret := range secrets {
+			if !final && len(rest) < len(secret) && strings.HasPrefix(secret, rest) {
+				writer.pending = rest
+				break scan
+			}
+		}
+
+		for _, secret := range secrets {
+			if strings.HasPrefix(rest, secret) {
+				redacted.WriteString(RedactedCredential)
+				i += len(secret)
+				continue scan
+			}
+		}
+
+		redacted.WriteByte(text[i])
+		i++
+	}
+
+	return redacted.String()
+}
+
+func (writer *dbEventWriter) save(payload string) error {
+	return writer.build.SaveEvent(event.Log{
+		Time:    writer.clock.Now().Unix(),
+		Payload: payload,
+		Origin:  writer.origin,
+	})
+}
+
 type implicitOutput struct {
 	resourceType string
 	info         exec.VersionInfo
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
 
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -19,6 +22,7 @@ var _ = Describe("BuildStepDelegate", func() {
 	var (
 		fakeBuild *dbfakes.FakeBuild
 		fakeClock *fakeclock.FakeClock
+		variables *creds.TrackedVariables
 
 		delegate *engine.BuildStepDelegate
 	)
@@ -26,7 +30,11 @@ var _ = Describe("BuildStepDelegate", func() {
 	BeforeEach(func() {
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
-		delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", fakeClock)
+		variables = creds.NewTrackedVariables(template.StaticVariables{
+			"some-var":  "some-secret",
+			"other-var": "some-secret-but-longer",
+		})
+		delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", fakeClock, variables)
 	})
 
 	Describe("ImageVersionDetermined", func() {
@@ -153,4 +161,109 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 	})
+
+	Describe("Variables", func() {
+		It("returns the tracked variables", func() {
+			Expect(delegate.Variables()).To(Equal(variables))
+		})
+	})
+
+	Describe("redacting credentials", func() {
+		var writer io.Writer
+
+		payloads := func() []string {
+			var written []string
+			for i := 0; i < fakeBuild.SaveEventCallCount(); i++ {
+				if log, ok := fakeBuild.SaveEventArgsForCall(i).(event.Log); ok {
+					written = append(written, log.Payload)
+				}
+			}
+
+			return written
+		}
+
+		BeforeEach(func() {
+			writer = delegate.Stdout()
+		})
+
+		It("returns the same writer each time", func() {
+			Expect(delegate.Stdout()).To(BeIdenticalTo(writer))
+		})
+
+		Context("when no credentials have been resolved", func() {
+			It("writes output as-is", func() {
+				_, err := writer.Write([]byte("some-secret\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(payloads()).To(Equal([]string{"some-secret\n"}))
+			})
+		})
+
+		Context("when credentials have been resolved", func() {
+			BeforeEach(func() {
+				_, _, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = variables.Get(template.VariableDefinition{Name: "other-var"})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("redacts them from the output", func() {
+				n, err := writer.Write([]byte("got some-secret and some-secret-but-longer\n"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(n).To(Equal(len("got some-secret and some-secret-but-longer\n")))
+
+				Expect(payloads()).To(Equal([]string{"got ((redacted)) and ((redacted))\n"}))
+			})
+
+			It("redacts them from stderr too", func() {
+				_, err := delegate.Stderr().Write([]byte("some-secret\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(payloads()).To(Equal([]string{"((redacted))\n"}))
+			})
+
+			It("redacts a credential split across writes", func() {
+				_, err := writer.Write([]byte("got some-se"))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = writer.Write([]byte("cr"))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = writer.Write([]byte("et!\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(payloads()).To(Equal([]string{"got ", "((redacted))!\n"}))
+			})
+
+			It("holds back output which may be the start of a credential", func() {
+				_, err := writer.Write([]byte("got some-secret-but"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(payloads()).To(Equal([]string{"got "}))
+			})
+
+			Context("when the step errors with output held back", func() {
+				BeforeEach(func() {
+					_, err := writer.Write([]byte("got some-secret-but"))
+					Expect(err).ToNot(HaveOccurred())
+
+					delegate.Errored(lagertest.NewTestLogger("test"), "nope")
+				})
+
+				It("saves the redacted held back output before the error", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
+					Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+						Time:    123456789,
+						Payload: "((redacted))-but",
+						Origin: event.Origin{
+							Source: event.OriginSourceStdout,
+							ID:     "some-plan-id",
+						},
+					}))
+					Expect(fakeBuild.SaveEventArgsForCall(2)).To(BeAssignableToTypeOf(event.Error{}))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+type conditionalDelegate struct {
+	*BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewConditionalDelegate(build db.Build, planID atc.PlanID, clock clock.Clock, variables *creds.TrackedVariables) exec.ConditionalDelegate {
+	return &conditionalDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock, variables),
+
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *conditionalDelegate) Skipped(logger lager.Logger, reason string) {
+	err := d.build.SaveEvent(event.Skipped{
+		Time:   d.clock.Now().Unix(),
+		Origin: d.eventOrigin,
+		Reason: reason,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-skipped-event", err)
+		return
+	}
+
+	logger.Info("skipped", lager.Data{"reason": reason})
+}
@@ -44,6 +44,28 @@ type FakeBuildDelegate struct {
 	taskDelegateReturnsOnCall map[int]struct {
 		result1 exec.TaskDelegate
 	}
+	ConditionalDelegateStub        func(atc.PlanID) exec.ConditionalDelegate
+	conditionalDelegateMutex       sync.RWMutex
+	conditionalDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	conditionalDelegateReturns struct {
+		result1 exec.ConditionalDelegate
+	}
+	conditionalDelegateReturnsOnCall map[int]struct {
+		result1 exec.ConditionalDelegate
+	}
+	SetPipelineDelegateStub        func(atc.PlanID) exec.SetPipelineDelegate
+	setPipelineDelegateMutex       sync.RWMutex
+	setPipelineDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	setPipelineDelegateReturns struct {
+		result1 exec.SetPipelineDelegate
+	}
+	setPipelineDelegateReturnsOnCall map[int]struct {
+		result1 exec.SetPipelineDelegate
+	}
 	BuildStepDelegateStub        func(atc.PlanID) exec.BuildStepDelegate
 	buildStepDelegateMutex       sync.RWMutex
 	buildStepDelegateArgsForCall []struct {
@@ -210,6 +232,102 @@ func (fake *FakeBuildDelegate) TaskDelegateReturnsOnCall(i int, result1 exec.Tas
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) ConditionalDelegate(arg1 atc.PlanID) exec.ConditionalDelegate {
+	fake.conditionalDelegateMutex.Lock()
+	ret, specificReturn := fake.conditionalDelegateReturnsOnCall[len(fake.conditionalDelegateArgsForCall)]
+	fake.conditionalDelegateArgsForCall = append(fake.conditionalDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("ConditionalDelegate", []interface{}{arg1})
+	fake.conditionalDelegateMutex.Unlock()
+	if fake.ConditionalDelegateStub != nil {
+		return fake.ConditionalDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.conditionalDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateCallCount() int {
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
+	return len(fake.conditionalDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateArgsForCall(i int) atc.PlanID {
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
+	return fake.conditionalDelegateArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateReturns(result1 exec.ConditionalDelegate) {
+	fake.ConditionalDelegateStub = nil
+	fake.conditionalDelegateReturns = struct {
+		result1 exec.ConditionalDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateReturnsOnCall(i int, result1 exec.ConditionalDelegate) {
+	fake.ConditionalDelegateStub = nil
+	if fake.conditionalDelegateReturnsOnCall == nil {
+		fake.conditionalDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.ConditionalDelegate
+		})
+	}
+	fake.conditionalDelegateReturnsOnCall[i] = struct {
+		result1 exec.ConditionalDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegate(arg1 atc.PlanID) exec.SetPipelineDelegate {
+	fake.setPipelineDelegateMutex.Lock()
+	ret, specificReturn := fake.setPipelineDelegateReturnsOnCall[len(fake.setPipelineDelegateArgsForCall)]
+	fake.setPipelineDelegateArgsForCall = append(fake.setPipelineDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("SetPipelineDelegate", []interface{}{arg1})
+	fake.setPipelineDelegateMutex.Unlock()
+	if fake.SetPipelineDelegateStub != nil {
+		return fake.SetPipelineDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setPipelineDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateCallCount() int {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return len(fake.setPipelineDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateArgsForCall(i int) atc.PlanID {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return fake.setPipelineDelegateArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturns(result1 exec.SetPipelineDelegate) {
+	fake.SetPipelineDelegateStub = nil
+	fake.setPipelineDelegateReturns = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturnsOnCall(i int, result1 exec.SetPipelineDelegate) {
+	fake.SetPipelineDelegateStub = nil
+	if fake.setPipelineDelegateReturnsOnCall == nil {
+		fake.setPipelineDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.SetPipelineDelegate
+		})
+	}
+	fake.setPipelineDelegateReturnsOnCall[i] = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) BuildStepDelegate(arg1 atc.PlanID) exec.BuildStepDelegate {
 	fake.buildStepDelegateMutex.Lock()
 	ret, specificReturn := fake.buildStepDelegateReturnsOnCall[len(fake.buildStepDelegateArgsForCall)]
@@ -293,6 +411,10 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.putDelegateMutex.RUnlock()
 	fake.taskDelegateMutex.RLock()
 	defer fake.taskDelegateMutex.RUnlock()
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
 	fake.buildStepDelegateMutex.RLock()
 	defer fake.buildStepDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
@@ -191,6 +191,10 @@ func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -231,10 +235,18 @@ func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step
 		return build.buildPutStep(logger, plan)
 	}
 
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
 	if plan.Retry != nil {
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.Conditional != nil {
+		return build.buildConditionalStep(logger, plan)
+	}
+
 	if plan.UserArtifact != nil {
 		return build.buildUserArtifactStep(logger, plan)
 	}
@@ -269,5 +281,7 @@ func (build *execBuild) containerMetadata(
 
 		StepName: stepName,
 		Attempt:  strings.Join(attemptStrs, "."),
+
+		Priority: build.dbBuild.Priority(),
 	}
 }
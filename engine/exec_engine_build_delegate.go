@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -16,6 +17,8 @@ type BuildDelegate interface {
 	GetDelegate(atc.PlanID) exec.GetDelegate
 	PutDelegate(atc.PlanID) exec.PutDelegate
 	TaskDelegate(atc.PlanID) exec.TaskDelegate
+	ConditionalDelegate(atc.PlanID) exec.ConditionalDelegate
+	SetPipelineDelegate(atc.PlanID) exec.SetPipelineDelegate
 
 	BuildStepDelegate(atc.PlanID) exec.BuildStepDelegate
 
@@ -28,40 +31,57 @@ type BuildDelegateFactory interface {
 	Delegate(db.Build) BuildDelegate
 }
 
-type buildDelegateFactory struct{}
+type buildDelegateFactory struct {
+	variablesFactory creds.VariablesFactory
+}
 
-func NewBuildDelegateFactory() BuildDelegateFactory {
-	return buildDelegateFactory{}
+func NewBuildDelegateFactory(variablesFactory creds.VariablesFactory) BuildDelegateFactory {
+	return buildDelegateFactory{
+		variablesFactory: variablesFactory,
+	}
 }
 
 func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+	return newBuildDelegate(
+		build,
+		creds.NewTrackedVariables(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())),
+	)
 }
 
 type delegate struct {
-	build db.Build
+	build     db.Build
+	variables *creds.TrackedVariables
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, variables *creds.TrackedVariables) BuildDelegate {
 	return &delegate{
-		build: build,
+		build:     build,
+		variables: variables,
 	}
 }
 
 func (delegate *delegate) GetDelegate(planID atc.PlanID) exec.GetDelegate {
-	return NewGetDelegate(delegate.build, planID, clock.NewClock())
+	return NewGetDelegate(delegate.build, planID, clock.NewClock(), delegate.variables)
 }
 
 func (delegate *delegate) PutDelegate(planID atc.PlanID) exec.PutDelegate {
-	return NewPutDelegate(delegate.build, planID, clock.NewClock())
+	return NewPutDelegate(delegate.build, planID, clock.NewClock(), delegate.variables)
 }
 
 func (delegate *delegate) TaskDelegate(planID atc.PlanID) exec.TaskDelegate {
-	return NewTaskDelegate(delegate.build, planID, clock.NewClock())
+	return NewTaskDelegate(delegate.build, planID, clock.NewClock(), delegate.variables)
+}
+
+func (delegate *delegate) ConditionalDelegate(planID atc.PlanID) exec.ConditionalDelegate {
+	return NewConditionalDelegate(delegate.build, planID, clock.NewClock(), delegate.variables)
+}
+
+func (delegate *delegate) SetPipelineDelegate(planID atc.PlanID) exec.SetPipelineDelegate {
+	return NewSetPipelineDelegate(delegate.build, planID, clock.NewClock(), delegate.variables)
 }
 
 func (delegate *delegate) BuildStepDelegate(planID atc.PlanID) exec.BuildStepDelegate {
-	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
+	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock(), delegate.variables)
 }
 
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded bool) {
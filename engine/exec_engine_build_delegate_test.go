@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/engine"
@@ -18,7 +20,8 @@ var _ = Describe("BuildDelegate", func() {
 	var (
 		factory BuildDelegateFactory
 
-		fakeBuild *dbfakes.FakeBuild
+		fakeVariablesFactory *credsfakes.FakeVariablesFactory
+		fakeBuild            *dbfakes.FakeBuild
 
 		delegate BuildDelegate
 
@@ -28,9 +31,16 @@ var _ = Describe("BuildDelegate", func() {
 	)
 
 	BeforeEach(func() {
-		factory = NewBuildDelegateFactory()
+		fakeVariablesFactory = new(credsfakes.FakeVariablesFactory)
+		fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{
+			"some-var": "some-secret",
+		})
+
+		factory = NewBuildDelegateFactory(fakeVariablesFactory)
 
 		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
 		delegate = factory.Delegate(fakeBuild)
 
 		logger = lagertest.NewTestLogger("test")
@@ -38,6 +48,29 @@ var _ = Describe("BuildDelegate", func() {
 		originID = event.OriginID("some-origin-id")
 	})
 
+	It("constructs variables for the build's team and pipeline", func() {
+		Expect(fakeVariablesFactory.NewVariablesCallCount()).To(Equal(1))
+		teamName, pipelineName := fakeVariablesFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+	})
+
+	Describe("step delegates", func() {
+		It("share the build's variables, redacting any credential resolved by another step", func() {
+			value, found, err := delegate.GetDelegate("some-get").Variables().Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-secret"))
+
+			_, err = delegate.TaskDelegate("some-task").Stdout().Write([]byte("the secret is some-secret\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(BeAssignableToTypeOf(event.Log{}))
+			Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("the secret is ((redacted))\n"))
+		})
+	})
+
 	Describe("Finish", func() {
 		Context("when build was aborted", func() {
 			BeforeEach(func() {
@@ -69,6 +69,7 @@ var _ = Describe("ExecEngine", func() {
 			dbBuild.PipelineIDReturns(expectedPipelineID)
 			dbBuild.TeamNameReturns("some-team")
 			dbBuild.TeamIDReturns(expectedTeamID)
+			dbBuild.PriorityReturns(10)
 
 			expectedMetadata = engine.StepMetadata{
 				BuildID:      expectedBuildID,
@@ -155,6 +156,7 @@ var _ = Describe("ExecEngine", func() {
 						JobName:      "some-job",
 						BuildID:      expectedBuildID,
 						BuildName:    "42",
+						Priority:     10,
 					}))
 
 					logger, plan, build, stepMetadata, containerMetadata, _ = fakeFactory.PutArgsForCall(1)
@@ -170,6 +172,7 @@ var _ = Describe("ExecEngine", func() {
 						JobName:      "some-job",
 						BuildID:      expectedBuildID,
 						BuildName:    "42",
+						Priority:     10,
 					}))
 				})
 			})
@@ -251,6 +254,7 @@ var _ = Describe("ExecEngine", func() {
 					BuildID:      expectedBuildID,
 					BuildName:    "42",
 					Attempt:      "1",
+					Priority:     10,
 				}))
 			})
 
@@ -272,6 +276,7 @@ var _ = Describe("ExecEngine", func() {
 					BuildID:      expectedBuildID,
 					BuildName:    "42",
 					Attempt:      "3",
+					Priority:     10,
 				}))
 			})
 
@@ -296,6 +301,7 @@ var _ = Describe("ExecEngine", func() {
 					BuildID:      expectedBuildID,
 					BuildName:    "42",
 					Attempt:      "2.1",
+					Priority:     10,
 				}))
 
 				logger, plan, build, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
@@ -314,6 +320,7 @@ var _ = Describe("ExecEngine", func() {
 					BuildID:      expectedBuildID,
 					BuildName:    "42",
 					Attempt:      "2.2",
+					Priority:     10,
 				}))
 			})
 		})
@@ -380,6 +387,169 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 
+		Context("with a conditional plan", func() {
+			var (
+				conditionalPlan atc.Plan
+				fakeConditional *execfakes.FakeConditionalDelegate
+			)
+
+			BeforeEach(func() {
+				fakeConditional = new(execfakes.FakeConditionalDelegate)
+				fakeDelegate.ConditionalDelegateReturns(fakeConditional)
+
+				conditionalPlan = planFactory.NewPlan(atc.ConditionalPlan{
+					Condition: atc.Condition{
+						Trigger: atc.ConditionTriggerManual,
+					},
+					Step: planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-task",
+						ConfigPath: "some-input/build.yml",
+					}),
+				})
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				build, err = execEngine.CreateBuild(logger, dbBuild, conditionalPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			Context("when the condition is met", func() {
+				BeforeEach(func() {
+					dbBuild.IsManuallyTriggeredReturns(true)
+				})
+
+				It("runs the nested step", func() {
+					Expect(taskStep.RunCallCount()).To(Equal(1))
+					Expect(fakeConditional.SkippedCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the condition is not met", func() {
+				BeforeEach(func() {
+					dbBuild.IsManuallyTriggeredReturns(false)
+				})
+
+				It("skips the nested step using the conditional's delegate", func() {
+					Expect(taskStep.RunCallCount()).To(BeZero())
+					Expect(fakeDelegate.ConditionalDelegateCallCount()).To(Equal(1))
+					Expect(fakeDelegate.ConditionalDelegateArgsForCall(0)).To(Equal(conditionalPlan.ID))
+					Expect(fakeConditional.SkippedCallCount()).To(Equal(1))
+				})
+
+				It("finishes the build successfully", func() {
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, succeeded := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(succeeded).To(BeTrue())
+				})
+			})
+		})
+
+		Context("with an in_parallel plan", func() {
+			var (
+				inParallelPlan atc.Plan
+				taskPlan       atc.Plan
+				otherTaskPlan  atc.Plan
+			)
+
+			BeforeEach(func() {
+				taskPlan = planFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task",
+					ConfigPath: "some-input/build.yml",
+				})
+
+				otherTaskPlan = planFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-other-task",
+					ConfigPath: "some-input/build.yml",
+				})
+
+				inParallelPlan = planFactory.NewPlan(atc.InParallelPlan{
+					Steps:    []atc.Plan{taskPlan, otherTaskPlan},
+					Limit:    1,
+					FailFast: true,
+				})
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				build, err = execEngine.CreateBuild(logger, dbBuild, inParallelPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			It("constructs and runs every step", func() {
+				Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+				Expect(taskStep.RunCallCount()).To(Equal(2))
+			})
+
+			Context("when the first step fails", func() {
+				BeforeEach(func() {
+					taskStep.SucceededReturns(false)
+				})
+
+				It("does not run the remaining steps", func() {
+					Expect(taskStep.RunCallCount()).To(Equal(1))
+				})
+
+				It("finishes the build as failed", func() {
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, succeeded := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(succeeded).To(BeFalse())
+				})
+			})
+		})
+
+		Context("with a set_pipeline plan", func() {
+			var (
+				setPipelinePlan atc.Plan
+				setPipelineStep *execfakes.FakeStep
+				fakeSetPipeline *execfakes.FakeSetPipelineDelegate
+			)
+
+			BeforeEach(func() {
+				setPipelineStep = new(execfakes.FakeStep)
+				setPipelineStep.SucceededReturns(true)
+				fakeFactory.SetPipelineReturns(setPipelineStep)
+
+				fakeSetPipeline = new(execfakes.FakeSetPipelineDelegate)
+				fakeDelegate.SetPipelineDelegateReturns(fakeSetPipeline)
+
+				setPipelinePlan = planFactory.NewPlan(atc.SetPipelinePlan{
+					Name: "some-pipeline",
+					File: "some-input/pipeline.yml",
+				})
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				build, err = execEngine.CreateBuild(logger, dbBuild, setPipelinePlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			It("constructs the step with the build and its delegate", func() {
+				Expect(fakeFactory.SetPipelineCallCount()).To(Equal(1))
+
+				logger, plan, build, delegate := fakeFactory.SetPipelineArgsForCall(0)
+				Expect(logger).NotTo(BeNil())
+				Expect(plan).To(Equal(setPipelinePlan))
+				Expect(build).To(Equal(dbBuild))
+				Expect(delegate).To(Equal(fakeSetPipeline))
+
+				Expect(fakeDelegate.SetPipelineDelegateArgsForCall(0)).To(Equal(setPipelinePlan.ID))
+			})
+
+			It("runs the step", func() {
+				Expect(setPipelineStep.RunCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("with a basic plan", func() {
 			var expectedPlan atc.Plan
 
@@ -418,6 +588,7 @@ var _ = Describe("ExecEngine", func() {
 						JobName:      "some-job",
 						BuildID:      expectedBuildID,
 						BuildName:    "42",
+						Priority:     10,
 					}))
 				})
 			})
@@ -466,6 +637,7 @@ var _ = Describe("ExecEngine", func() {
 						JobName:      "some-job",
 						BuildID:      expectedBuildID,
 						BuildName:    "42",
+						Priority:     10,
 					}))
 				})
 			})
@@ -524,6 +696,7 @@ var _ = Describe("ExecEngine", func() {
 						JobName:      "some-job",
 						BuildID:      expectedBuildID,
 						BuildName:    "42",
+						Priority:     10,
 					}))
 				})
 
@@ -549,6 +722,7 @@ var _ = Describe("ExecEngine", func() {
 						JobName:      "some-job",
 						BuildID:      expectedBuildID,
 						BuildName:    "42",
+						Priority:     10,
 					}))
 				})
 			})
@@ -568,6 +742,7 @@ var _ = Describe("ExecEngine", func() {
 			dbBuild.PipelineIDReturns(expectedPipelineID)
 			dbBuild.TeamNameReturns("some-team")
 			dbBuild.TeamIDReturns(expectedTeamID)
+			dbBuild.PriorityReturns(10)
 		})
 
 		Context("when the build has a get step", func() {
@@ -624,6 +799,7 @@ var _ = Describe("ExecEngine", func() {
 					BuildID:      expectedBuildID,
 					BuildName:    "42",
 					Attempt:      "1",
+					Priority:     10,
 				}))
 			})
 		})
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+type setPipelineDelegate struct {
+	*BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewSetPipelineDelegate(build db.Build, planID atc.PlanID, clock clock.Clock, variables *creds.TrackedVariables) exec.SetPipelineDelegate {
+	return &setPipelineDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock, variables),
+
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *setPipelineDelegate) SetPipelineChanged(logger lager.Logger, pipelineName string, created bool, changes []atc.ConfigChange) {
+	err := d.build.SaveEvent(event.SetPipelineChanged{
+		Time:     d.clock.Now().Unix(),
+		Origin:   d.eventOrigin,
+		Team:     d.build.TeamName(),
+		Pipeline: pipelineName,
+		Created:  created,
+		Changes:  changes,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-set-pipeline-changed-event", err)
+		return
+	}
+
+	logger.Info("set-pipeline-changed", lager.Data{"created": created, "changes": len(changes)})
+}
@@ -7,21 +7,22 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
 type taskDelegate struct {
-	exec.BuildStepDelegate
+	*BuildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
 }
 
-func NewTaskDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.TaskDelegate {
+func NewTaskDelegate(build db.Build, planID atc.PlanID, clock clock.Clock, variables *creds.TrackedVariables) exec.TaskDelegate {
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock, variables),
 
 		build: build,
 		eventOrigin: event.Origin{
@@ -59,6 +60,8 @@ func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig)
 }
 
 func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus) {
+	d.BuildStepDelegate.flush(logger)
+
 	err := d.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(exitStatus),
 		Time:       time.Now().Unix(),
@@ -10,6 +10,27 @@ type Error struct {
 func (Error) EventType() atc.EventType  { return EventTypeError }
 func (Error) Version() atc.EventVersion { return "4.0" }
 
+type Skipped struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Reason string `json:"reason"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
+
+type SetPipelineChanged struct {
+	Time     int64              `json:"time"`
+	Origin   Origin             `json:"origin"`
+	Team     string             `json:"team"`
+	Pipeline string             `json:"pipeline"`
+	Created  bool               `json:"created"`
+	Changes  []atc.ConfigChange `json:"changes"`
+}
+
+func (SetPipelineChanged) EventType() atc.EventType  { return EventTypeSetPipelineChanged }
+func (SetPipelineChanged) Version() atc.EventVersion { return "1.0" }
+
 type FinishTask struct {
 	Time       int64  `json:"time"`
 	ExitStatus int    `json:"exit_status"`
@@ -35,6 +56,8 @@ type TaskConfig struct {
 
 	Run    TaskRunConfig     `json:"run"`
 	Inputs []TaskInputConfig `json:"inputs"`
+
+	Limits *atc.ContainerLimits `json:"container_limits,omitempty"`
 }
 
 type TaskRunConfig struct {
@@ -67,6 +90,7 @@ func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
 			Dir:  config.Run.Dir,
 		},
 		Inputs: inputConfigs,
+		Limits: config.Limits,
 	}
 }
 
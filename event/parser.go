@@ -41,6 +41,8 @@ func init() {
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(Skipped{})
+	registerEvent(SetPipelineChanged{})
 
 	// deprecated:
 	registerEvent(InitializeV10{})
@@ -29,4 +29,10 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// step skipped because its condition was not met
+	EventTypeSkipped atc.EventType = "skipped"
+
+	// pipeline configured by a set_pipeline step
+	EventTypeSetPipelineChanged atc.EventType = "set-pipeline-changed"
 )
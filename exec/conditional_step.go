@@ -0,0 +1,151 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+)
+
+//go:generate counterfeiter . ConditionalDelegate
+
+type ConditionalDelegate interface {
+	BuildStepDelegate
+
+	Skipped(lager.Logger, string)
+}
+
+// ConditionalStep runs the nested step only if its condition is met at the
+// time the step is reached.
+type ConditionalStep struct {
+	condition         atc.Condition
+	manuallyTriggered bool
+	step              Step
+	delegate          ConditionalDelegate
+
+	skipped bool
+}
+
+// Conditional constructs a ConditionalStep.
+func Conditional(
+	condition atc.Condition,
+	manuallyTriggered bool,
+	step Step,
+	delegate ConditionalDelegate,
+) *ConditionalStep {
+	return &ConditionalStep{
+		condition:         condition,
+		manuallyTriggered: manuallyTriggered,
+		step:              step,
+		delegate:          delegate,
+	}
+}
+
+// Run evaluates the condition against the build and the results of the steps
+// that have run so far. If the condition is met, the nested step is run and
+// its error is returned. Otherwise the step is skipped and nil is returned.
+func (cs *ConditionalStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
+	reason, met, err := cs.evaluate(state)
+	if err != nil {
+		return err
+	}
+
+	if !met {
+		logger.Info("skipping", lager.Data{"reason": reason})
+
+		cs.skipped = true
+		cs.delegate.Skipped(logger, reason)
+
+		return nil
+	}
+
+	return cs.step.Run(ctx, state)
+}
+
+// Succeeded is true if the step was skipped or if the nested step succeeded.
+func (cs *ConditionalStep) Succeeded() bool {
+	return cs.skipped || cs.step.Succeeded()
+}
+
+func (cs *ConditionalStep) evaluate(state RunState) (string, bool, error) {
+	switch cs.condition.Trigger {
+	case atc.ConditionTriggerManual:
+		if !cs.manuallyTriggered {
+			return "build was not triggered manually", false, nil
+		}
+	case atc.ConditionTriggerAutomatic:
+		if cs.manuallyTriggered {
+			return "build was triggered manually", false, nil
+		}
+	}
+
+	for _, condition := range cs.condition.Versions {
+		info, found := latestVersionInfo(state, condition.Plans)
+		if !found {
+			return fmt.Sprintf("no version of '%s' is available", condition.Name), false, nil
+		}
+
+		matched, err := regexp.MatchString(condition.Match, info.Version[condition.Field])
+		if err != nil {
+			return "", false, err
+		}
+
+		if !matched {
+			return fmt.Sprintf(
+				"version field '%s' of '%s' does not match '%s'",
+				condition.Field,
+				condition.Name,
+				condition.Match,
+			), false, nil
+		}
+	}
+
+	for _, condition := range cs.condition.Steps {
+		succeeded := stepSucceeded(state, condition.Plans)
+
+		if condition.Status == atc.StatusSucceeded && !succeeded {
+			return fmt.Sprintf("step '%s' did not succeed", condition.Name), false, nil
+		}
+
+		if condition.Status == atc.StatusFailed && succeeded {
+			return fmt.Sprintf("step '%s' did not fail", condition.Name), false, nil
+		}
+	}
+
+	return "", true, nil
+}
+
+func latestVersionInfo(state RunState, plans []atc.PlanID) (VersionInfo, bool) {
+	for i := len(plans) - 1; i >= 0; i-- {
+		var info VersionInfo
+		if state.Result(plans[i], &info) {
+			return info, true
+		}
+	}
+
+	return VersionInfo{}, false
+}
+
+// stepSucceeded returns whether any attempt of the step succeeded. A step
+// that has not run, e.g. because it was skipped, has not succeeded, and so
+// counts as failed.
+func stepSucceeded(state RunState, plans []atc.PlanID) bool {
+	for _, id := range plans {
+		var status ExitStatus
+		if state.Result(id, &status) && status == 0 {
+			return true
+		}
+
+		var info VersionInfo
+		if state.Result(id, &info) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,220 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conditional Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep     *execfakes.FakeStep
+		fakeDelegate *execfakes.FakeConditionalDelegate
+
+		state RunState
+
+		condition         atc.Condition
+		manuallyTriggered bool
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeDelegate = new(execfakes.FakeConditionalDelegate)
+
+		state = NewRunState()
+
+		condition = atc.Condition{}
+		manuallyTriggered = false
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = Conditional(condition, manuallyTriggered, fakeStep, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	itRunsTheStep := func() {
+		It("runs the nested step", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+
+		It("does not emit a skipped event", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+	}
+
+	itSkipsTheStep := func(reason string) {
+		It("does not run the nested step", func() {
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+
+		It("emits a skipped event with the reason", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+			_, actualReason := fakeDelegate.SkippedArgsForCall(0)
+			Expect(actualReason).To(Equal(reason))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	}
+
+	Context("when there is no condition", func() {
+		itRunsTheStep()
+
+		Context("when the nested step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when the nested step fails", func() {
+			BeforeEach(func() {
+				fakeStep.SucceededReturns(false)
+			})
+
+			It("does not succeed", func() {
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the condition requires a manual trigger", func() {
+		BeforeEach(func() {
+			condition.Trigger = atc.ConditionTriggerManual
+		})
+
+		Context("when the build was triggered manually", func() {
+			BeforeEach(func() {
+				manuallyTriggered = true
+			})
+
+			itRunsTheStep()
+		})
+
+		Context("when the build was not triggered manually", func() {
+			itSkipsTheStep("build was not triggered manually")
+		})
+	})
+
+	Context("when the condition requires an automatic trigger", func() {
+		BeforeEach(func() {
+			condition.Trigger = atc.ConditionTriggerAutomatic
+		})
+
+		Context("when the build was triggered manually", func() {
+			BeforeEach(func() {
+				manuallyTriggered = true
+			})
+
+			itSkipsTheStep("build was triggered manually")
+		})
+
+		Context("when the build was not triggered manually", func() {
+			itRunsTheStep()
+		})
+	})
+
+	Context("when the condition is on a version field", func() {
+		BeforeEach(func() {
+			condition.Versions = []atc.VersionCondition{
+				{
+					Name:  "some-input",
+					Field: "ref",
+					Match: "^v[0-9]+",
+					Plans: []atc.PlanID{"some-get"},
+				},
+			}
+		})
+
+		Context("when the version matches", func() {
+			BeforeEach(func() {
+				state.StoreResult("some-get", VersionInfo{
+					Version: atc.Version{"ref": "v1.2.3"},
+				})
+			})
+
+			itRunsTheStep()
+		})
+
+		Context("when the version does not match", func() {
+			BeforeEach(func() {
+				state.StoreResult("some-get", VersionInfo{
+					Version: atc.Version{"ref": "abcdef"},
+				})
+			})
+
+			itSkipsTheStep("version field 'ref' of 'some-input' does not match '^v[0-9]+'")
+		})
+
+		Context("when no version has been fetched", func() {
+			itSkipsTheStep("no version of 'some-input' is available")
+		})
+	})
+
+	Context("when the condition is on the result of an earlier step", func() {
+		BeforeEach(func() {
+			condition.Steps = []atc.StepCondition{
+				{
+					Name:   "some-task",
+					Status: atc.StatusFailed,
+					Plans:  []atc.PlanID{"some-attempt", "some-other-attempt"},
+				},
+			}
+		})
+
+		Context("when every attempt of the step failed", func() {
+			BeforeEach(func() {
+				state.StoreResult("some-attempt", ExitStatus(1))
+				state.StoreResult("some-other-attempt", ExitStatus(2))
+			})
+
+			itRunsTheStep()
+		})
+
+		Context("when an attempt of the step succeeded", func() {
+			BeforeEach(func() {
+				state.StoreResult("some-attempt", ExitStatus(1))
+				state.StoreResult("some-other-attempt", ExitStatus(0))
+			})
+
+			itSkipsTheStep("step 'some-task' did not fail")
+		})
+
+		Context("when the step has not run", func() {
+			itRunsTheStep()
+
+			Context("when the condition is on the step succeeding", func() {
+				BeforeEach(func() {
+					condition.Steps[0].Status = atc.StatusSucceeded
+				})
+
+				itSkipsTheStep("step 'some-task' did not succeed")
+			})
+		})
+	})
+})
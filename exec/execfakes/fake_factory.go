@@ -58,6 +58,20 @@ type FakeFactory struct {
 	taskReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	SetPipelineStub        func(lager.Logger, atc.Plan, db.Build, exec.SetPipelineDelegate) exec.Step
+	setPipelineMutex       sync.RWMutex
+	setPipelineArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.SetPipelineDelegate
+	}
+	setPipelineReturns struct {
+		result1 exec.Step
+	}
+	setPipelineReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -220,6 +234,57 @@ func (fake *FakeFactory) TaskReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
+func (fake *FakeFactory) SetPipeline(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.SetPipelineDelegate) exec.Step {
+	fake.setPipelineMutex.Lock()
+	ret, specificReturn := fake.setPipelineReturnsOnCall[len(fake.setPipelineArgsForCall)]
+	fake.setPipelineArgsForCall = append(fake.setPipelineArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.SetPipelineDelegate
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SetPipeline", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setPipelineMutex.Unlock()
+	if fake.SetPipelineStub != nil {
+		return fake.SetPipelineStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setPipelineReturns.result1
+}
+
+func (fake *FakeFactory) SetPipelineCallCount() int {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return len(fake.setPipelineArgsForCall)
+}
+
+func (fake *FakeFactory) SetPipelineArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.SetPipelineDelegate) {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return fake.setPipelineArgsForCall[i].arg1, fake.setPipelineArgsForCall[i].arg2, fake.setPipelineArgsForCall[i].arg3, fake.setPipelineArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) SetPipelineReturns(result1 exec.Step) {
+	fake.SetPipelineStub = nil
+	fake.setPipelineReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) SetPipelineReturnsOnCall(i int, result1 exec.Step) {
+	fake.SetPipelineStub = nil
+	if fake.setPipelineReturnsOnCall == nil {
+		fake.setPipelineReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.setPipelineReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -229,6 +294,8 @@ func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	defer fake.putMutex.RUnlock()
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
@@ -40,6 +41,14 @@ type Factory interface {
 		db.ContainerMetadata,
 		TaskDelegate,
 	) Step
+
+	// SetPipeline constructs a SetPipeline step.
+	SetPipeline(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		SetPipelineDelegate,
+	) Step
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -53,6 +62,8 @@ type StepMetadata interface {
 type BuildStepDelegate interface {
 	ImageVersionDetermined(*db.UsedResourceCache) error
 
+	Variables() creds.Variables
+
 	Stdout() io.Writer
 	Stderr() io.Writer
 
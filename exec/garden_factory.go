@@ -19,7 +19,7 @@ type gardenFactory struct {
 	resourceFetcher        resource.Fetcher
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
-	variablesFactory       creds.VariablesFactory
+	teamFactory            db.TeamFactory
 }
 
 func NewGardenFactory(
@@ -27,14 +27,14 @@ func NewGardenFactory(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
-	variablesFactory creds.VariablesFactory,
+	teamFactory db.TeamFactory,
 ) Factory {
 	return &gardenFactory{
 		workerClient:           workerClient,
 		resourceFetcher:        resourceFetcher,
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
-		variablesFactory:       variablesFactory,
+		teamFactory:            teamFactory,
 	}
 }
 
@@ -48,7 +48,7 @@ func (factory *gardenFactory) Get(
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables()
 
 	getStep := NewGetStep(
 		build,
@@ -60,6 +60,7 @@ func (factory *gardenFactory) Get(
 		creds.NewParams(variables, plan.Get.Params),
 		NewVersionSourceFromPlan(plan.Get),
 		plan.Get.Tags,
+		plan.Get.LabelSelector,
 
 		delegate,
 		factory.resourceFetcher,
@@ -86,7 +87,7 @@ func (factory *gardenFactory) Put(
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables()
 
 	putStep := NewPutStep(
 		build,
@@ -97,6 +98,7 @@ func (factory *gardenFactory) Put(
 		creds.NewSource(variables, plan.Put.Source),
 		creds.NewParams(variables, plan.Put.Params),
 		plan.Put.Tags,
+		plan.Put.LabelSelector,
 
 		delegate,
 		factory.resourceFactory,
@@ -132,6 +134,13 @@ func (factory *gardenFactory) Task(
 		taskConfigSource = FileConfigSource{plan.Task.ConfigPath}
 	}
 
+	if plan.Task.DefaultLimits != nil {
+		taskConfigSource = DefaultLimitsConfigSource{
+			ConfigSource: taskConfigSource,
+			Limits:       *plan.Task.DefaultLimits,
+		}
+	}
+
 	taskConfigSource = ValidatingConfigSource{ConfigSource: taskConfigSource}
 
 	taskConfigSource = DeprecationConfigSource{
@@ -139,14 +148,16 @@ func (factory *gardenFactory) Task(
 		Stderr:   delegate.Stderr(),
 	}
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables()
 
 	taskStep := NewTaskStep(
 		Privileged(plan.Task.Privileged),
 		taskConfigSource,
 		plan.Task.Tags,
+		plan.Task.LabelSelector,
 		plan.Task.InputMapping,
 		plan.Task.OutputMapping,
+		plan.Task.OutputSuffix,
 
 		workingDirectory,
 		plan.Task.ImageArtifactName,
@@ -168,6 +179,22 @@ func (factory *gardenFactory) Task(
 	return LogError(taskStep, delegate)
 }
 
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	delegate SetPipelineDelegate,
+) Step {
+	setPipelineStep := NewSetPipelineStep(
+		*plan.SetPipeline,
+		build,
+		factory.teamFactory,
+		delegate,
+	)
+
+	return LogError(setPipelineStep, delegate)
+}
+
 func (factory *gardenFactory) taskWorkingDirectory(sourceName worker.ArtifactName) string {
 	sum := sha1.Sum([]byte(sourceName))
 	return filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
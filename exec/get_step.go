@@ -35,6 +35,7 @@ type GetStep struct {
 	params        creds.Params
 	versionSource VersionSource
 	tags          atc.Tags
+	labelSelector atc.LabelSelector
 
 	delegate GetDelegate
 
@@ -61,6 +62,7 @@ func NewGetStep(
 	params creds.Params,
 	versionSource VersionSource,
 	tags atc.Tags,
+	labelSelector atc.LabelSelector,
 
 	delegate GetDelegate,
 
@@ -84,6 +86,7 @@ func NewGetStep(
 		params:        params,
 		versionSource: versionSource,
 		tags:          tags,
+		labelSelector: labelSelector,
 
 		delegate: delegate,
 
@@ -171,6 +174,7 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 			Metadata: step.containerMetadata,
 		},
 		step.tags,
+		step.labelSelector,
 		step.teamID,
 		step.resourceTypes,
 		resourceInstance,
@@ -212,10 +216,14 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 
 	step.succeeded = true
 
-	step.delegate.Finished(logger, 0, VersionInfo{
+	versionInfo := VersionInfo{
 		Version:  versionedSource.Version(),
 		Metadata: versionedSource.Metadata(),
-	})
+	}
+
+	state.StoreResult(step.planID, versionInfo)
+
+	step.delegate.Finished(logger, 0, versionInfo)
 
 	return nil
 }
@@ -14,7 +14,6 @@ import (
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
-	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/exec"
@@ -36,7 +35,6 @@ var _ = Describe("GetStep", func() {
 		fakeWorkerClient           *workerfakes.FakeClient
 		fakeResourceFetcher        *resourcefakes.FakeFetcher
 		fakeDBResourceCacheFactory *dbfakes.FakeResourceCacheFactory
-		fakeVariablesFactory       *credsfakes.FakeVariablesFactory
 		variables                  creds.Variables
 		fakeBuild                  *dbfakes.FakeBuild
 		fakeDelegate               *execfakes.FakeGetDelegate
@@ -72,11 +70,9 @@ var _ = Describe("GetStep", func() {
 		fakeWorkerClient = new(workerfakes.FakeClient)
 		fakeDBResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 
-		fakeVariablesFactory = new(credsfakes.FakeVariablesFactory)
 		variables = template.StaticVariables{
 			"source-param": "super-secret-source",
 		}
-		fakeVariablesFactory.NewVariablesReturns(variables)
 
 		artifactRepository = worker.NewArtifactRepository()
 		state = new(execfakes.FakeRunState)
@@ -108,13 +104,15 @@ var _ = Describe("GetStep", func() {
 			Source:                 atc.Source{"some": "((source-param))"},
 			Params:                 atc.Params{"some-param": "some-value"},
 			Tags:                   []string{"some", "tags"},
+			LabelSelector:          atc.LabelSelector{"arch in (arm64)"},
 			Version:                &atc.Version{"some-version": "some-value"},
 			VersionedResourceTypes: resourceTypes,
 		}
 
-		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeVariablesFactory)
+		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, new(dbfakes.FakeTeamFactory))
 
 		fakeDelegate = new(execfakes.FakeGetDelegate)
+		fakeDelegate.VariablesReturns(variables)
 	})
 
 	JustBeforeEach(func() {
@@ -137,7 +135,7 @@ var _ = Describe("GetStep", func() {
 		Expect(stepErr).ToNot(HaveOccurred())
 
 		Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
-		fctx, _, sid, tags, actualTeamID, actualResourceTypes, resourceInstance, sm, delegate := fakeResourceFetcher.FetchArgsForCall(0)
+		fctx, _, sid, tags, labelSelector, actualTeamID, actualResourceTypes, resourceInstance, sm, delegate := fakeResourceFetcher.FetchArgsForCall(0)
 		Expect(fctx).To(Equal(ctx))
 		Expect(sm).To(Equal(stepMetadata))
 		Expect(sid).To(Equal(resource.Session{
@@ -149,6 +147,7 @@ var _ = Describe("GetStep", func() {
 			},
 		}))
 		Expect(tags).To(ConsistOf("some", "tags"))
+		Expect(labelSelector).To(ConsistOf("arch in (arm64)"))
 		Expect(actualTeamID).To(Equal(teamID))
 		Expect(resourceInstance).To(Equal(resource.NewResourceInstance(
 			"some-resource-type",
@@ -192,6 +191,16 @@ var _ = Describe("GetStep", func() {
 			Expect(info.Metadata).To(Equal([]atc.MetadataField{{"some", "metadata"}}))
 		})
 
+		It("stores the version info as the step result", func() {
+			Expect(state.StoreResultCallCount()).To(Equal(1))
+			sID, sVal := state.StoreResultArgsForCall(0)
+			Expect(sID).To(Equal(atc.PlanID(planID)))
+			Expect(sVal).To(Equal(exec.VersionInfo{
+				Version:  atc.Version{"some": "version"},
+				Metadata: []atc.MetadataField{{"some", "metadata"}},
+			}))
+		})
+
 		Context("when getting a pipeline resource", func() {
 			BeforeEach(func() {
 				getPlan.Resource = "some-pipeline-resource"
@@ -0,0 +1,114 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// InParallelStep is a step of steps to run in parallel, optionally bounding
+// how many of them run at once.
+type InParallelStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+}
+
+// InParallel constructs an InParallelStep. A limit of zero or less means all
+// steps are run at once.
+func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
+	if limit <= 0 || limit > len(steps) {
+		limit = len(steps)
+	}
+
+	return InParallelStep{
+		steps:    steps,
+		limit:    limit,
+		failFast: failFast,
+	}
+}
+
+// Run executes the steps in parallel, running at most limit of them at a
+// time. Steps are started in order as earlier ones exit.
+//
+// If failFast is set, the first step to fail or error cancels the context
+// given to its siblings, and no further steps are started. Otherwise it will
+// wait for all steps to exit, even if one step fails or errors.
+//
+// After all started steps finish, their errors (if any) will be aggregated and
+// returned as a single error.
+func (step InParallelStep) Run(ctx context.Context, state RunState) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, step.limit)
+	errs := make(chan error, len(step.steps))
+
+	started := 0
+
+dispatch:
+	for _, s := range step.steps {
+		select {
+		case sem <- struct{}{}:
+		case <-runCtx.Done():
+			break dispatch
+		}
+
+		if runCtx.Err() != nil {
+			<-sem
+			break dispatch
+		}
+
+		started++
+
+		s := s
+		go func() {
+			defer func() { <-sem }()
+
+			err := s.Run(runCtx, state)
+			if step.failFast && (err != nil || !s.Succeeded()) {
+				cancel()
+			}
+
+			errs <- err
+		}()
+	}
+
+	var errorMessages []string
+	for i := 0; i < started; i++ {
+		err := <-errs
+		if err == nil {
+			continue
+		}
+
+		if err == context.Canceled && ctx.Err() == nil {
+			// canceled by a failing sibling; its own error is reported instead
+			continue
+		}
+
+		errorMessages = append(errorMessages, err.Error())
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("one or more parallel steps errored:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// Succeeded is true if all of the steps' Succeeded is true
+func (step InParallelStep) Succeeded() bool {
+	succeeded := true
+
+	for _, step := range step.steps {
+		if !step.Succeeded() {
+			succeeded = false
+		}
+	}
+
+	return succeeded
+}
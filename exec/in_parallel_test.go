@@ -0,0 +1,271 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStepA *execfakes.FakeStep
+		fakeStepB *execfakes.FakeStep
+		fakeStepC *execfakes.FakeStep
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		limit    int
+		failFast bool
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStepA = new(execfakes.FakeStep)
+		fakeStepB = new(execfakes.FakeStep)
+		fakeStepC = new(execfakes.FakeStep)
+
+		fakeStepA.SucceededReturns(true)
+		fakeStepB.SucceededReturns(true)
+		fakeStepC.SucceededReturns(true)
+
+		limit = 0
+		failFast = false
+
+		repo = worker.NewArtifactRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = InParallel([]Step{fakeStepA, fakeStepB, fakeStepC}, limit, failFast)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("runs every step with the run state", func() {
+		Expect(fakeStepA.RunCallCount()).To(Equal(1))
+		_, actualState := fakeStepA.RunArgsForCall(0)
+		Expect(actualState).To(Equal(state))
+
+		Expect(fakeStepB.RunCallCount()).To(Equal(1))
+		_, actualState = fakeStepB.RunArgsForCall(0)
+		Expect(actualState).To(Equal(state))
+
+		Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		_, actualState = fakeStepC.RunArgsForCall(0)
+		Expect(actualState).To(Equal(state))
+	})
+
+	Context("when there is no limit", func() {
+		BeforeEach(func() {
+			wg := new(sync.WaitGroup)
+			wg.Add(3)
+
+			stub := func(context.Context, RunState) error {
+				wg.Done()
+				wg.Wait()
+				return nil
+			}
+
+			fakeStepA.RunStub = stub
+			fakeStepB.RunStub = stub
+			fakeStepC.RunStub = stub
+		})
+
+		It("runs every step concurrently", func() {
+			Expect(fakeStepA.RunCallCount()).To(Equal(1))
+			Expect(fakeStepB.RunCallCount()).To(Equal(1))
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when there is a limit", func() {
+		var (
+			lock       sync.Mutex
+			running    int
+			maxRunning int
+		)
+
+		BeforeEach(func() {
+			limit = 2
+
+			running = 0
+			maxRunning = 0
+
+			stub := func(context.Context, RunState) error {
+				lock.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+
+				lock.Lock()
+				running--
+				lock.Unlock()
+
+				return nil
+			}
+
+			fakeStepA.RunStub = stub
+			fakeStepB.RunStub = stub
+			fakeStepC.RunStub = stub
+		})
+
+		It("never runs more than the limit at once", func() {
+			Expect(maxRunning).To(BeNumerically("<=", 2))
+		})
+
+		It("eventually runs every step", func() {
+			Expect(fakeStepA.RunCallCount()).To(Equal(1))
+			Expect(fakeStepB.RunCallCount()).To(Equal(1))
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the limit is one", func() {
+		var order chan string
+
+		BeforeEach(func() {
+			limit = 1
+			order = make(chan string, 3)
+
+			fakeStepA.RunStub = func(context.Context, RunState) error {
+				order <- "a"
+				return nil
+			}
+
+			fakeStepB.RunStub = func(context.Context, RunState) error {
+				order <- "b"
+				return nil
+			}
+
+			fakeStepC.RunStub = func(context.Context, RunState) error {
+				order <- "c"
+				return nil
+			}
+		})
+
+		It("runs the steps in order", func() {
+			Expect(<-order).To(Equal("a"))
+			Expect(<-order).To(Equal("b"))
+			Expect(<-order).To(Equal("c"))
+		})
+	})
+
+	Context("when steps error", func() {
+		BeforeEach(func() {
+			fakeStepA.RunReturns(errors.New("nope A"))
+			fakeStepC.RunReturns(errors.New("nope C"))
+		})
+
+		It("runs every step", func() {
+			Expect(fakeStepA.RunCallCount()).To(Equal(1))
+			Expect(fakeStepB.RunCallCount()).To(Equal(1))
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+
+		It("exits with an error including the original messages", func() {
+			Expect(stepErr.Error()).To(ContainSubstring("nope A"))
+			Expect(stepErr.Error()).To(ContainSubstring("nope C"))
+		})
+	})
+
+	Context("when failing fast", func() {
+		BeforeEach(func() {
+			failFast = true
+			limit = 1
+		})
+
+		Context("when a step fails", func() {
+			BeforeEach(func() {
+				fakeStepA.SucceededReturns(false)
+			})
+
+			It("does not start the remaining steps", func() {
+				Expect(fakeStepA.RunCallCount()).To(Equal(1))
+				Expect(fakeStepB.RunCallCount()).To(BeZero())
+				Expect(fakeStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("does not error", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+			})
+
+			It("does not succeed", func() {
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when a step errors", func() {
+			BeforeEach(func() {
+				fakeStepA.RunReturns(errors.New("nope A"))
+			})
+
+			It("does not start the remaining steps", func() {
+				Expect(fakeStepB.RunCallCount()).To(BeZero())
+				Expect(fakeStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr.Error()).To(ContainSubstring("nope A"))
+			})
+		})
+
+		Context("when a running sibling is interrupted", func() {
+			BeforeEach(func() {
+				limit = 0
+
+				fakeStepA.RunStub = func(ctx context.Context, _ RunState) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+
+				fakeStepB.RunReturns(errors.New("nope B"))
+			})
+
+			It("cancels the sibling", func() {
+				ctx, _ := fakeStepA.RunArgsForCall(0)
+				Expect(ctx.Err()).To(Equal(context.Canceled))
+			})
+
+			It("only reports the error of the failing step", func() {
+				Expect(stepErr.Error()).To(ContainSubstring("nope B"))
+				Expect(stepErr.Error()).ToNot(ContainSubstring(context.Canceled.Error()))
+			})
+		})
+	})
+
+	Describe("canceling", func() {
+		BeforeEach(func() {
+			cancel()
+		})
+
+		It("returns ctx.Err()", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+		})
+	})
+})
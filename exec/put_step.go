@@ -25,11 +25,12 @@ type PutDelegate interface {
 type PutStep struct {
 	build db.Build
 
-	name         string
-	resourceType string
-	source       creds.Source
-	params       creds.Params
-	tags         atc.Tags
+	name          string
+	resourceType  string
+	source        creds.Source
+	params        creds.Params
+	tags          atc.Tags
+	labelSelector atc.LabelSelector
 
 	resource string
 
@@ -53,6 +54,7 @@ func NewPutStep(
 	source creds.Source,
 	params creds.Params,
 	tags atc.Tags,
+	labelSelector atc.LabelSelector,
 	delegate PutDelegate,
 	resourceFactory resource.ResourceFactory,
 	planID atc.PlanID,
@@ -69,6 +71,7 @@ func NewPutStep(
 		source:            source,
 		params:            params,
 		tags:              tags,
+		labelSelector:     labelSelector,
 		delegate:          delegate,
 		resourceFactory:   resourceFactory,
 		planID:            planID,
@@ -93,8 +96,9 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		ImageSpec: worker.ImageSpec{
 			ResourceType: step.resourceType,
 		},
-		Tags:   step.tags,
-		TeamID: step.build.TeamID(),
+		Tags:          step.tags,
+		LabelSelector: step.labelSelector,
+		TeamID:        step.build.TeamID(),
 
 		Dir: resource.ResourcesDir("put"),
 
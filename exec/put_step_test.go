@@ -107,6 +107,7 @@ var _ = Describe("PutStep", func() {
 			creds.NewSource(variables, atc.Source{"some": "((source-param))"}),
 			creds.NewParams(variables, atc.Params{"some-param": "some-value"}),
 			[]string{"some", "tags"},
+			atc.LabelSelector{"arch in (arm64)"},
 			fakeDelegate,
 			fakeResourceFactory,
 			planID,
@@ -162,6 +163,7 @@ var _ = Describe("PutStep", func() {
 					ResourceType: "some-resource-type",
 				}))
 				Expect(containerSpec.Tags).To(Equal([]string{"some", "tags"}))
+				Expect(containerSpec.LabelSelector).To(Equal(atc.LabelSelector{"arch in (arm64)"}))
 				Expect(containerSpec.TeamID).To(Equal(123))
 				Expect(containerSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 				Expect(containerSpec.Dir).To(Equal("/tmp/build/put"))
@@ -0,0 +1,249 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . SetPipelineDelegate
+
+type SetPipelineDelegate interface {
+	BuildStepDelegate
+
+	SetPipelineChanged(lager.Logger, string, bool, []atc.ConfigChange)
+}
+
+// SetPipelineStep configures a pipeline within the build's team from a config
+// file found in the worker.ArtifactRepository, as if it were set via the API.
+type SetPipelineStep struct {
+	plan        atc.SetPipelinePlan
+	build       db.Build
+	teamFactory db.TeamFactory
+	delegate    SetPipelineDelegate
+
+	succeeded bool
+}
+
+func NewSetPipelineStep(
+	plan atc.SetPipelinePlan,
+	build db.Build,
+	teamFactory db.TeamFactory,
+	delegate SetPipelineDelegate,
+) *SetPipelineStep {
+	return &SetPipelineStep{
+		plan:        plan,
+		build:       build,
+		teamFactory: teamFactory,
+		delegate:    delegate,
+	}
+}
+
+// Run reads and validates the pipeline config, and saves it if it differs
+// from the pipeline's current config.
+//
+// If the config is invalid, the errors are written to stderr and the step
+// fails. A newly created pipeline is left paused.
+func (step *SetPipelineStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).Session("set-pipeline", lager.Data{
+		"pipeline": step.plan.Name,
+	})
+
+	config, err := step.fetchConfig(state.Artifacts())
+	if err != nil {
+		return err
+	}
+
+	warnings, errorMessages := config.Validate()
+	for _, warning := range warnings {
+		fmt.Fprintf(step.delegate.Stderr(), "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintln(step.delegate.Stderr(), "invalid pipeline config:")
+		for _, message := range errorMessages {
+			fmt.Fprintf(step.delegate.Stderr(), "  - %s\n", message)
+		}
+
+		return nil
+	}
+
+	team, found, err := step.teamFactory.FindTeam(step.build.TeamName())
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("team '%s' not found", step.build.TeamName())
+	}
+
+	pipeline, found, err := team.Pipeline(step.plan.Name)
+	if err != nil {
+		return err
+	}
+
+	var fromVersion db.ConfigVersion
+	changes := atc.DiffConfigs(atc.Config{}, config)
+
+	if found {
+		currentConfig, err := currentPipelineConfig(pipeline)
+		if err != nil {
+			return err
+		}
+
+		// the saved config has been through JSON, which e.g. turns numbers
+		// into float64s, so the new config must be too for them to compare
+		newConfig, err := jsonRoundTrip(config)
+		if err != nil {
+			return err
+		}
+
+		fromVersion = pipeline.ConfigVersion()
+		changes = atc.DiffConfigs(currentConfig, newConfig)
+
+		if len(changes) == 0 {
+			fmt.Fprintf(step.delegate.Stdout(), "no changes to pipeline '%s'\n", step.plan.Name)
+			step.succeeded = true
+			return nil
+		}
+	}
+
+	_, created, err := team.SavePipeline(step.plan.Name, config, fromVersion, db.PipelineNoChange, step.author())
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Fprintf(step.delegate.Stdout(), "created pipeline '%s'\n", step.plan.Name)
+	} else {
+		fmt.Fprintf(step.delegate.Stdout(), "configured pipeline '%s'\n", step.plan.Name)
+	}
+
+	for _, change := range changes {
+		fmt.Fprintf(step.delegate.Stdout(), "  %s %s '%s'\n", change.Type, strings.TrimSuffix(change.Section, "s"), change.Name)
+	}
+
+	step.delegate.SetPipelineChanged(logger, step.plan.Name, created, changes)
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Succeeded returns true if the pipeline config was valid and saved, or was
+// already up to date.
+func (step *SetPipelineStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *SetPipelineStep) fetchConfig(repo *worker.ArtifactRepository) (atc.Config, error) {
+	segs := strings.SplitN(step.plan.File, "/", 2)
+	if len(segs) != 2 {
+		return atc.Config{}, UnspecifiedArtifactSourceError{step.plan.File}
+	}
+
+	sourceName := worker.ArtifactName(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return atc.Config{}, UnknownArtifactSourceError{sourceName}
+	}
+
+	stream, err := source.StreamFile(filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return atc.Config{}, fmt.Errorf("pipeline config '%s/%s' not found", sourceName, filePath)
+		}
+		return atc.Config{}, err
+	}
+
+	defer stream.Close()
+
+	streamedFile, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var configStructure interface{}
+	err = yaml.Unmarshal(streamedFile, &configStructure)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to load %s: %s", step.plan.File, err)
+	}
+
+	config, err := atc.DecodeConfig(configStructure)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to load %s: %s", step.plan.File, err)
+	}
+
+	return config, nil
+}
+
+func (step *SetPipelineStep) author() string {
+	if step.build.JobName() != "" {
+		return fmt.Sprintf("%s/%s #%s", step.build.PipelineName(), step.build.JobName(), step.build.Name())
+	}
+
+	return fmt.Sprintf("build #%d", step.build.ID())
+}
+
+func jsonRoundTrip(config atc.Config) (atc.Config, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var roundTripped atc.Config
+	err = json.Unmarshal(payload, &roundTripped)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return roundTripped, nil
+}
+
+// currentPipelineConfig prefers the config recorded in the pipeline's
+// history, falling back to reconstructing it for pipelines that were last
+// saved before history was kept.
+func currentPipelineConfig(pipeline db.Pipeline) (atc.Config, error) {
+	saved, found, err := pipeline.ConfigAtVersion(pipeline.ConfigVersion())
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	if found {
+		return saved.Config, nil
+	}
+
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resources, err := pipeline.Resources()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return atc.Config{
+		Groups:        pipeline.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+	}, nil
+}
@@ -0,0 +1,343 @@
+package exec_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeBuild          *dbfakes.FakeBuild
+		fakeTeamFactory    *dbfakes.FakeTeamFactory
+		fakeTeam           *dbfakes.FakeTeam
+		fakePipeline       *dbfakes.FakePipeline
+		fakeDelegate       *execfakes.FakeSetPipelineDelegate
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		plan   atc.SetPipelinePlan
+		config atc.Config
+
+		state RunState
+
+		step    *SetPipelineStep
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("3")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeSetPipelineDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Task: "some-task", TaskConfigPath: "some/task.yml"},
+					},
+				},
+			},
+		}
+
+		plan = atc.SetPipelinePlan{
+			Name: "other-pipeline",
+			File: "some-artifact/pipeline.yml",
+		}
+
+		state = NewRunState()
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileStub = func(string) (io.ReadCloser, error) {
+			payload, err := yaml.Marshal(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			return gbytes.BufferWithBytes(payload), nil
+		}
+
+		state.Artifacts().RegisterSource("some-artifact", fakeArtifactSource)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = NewSetPipelineStep(plan, fakeBuild, fakeTeamFactory, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("reads the config from the artifact", func() {
+		Expect(fakeArtifactSource.StreamFileArgsForCall(0)).To(Equal("pipeline.yml"))
+	})
+
+	It("looks up the build's team", func() {
+		Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+		Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal("other-pipeline"))
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(nil, false, nil)
+			fakeTeam.SavePipelineReturns(fakePipeline, true, nil)
+		})
+
+		It("creates it, authored by the build", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+			name, savedConfig, from, pausedState, author := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(name).To(Equal("other-pipeline"))
+			Expect(savedConfig).To(Equal(config))
+			Expect(from).To(Equal(db.ConfigVersion(0)))
+			Expect(pausedState).To(Equal(db.PipelineNoChange))
+			Expect(author).To(Equal("some-pipeline/some-job #3"))
+		})
+
+		It("emits the changes", func() {
+			Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+
+			_, name, created, changes := fakeDelegate.SetPipelineChangedArgsForCall(0)
+			Expect(name).To(Equal("other-pipeline"))
+			Expect(created).To(BeTrue())
+			Expect(changes).To(Equal(atc.DiffConfigs(atc.Config{}, config)))
+		})
+
+		It("prints a summary", func() {
+			Expect(stdout).To(gbytes.Say("created pipeline 'other-pipeline'"))
+			Expect(stdout).To(gbytes.Say("added job 'some-job'"))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).NotTo(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		Context("when the build is a one-off", func() {
+			BeforeEach(func() {
+				fakeBuild.JobNameReturns("")
+				fakeBuild.PipelineNameReturns("")
+			})
+
+			It("is authored by the build id", func() {
+				_, _, _, _, author := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(author).To(Equal("build #42"))
+			})
+		})
+
+		Context("when saving fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTeam.SavePipelineReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+
+			It("does not emit any changes", func() {
+				Expect(fakeDelegate.SetPipelineChangedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the pipeline exists", func() {
+		var currentConfig atc.Config
+
+		BeforeEach(func() {
+			currentConfig = config
+
+			fakePipeline.ConfigVersionReturns(7)
+			fakeTeam.PipelineReturns(fakePipeline, true, nil)
+			fakeTeam.SavePipelineReturns(fakePipeline, false, nil)
+		})
+
+		JustBeforeEach(func() {
+			Expect(fakePipeline.ConfigAtVersionArgsForCall(0)).To(Equal(db.ConfigVersion(7)))
+		})
+
+		Context("when its saved config is the same", func() {
+			BeforeEach(func() {
+				fakePipeline.ConfigAtVersionReturns(db.PipelineConfig{Config: currentConfig}, true, nil)
+			})
+
+			It("does not save it", func() {
+				Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+				Expect(fakeDelegate.SetPipelineChangedCallCount()).To(BeZero())
+			})
+
+			It("says so and succeeds", func() {
+				Expect(stdout).To(gbytes.Say("no changes to pipeline 'other-pipeline'"))
+				Expect(stepErr).NotTo(HaveOccurred())
+				Expect(step.Succeeded()).To(BeTrue())
+			})
+		})
+
+		Context("when the same config with numbers and empty lists was saved before", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Plan[0].Params = atc.Params{"count": 3, "ratio": 0.5}
+				config.Jobs[0].Plan[0].Tags = atc.Tags{}
+
+				payload, err := json.Marshal(config)
+				Expect(err).NotTo(HaveOccurred())
+
+				var savedConfig atc.Config
+				err = json.Unmarshal(payload, &savedConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakePipeline.ConfigAtVersionReturns(db.PipelineConfig{Config: savedConfig}, true, nil)
+			})
+
+			It("does not save it again", func() {
+				Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+				Expect(stdout).To(gbytes.Say("no changes to pipeline 'other-pipeline'"))
+			})
+		})
+
+		Context("when its saved config differs", func() {
+			BeforeEach(func() {
+				currentConfig = atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "some-job", Public: true},
+					},
+				}
+
+				fakePipeline.ConfigAtVersionReturns(db.PipelineConfig{Config: currentConfig}, true, nil)
+			})
+
+			It("saves it from the current version", func() {
+				Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+				_, _, from, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(from).To(Equal(db.ConfigVersion(7)))
+			})
+
+			It("emits the changes", func() {
+				_, _, created, changes := fakeDelegate.SetPipelineChangedArgsForCall(0)
+				Expect(created).To(BeFalse())
+				Expect(changes).To(Equal(atc.DiffConfigs(currentConfig, config)))
+			})
+
+			It("prints a summary", func() {
+				Expect(stdout).To(gbytes.Say("configured pipeline 'other-pipeline'"))
+				Expect(stdout).To(gbytes.Say("changed job 'some-job'"))
+			})
+		})
+
+		Context("when it has no saved config", func() {
+			var fakeJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakePipeline.ConfigAtVersionReturns(db.PipelineConfig{}, false, nil)
+
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.ConfigReturns(config.Jobs[0])
+				fakePipeline.JobsReturns(db.Jobs{fakeJob}, nil)
+			})
+
+			It("compares against its current jobs and resources", func() {
+				Expect(fakePipeline.JobsCallCount()).To(Equal(1))
+				Expect(fakePipeline.ResourcesCallCount()).To(Equal(1))
+				Expect(fakePipeline.ResourceTypesCallCount()).To(Equal(1))
+
+				Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			config.Jobs = append(config.Jobs, config.Jobs[0])
+		})
+
+		It("prints the errors and fails", func() {
+			Expect(stderr).To(gbytes.Say("invalid pipeline config:"))
+			Expect(stderr).To(gbytes.Say("have the same name \\('some-job'\\)"))
+			Expect(stepErr).NotTo(HaveOccurred())
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("does not save it", func() {
+			Expect(fakeTeamFactory.FindTeamCallCount()).To(BeZero())
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the file path does not indicate an artifact", func() {
+		BeforeEach(func() {
+			plan.File = "pipeline.yml"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(UnspecifiedArtifactSourceError{"pipeline.yml"}))
+		})
+	})
+
+	Context("when the artifact does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "bogus/pipeline.yml"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(UnknownArtifactSourceError{"bogus"}))
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileStub = nil
+			fakeArtifactSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(MatchError("pipeline config 'some-artifact/pipeline.yml' not found"))
+		})
+	})
+
+	Context("when the team cannot be found", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(MatchError("team 'some-team' not found"))
+		})
+	})
+})
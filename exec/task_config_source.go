@@ -213,6 +213,35 @@ func (configSource ValidatingConfigSource) Warnings() []string {
 	return configSource.ConfigSource.Warnings()
 }
 
+// DefaultLimitsConfigSource delegates to another ConfigSource, and fills in any
+// container limits not set by its task config.
+type DefaultLimitsConfigSource struct {
+	ConfigSource TaskConfigSource
+	Limits       atc.ContainerLimits
+}
+
+// FetchConfig fetches the config using the underlying ConfigSource, and
+// applies the default limits beneath the config's own limits.
+func (configSource DefaultLimitsConfigSource) FetchConfig(source *worker.ArtifactRepository) (atc.TaskConfig, error) {
+	config, err := configSource.ConfigSource.FetchConfig(source)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	limits := configSource.Limits
+	if config.Limits != nil {
+		limits = limits.Merge(*config.Limits)
+	}
+
+	config.Limits = &limits
+
+	return config, nil
+}
+
+func (configSource DefaultLimitsConfigSource) Warnings() []string {
+	return configSource.ConfigSource.Warnings()
+}
+
 // UnknownArtifactSourceError is returned when the worker.ArtifactName specified by the
 // path does not exist in the worker.ArtifactRepository.
 type UnknownArtifactSourceError struct {
@@ -539,4 +539,76 @@ run: {path: a/file}
 			})
 		})
 	})
+
+	Describe("DefaultLimitsConfigSource", func() {
+		var (
+			fakeConfigSource *execfakes.FakeTaskConfigSource
+
+			cpu          uint64
+			memory       uint64
+			configMemory uint64
+
+			configSource TaskConfigSource
+
+			fetchedConfig atc.TaskConfig
+			fetchErr      error
+		)
+
+		BeforeEach(func() {
+			fakeConfigSource = new(execfakes.FakeTaskConfigSource)
+
+			cpu = 512
+			memory = 1024 * 1024 * 1024
+			configMemory = 2 * 1024 * 1024 * 1024
+
+			configSource = DefaultLimitsConfigSource{
+				ConfigSource: fakeConfigSource,
+				Limits:       atc.ContainerLimits{CPU: &cpu, Memory: &memory},
+			}
+		})
+
+		JustBeforeEach(func() {
+			fetchedConfig, fetchErr = configSource.FetchConfig(repo)
+		})
+
+		Context("when the config has no limits", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{Platform: "some-platform"}, nil)
+			})
+
+			It("uses the defaults", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig).To(Equal(atc.TaskConfig{
+					Platform: "some-platform",
+					Limits:   &atc.ContainerLimits{CPU: &cpu, Memory: &memory},
+				}))
+			})
+		})
+
+		Context("when the config has its own limits", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{
+					Platform: "some-platform",
+					Limits:   &atc.ContainerLimits{Memory: &configMemory},
+				}, nil)
+			})
+
+			It("prefers them over the defaults", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig.Limits).To(Equal(&atc.ContainerLimits{CPU: &cpu, Memory: &configMemory}))
+			})
+		})
+
+		Context("when fetching the config fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(fetchErr).To(Equal(disaster))
+			})
+		})
+	})
 })
@@ -70,8 +70,10 @@ type TaskStep struct {
 	privileged    Privileged
 	configSource  TaskConfigSource
 	tags          atc.Tags
+	labelSelector atc.LabelSelector
 	inputMapping  map[string]string
 	outputMapping map[string]string
+	outputSuffix  string
 
 	artifactsRoot     string
 	imageArtifactName string
@@ -97,8 +99,10 @@ func NewTaskStep(
 	privileged Privileged,
 	configSource TaskConfigSource,
 	tags atc.Tags,
+	labelSelector atc.LabelSelector,
 	inputMapping map[string]string,
 	outputMapping map[string]string,
+	outputSuffix string,
 	artifactsRoot string,
 	imageArtifactName string,
 	delegate TaskDelegate,
@@ -116,8 +120,10 @@ func NewTaskStep(
 		privileged:        privileged,
 		configSource:      configSource,
 		tags:              tags,
+		labelSelector:     labelSelector,
 		inputMapping:      inputMapping,
 		outputMapping:     outputMapping,
+		outputSuffix:      outputSuffix,
 		artifactsRoot:     artifactsRoot,
 		imageArtifactName: imageArtifactName,
 		delegate:          delegate,
@@ -189,6 +195,8 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 		action.succeeded = status == 0
 
+		state.StoreResult(action.planID, ExitStatus(status))
+
 		err = action.registerOutputs(logger, repository, config, container)
 		if err != nil {
 			return err
@@ -281,6 +289,8 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 		action.succeeded = processStatus == 0
 
+		state.StoreResult(action.planID, ExitStatus(processStatus))
+
 		return nil
 	}
 }
@@ -320,18 +330,23 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 	}
 
 	containerSpec := worker.ContainerSpec{
-		Platform:  config.Platform,
-		Tags:      action.tags,
-		TeamID:    action.teamID,
-		ImageSpec: imageSpec,
-		User:      config.Run.User,
-		Dir:       action.artifactsRoot,
-		Env:       action.envForParams(params),
+		Platform:      config.Platform,
+		Tags:          action.tags,
+		LabelSelector: action.labelSelector,
+		TeamID:        action.teamID,
+		ImageSpec:     imageSpec,
+		User:          config.Run.User,
+		Dir:           action.artifactsRoot,
+		Env:           action.envForParams(params),
 
 		Inputs:  []worker.InputSource{},
 		Outputs: worker.OutputPaths{},
 	}
 
+	if config.Limits != nil {
+		containerSpec.Limits = *config.Limits
+	}
+
 	var missingRequiredInputs []string
 	for _, input := range config.Inputs {
 		inputName := input.Name
@@ -386,6 +401,8 @@ func (action *TaskStep) registerOutputs(logger lager.Logger, repository *worker.
 			outputName = destinationName
 		}
 
+		outputName += action.outputSuffix
+
 		outputPath := artifactsPath(output, action.artifactsRoot)
 
 		for _, mount := range volumeMounts {
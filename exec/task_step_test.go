@@ -44,6 +44,7 @@ var _ = Describe("TaskStep", func() {
 
 		privileged    exec.Privileged
 		tags          []string
+		labelSelector atc.LabelSelector
 		teamID        int
 		buildID       int
 		planID        atc.PlanID
@@ -52,6 +53,7 @@ var _ = Describe("TaskStep", func() {
 		resourceTypes creds.VersionedResourceTypes
 		inputMapping  map[string]string
 		outputMapping map[string]string
+		outputSuffix  string
 		variables     creds.Variables
 
 		repo  *worker.ArtifactRepository
@@ -77,6 +79,7 @@ var _ = Describe("TaskStep", func() {
 
 		privileged = false
 		tags = []string{"step", "tags"}
+		labelSelector = atc.LabelSelector{"arch in (arm64)"}
 		teamID = 123
 		planID = atc.PlanID(42)
 		buildID = 1234
@@ -101,6 +104,7 @@ var _ = Describe("TaskStep", func() {
 
 		inputMapping = nil
 		outputMapping = nil
+		outputSuffix = ""
 		imageArtifactName = ""
 
 		variables = template.StaticVariables{
@@ -121,8 +125,10 @@ var _ = Describe("TaskStep", func() {
 			privileged,
 			configSource,
 			tags,
+			labelSelector,
 			inputMapping,
 			outputMapping,
+			outputSuffix,
 			"some-artifact-root",
 			imageArtifactName,
 			fakeDelegate,
@@ -201,9 +207,10 @@ var _ = Describe("TaskStep", func() {
 				Expect(delegate).To(Equal(fakeDelegate))
 
 				Expect(spec).To(Equal(worker.ContainerSpec{
-					Platform: "some-platform",
-					Tags:     []string{"step", "tags"},
-					TeamID:   teamID,
+					Platform:      "some-platform",
+					Tags:          []string{"step", "tags"},
+					LabelSelector: atc.LabelSelector{"arch in (arm64)"},
+					TeamID:        teamID,
 					ImageSpec: worker.ImageSpec{
 						ImageResource: &worker.ImageResource{
 							Type:    "docker",
@@ -249,9 +256,10 @@ var _ = Describe("TaskStep", func() {
 					Expect(delegate).To(Equal(fakeDelegate))
 
 					Expect(spec).To(Equal(worker.ContainerSpec{
-						Platform: "some-platform",
-						Tags:     []string{"step", "tags"},
-						TeamID:   teamID,
+						Platform:      "some-platform",
+						Tags:          []string{"step", "tags"},
+						LabelSelector: atc.LabelSelector{"arch in (arm64)"},
+						TeamID:        teamID,
 						ImageSpec: worker.ImageSpec{
 							ImageURL:   "some-image",
 							Privileged: false,
@@ -266,6 +274,23 @@ var _ = Describe("TaskStep", func() {
 				})
 			})
 
+			Context("when the config has container limits", func() {
+				var cpu, memory uint64
+
+				BeforeEach(func() {
+					cpu = 512
+					memory = 1024 * 1024 * 1024
+
+					fetchedConfig.Limits = &atc.ContainerLimits{CPU: &cpu, Memory: &memory}
+					configSource.FetchConfigReturns(fetchedConfig, nil)
+				})
+
+				It("creates the container with the limits", func() {
+					_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+					Expect(spec.Limits).To(Equal(atc.ContainerLimits{CPU: &cpu, Memory: &memory}))
+				})
+			})
+
 			Context("when an exit status is already saved off", func() {
 				BeforeEach(func() {
 					fakeContainer.PropertyStub = func(name string) (string, error) {
@@ -766,6 +791,13 @@ var _ = Describe("TaskStep", func() {
 							Expect(status).To(Equal(exec.ExitStatus(0)))
 						})
 
+						It("stores the exit status as the step result", func() {
+							Expect(state.StoreResultCallCount()).To(Equal(1))
+							sID, sVal := state.StoreResultArgsForCall(0)
+							Expect(sID).To(Equal(planID))
+							Expect(sVal).To(Equal(exec.ExitStatus(0)))
+						})
+
 						Describe("the registered sources", func() {
 							var (
 								artifactSource1 worker.ArtifactSource
@@ -1144,6 +1176,49 @@ var _ = Describe("TaskStep", func() {
 					})
 				})
 
+				Context("when outputs have a suffix", func() {
+					var (
+						fakeMountPath string = "some-artifact-root/generic-remapped-output/"
+					)
+
+					BeforeEach(func() {
+						outputMapping = map[string]string{"generic-remapped-output": "specific-remapped-output"}
+						outputSuffix = "-1.9-linux"
+						configSource.FetchConfigReturns(atc.TaskConfig{
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "generic-remapped-output"},
+							},
+						}, nil)
+
+						fakeProcess.WaitReturns(0, nil)
+
+						fakeVolume := new(workerfakes.FakeVolume)
+						fakeVolume.HandleReturns("some-handle")
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							worker.VolumeMount{
+								Volume:    fakeVolume,
+								MountPath: fakeMountPath,
+							},
+						})
+					})
+
+					JustBeforeEach(func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+					})
+
+					It("registers the outputs as sources with the suffix appended", func() {
+						artifactSource, found := repo.SourceFor("specific-remapped-output-1.9-linux")
+						Expect(found).To(BeTrue())
+
+						sourceMap := repo.AsMap()
+						Expect(sourceMap).To(ConsistOf(artifactSource))
+					})
+				})
+
 				Context("when an image artifact name is specified", func() {
 					BeforeEach(func() {
 						imageArtifactName = "some-image-artifact"
@@ -0,0 +1,50 @@
+package gc
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type auditEventCollector struct {
+	logger            lager.Logger
+	auditEventFactory auditEventFactory
+	retention         time.Duration
+}
+
+type auditEventFactory interface {
+	DeleteAuditEventsOlderThan(time.Duration) (int, error)
+}
+
+func NewAuditEventCollector(
+	logger lager.Logger,
+	auditEventFactory auditEventFactory,
+	retention time.Duration,
+) *auditEventCollector {
+	return &auditEventCollector{
+		logger:            logger,
+		auditEventFactory: auditEventFactory,
+		retention:         retention,
+	}
+}
+
+func (c *auditEventCollector) Run() error {
+	if c.retention == 0 {
+		return nil
+	}
+
+	c.logger.Debug("start")
+	defer c.logger.Debug("done")
+
+	deleted, err := c.auditEventFactory.DeleteAuditEventsOlderThan(c.retention)
+	if err != nil {
+		c.logger.Error("failed-to-delete-audit-events", err)
+		return err
+	}
+
+	if deleted > 0 {
+		c.logger.Debug("deleted-audit-events", lager.Data{"count": deleted})
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package gc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventCollector", func() {
+	var (
+		collector             gc.Collector
+		fakeAuditEventFactory *dbfakes.FakeAuditEventFactory
+		retention             time.Duration
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+		retention = 24 * time.Hour
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewAuditEventCollector(
+			lagertest.NewTestLogger("test"),
+			fakeAuditEventFactory,
+			retention,
+		)
+
+		err = collector.Run()
+	})
+
+	It("deletes audit events older than the retention period", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeAuditEventFactory.DeleteAuditEventsOlderThanCallCount()).To(Equal(1))
+		Expect(fakeAuditEventFactory.DeleteAuditEventsOlderThanArgsForCall(0)).To(Equal(24 * time.Hour))
+	})
+
+	Context("when deleting fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeAuditEventFactory.DeleteAuditEventsOlderThanReturns(0, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+
+	Context("when there is no retention period", func() {
+		BeforeEach(func() {
+			retention = 0
+		})
+
+		It("keeps every audit event", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeAuditEventFactory.DeleteAuditEventsOlderThanCallCount()).To(Equal(0))
+		})
+	})
+})
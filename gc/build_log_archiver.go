@@ -0,0 +1,47 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type buildLogArchiver struct {
+	logger       lager.Logger
+	buildFactory db.BuildFactory
+	batchSize    int
+}
+
+func NewBuildLogArchiver(
+	logger lager.Logger,
+	buildFactory db.BuildFactory,
+	batchSize int,
+) Collector {
+	return &buildLogArchiver{
+		logger:       logger,
+		buildFactory: buildFactory,
+		batchSize:    batchSize,
+	}
+}
+
+func (a *buildLogArchiver) Run() error {
+	a.logger.Debug("start")
+	defer a.logger.Debug("done")
+
+	builds, err := a.buildFactory.GetCompletedBuildsToArchive(a.batchSize)
+	if err != nil {
+		a.logger.Error("failed-to-get-builds-to-archive", err)
+		return err
+	}
+
+	for _, build := range builds {
+		err := build.ArchiveEvents()
+		if err != nil {
+			a.logger.Error("failed-to-archive-build-events", err, lager.Data{"build": build.ID()})
+			continue
+		}
+
+		a.logger.Debug("archived-build-events", lager.Data{"build": build.ID()})
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildLogArchiver", func() {
+	var (
+		archiver         gc.Collector
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+
+		build1 *dbfakes.FakeBuild
+		build2 *dbfakes.FakeBuild
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+
+		build1 = new(dbfakes.FakeBuild)
+		build1.IDReturns(1)
+		build2 = new(dbfakes.FakeBuild)
+		build2.IDReturns(2)
+
+		fakeBuildFactory.GetCompletedBuildsToArchiveReturns([]db.Build{build1, build2}, nil)
+	})
+
+	JustBeforeEach(func() {
+		archiver = gc.NewBuildLogArchiver(
+			lagertest.NewTestLogger("test"),
+			fakeBuildFactory,
+			42,
+		)
+
+		err = archiver.Run()
+	})
+
+	It("archives the events of a batch of completed builds", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeBuildFactory.GetCompletedBuildsToArchiveCallCount()).To(Equal(1))
+		Expect(fakeBuildFactory.GetCompletedBuildsToArchiveArgsForCall(0)).To(Equal(42))
+
+		Expect(build1.ArchiveEventsCallCount()).To(Equal(1))
+		Expect(build2.ArchiveEventsCallCount()).To(Equal(1))
+	})
+
+	Context("when archiving a build fails", func() {
+		BeforeEach(func() {
+			build1.ArchiveEventsReturns(errors.New("nope"))
+		})
+
+		It("keeps archiving the other builds", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build2.ArchiveEventsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the builds fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeBuildFactory.GetCompletedBuildsToArchiveReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
@@ -1,7 +1,10 @@
 package gc
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -10,20 +13,26 @@ type BuildReaper interface {
 }
 
 type buildReaper struct {
-	logger          lager.Logger
-	pipelineFactory db.PipelineFactory
-	batchSize       int
+	logger           lager.Logger
+	pipelineFactory  db.PipelineFactory
+	batchSize        int
+	defaultRetention atc.BuildLogRetention
 }
 
+// NewBuildReaper returns a BuildReaper which deletes the logs of builds which
+// fall outside of their job's build log retention policy. Jobs which do not
+// configure one use the default retention.
 func NewBuildReaper(
 	logger lager.Logger,
 	pipelineFactory db.PipelineFactory,
 	batchSize int,
+	defaultRetention atc.BuildLogRetention,
 ) BuildReaper {
 	return &buildReaper{
-		logger:          logger,
-		pipelineFactory: pipelineFactory,
-		batchSize:       batchSize,
+		logger:           logger,
+		pipelineFactory:  pipelineFactory,
+		batchSize:        batchSize,
+		defaultRetention: defaultRetention,
 	}
 }
 
@@ -46,7 +55,8 @@ func (br *buildReaper) Run() error {
 		}
 
 		for _, job := range jobs {
-			if job.Config().BuildLogsToRetain == 0 {
+			retention := br.retentionFor(job.Config())
+			if retention.Builds == 0 && retention.Days == 0 {
 				continue
 			}
 
@@ -83,38 +93,63 @@ func (br *buildReaper) Run() error {
 				)
 			}
 
-			buildIDsToConsiderDeleting := []int{}
-			for _, build := range buildsToConsiderDeleting {
-				buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
+			if len(buildsToConsiderDeleting) == 0 {
+				continue
 			}
 
-			buildsToRetain, _, err := job.Builds(
-				db.Page{Limit: job.Config().BuildLogsToRetain},
-			)
-			if err != nil {
-				br.logger.Error("could-not-get-job-builds-to-retain", err)
-				return err
-			}
+			firstBuildToRetain := 0
+			if retention.Builds > 0 {
+				buildsToRetain, _, err := job.Builds(
+					db.Page{Limit: retention.Builds},
+				)
+				if err != nil {
+					br.logger.Error("could-not-get-job-builds-to-retain", err)
+					return err
+				}
+
+				if len(buildsToRetain) == 0 {
+					continue
+				}
 
-			buildIDsToRetain := []int{}
-			for _, build := range buildsToRetain {
-				buildIDsToRetain = append(buildIDsToRetain, build.ID())
+				firstBuildToRetain = buildsToRetain[len(buildsToRetain)-1].ID()
 			}
 
-			if len(buildsToRetain) == 0 {
-				continue
+			succeededBuildsToRetain := map[int]bool{}
+			if retention.MinimumSucceededBuilds > 0 {
+				oldestBuildToConsider := buildsToConsiderDeleting[len(buildsToConsiderDeleting)-1].ID()
+
+				succeededBuildsToRetain, err = br.latestSucceededBuilds(job, retention.MinimumSucceededBuilds, oldestBuildToConsider)
+				if err != nil {
+					br.logger.Error("could-not-get-job-succeeded-builds-to-retain", err)
+					return err
+				}
 			}
 
-			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
+			expiry := time.Now().AddDate(0, 0, -retention.Days)
 
 			buildIDsToDelete := []int{}
+			firstRetainedBuildID := 0
 			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
 				build := buildsToConsiderDeleting[i]
 
-				if build.ID() >= firstBuildToRetain || build.IsRunning() {
+				if build.IsRunning() {
+					break
+				}
+
+				tooMany := retention.Builds > 0 && build.ID() < firstBuildToRetain
+				tooOld := retention.Days > 0 && finishedBefore(build, expiry)
+				if !tooMany && !tooOld {
 					break
 				}
 
+				if succeededBuildsToRetain[build.ID()] {
+					if firstRetainedBuildID == 0 {
+						firstRetainedBuildID = build.ID()
+					}
+
+					continue
+				}
+
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
 			}
 
@@ -128,7 +163,14 @@ func (br *buildReaper) Run() error {
 				return err
 			}
 
-			err = job.UpdateFirstLoggedBuildID(buildIDsToDelete[len(buildIDsToDelete)-1] + 1)
+			// the first logged build must not move past a build that still has
+			// its logs
+			firstLoggedBuildID := buildIDsToDelete[len(buildIDsToDelete)-1] + 1
+			if firstRetainedBuildID != 0 {
+				firstLoggedBuildID = firstRetainedBuildID
+			}
+
+			err = job.UpdateFirstLoggedBuildID(firstLoggedBuildID)
 			if err != nil {
 				br.logger.Error("could-not-update-first-logged-build-id", err)
 				return err
@@ -138,3 +180,59 @@ func (br *buildReaper) Run() error {
 
 	return nil
 }
+
+func (br *buildReaper) retentionFor(config atc.JobConfig) atc.BuildLogRetention {
+	if config.BuildLogRetention != nil {
+		return *config.BuildLogRetention
+	}
+
+	if config.BuildLogsToRetain != 0 {
+		return atc.BuildLogRetention{Builds: config.BuildLogsToRetain}
+	}
+
+	return br.defaultRetention
+}
+
+// latestSucceededBuilds returns the IDs of the job's count most recent
+// succeeded builds, looking no further back than the oldest build ID.
+func (br *buildReaper) latestSucceededBuilds(job db.Job, count int, oldest int) (map[int]bool, error) {
+	succeeded := map[int]bool{}
+
+	page := &db.Page{Limit: br.batchSize}
+	for page != nil {
+		builds, pagination, err := job.Builds(*page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, build := range builds {
+			if build.ID() < oldest {
+				return succeeded, nil
+			}
+
+			if build.Status() == db.BuildStatusSucceeded {
+				succeeded[build.ID()] = true
+
+				if len(succeeded) == count {
+					return succeeded, nil
+				}
+			}
+		}
+
+		page = pagination.Next
+	}
+
+	return succeeded, nil
+}
+
+// finishedBefore reports whether the build finished before the given time.
+// Builds which never started, e.g. pending builds which were aborted, are
+// considered to have finished long ago.
+func finishedBefore(build db.Build, t time.Time) bool {
+	finished := build.EndTime()
+	if finished.IsZero() {
+		finished = build.StartTime()
+	}
+
+	return finished.Before(t)
+}
@@ -3,6 +3,7 @@ package gc_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -19,11 +20,13 @@ var _ = Describe("BuildReaper", func() {
 		buildReaper         BuildReaper
 		fakePipelineFactory *dbfakes.FakePipelineFactory
 		batchSize           int
+		defaultRetention    atc.BuildLogRetention
 	)
 
 	BeforeEach(func() {
 		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
 		batchSize = 5
+		defaultRetention = atc.BuildLogRetention{}
 	})
 
 	JustBeforeEach(func() {
@@ -32,6 +35,7 @@ var _ = Describe("BuildReaper", func() {
 			buildReaperLogger,
 			fakePipelineFactory,
 			batchSize,
+			defaultRetention,
 		)
 	})
 
@@ -470,6 +474,185 @@ var _ = Describe("BuildReaper", func() {
 			})
 		})
 
+		Context("when the job retains builds for a number of days", func() {
+			var fakeJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("job-1")
+				fakeJob.FirstLoggedBuildIDReturns(6)
+				fakeJob.ConfigReturns(atc.JobConfig{
+					BuildLogRetention: &atc.BuildLogRetention{
+						Days: 7,
+					},
+				})
+
+				fakePipeline.JobsReturns([]db.Job{fakeJob}, nil)
+
+				longAgo := time.Now().AddDate(0, 0, -10)
+				recently := time.Now().AddDate(0, 0, -1)
+
+				fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+					if page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{
+							finishedBuild(10, recently),
+							finishedBuild(9, longAgo),
+							finishedBuild(8, recently),
+							finishedBuild(7, longAgo),
+							finishedBuild(6, longAgo),
+						}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+			})
+
+			It("reaps the builds which finished before then, up to the first one which did not", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+				Expect(actualBuildIDs).To(ConsistOf(6, 7))
+
+				Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(8))
+			})
+		})
+
+		Context("when the job retains a minimum number of succeeded builds", func() {
+			var fakeJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("job-1")
+				fakeJob.FirstLoggedBuildIDReturns(1)
+				fakeJob.ConfigReturns(atc.JobConfig{
+					BuildLogRetention: &atc.BuildLogRetention{
+						Builds:                 5,
+						MinimumSucceededBuilds: 1,
+					},
+				})
+
+				fakePipeline.JobsReturns([]db.Job{fakeJob}, nil)
+
+				fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+					if page == (db.Page{Limit: 5}) {
+						return []db.Build{sb(7), sb(6), sb(5), sb(4), sb(3)}, db.Pagination{Next: &db.Page{Since: 3, Limit: 5}}, nil
+					} else if page == (db.Page{Since: 3, Limit: 5}) {
+						return []db.Build{sb(2), succeededBuild(1)}, db.Pagination{}, nil
+					} else if page == (db.Page{Until: 1, Limit: 4}) {
+						return []db.Build{sb(5), sb(4), sb(3), sb(2)}, db.Pagination{}, nil
+					} else if page == (db.Page{Since: 2, Limit: 1}) {
+						return []db.Build{succeededBuild(1)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+			})
+
+			It("keeps the latest succeeded builds and reaps the others", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+				Expect(actualBuildIDs).To(ConsistOf(2))
+
+				Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(1))
+			})
+
+			Context("when the succeeded build is between builds that are reaped", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{
+						BuildLogRetention: &atc.BuildLogRetention{
+							Builds:                 3,
+							MinimumSucceededBuilds: 1,
+						},
+					})
+
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if page == (db.Page{Limit: 3}) {
+							return []db.Build{sb(7), sb(6), sb(5)}, db.Pagination{}, nil
+						} else if page == (db.Page{Limit: 5}) {
+							return []db.Build{sb(7), sb(6), sb(5), sb(4), sb(3)}, db.Pagination{Next: &db.Page{Since: 3, Limit: 5}}, nil
+						} else if page == (db.Page{Since: 3, Limit: 5}) {
+							return []db.Build{succeededBuild(2), sb(1)}, db.Pagination{}, nil
+						} else if page == (db.Page{Until: 1, Limit: 4}) {
+							return []db.Build{sb(5), sb(4), sb(3), succeededBuild(2)}, db.Pagination{}, nil
+						} else if page == (db.Page{Since: 2, Limit: 1}) {
+							return []db.Build{sb(1)}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+				})
+
+				It("reaps the builds around it but does not move the first logged build past it", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(1, 3, 4))
+
+					Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+					Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when the job configures no retention", func() {
+			var fakeJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("job-1")
+				fakeJob.FirstLoggedBuildIDReturns(6)
+				fakeJob.ConfigReturns(atc.JobConfig{})
+
+				fakePipeline.JobsReturns([]db.Job{fakeJob}, nil)
+
+				fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+					if page == (db.Page{Limit: 3}) {
+						return []db.Build{sb(10), sb(9), sb(8)}, db.Pagination{}, nil
+					} else if page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{sb(10), sb(9), sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+			})
+
+			It("does not reap any builds", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJob.BuildsCallCount()).To(BeZero())
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+			})
+
+			Context("when there is a default retention", func() {
+				BeforeEach(func() {
+					defaultRetention = atc.BuildLogRetention{Builds: 3}
+				})
+
+				It("reaps the builds outside of the default retention", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7))
+				})
+			})
+		})
+
 		Context("when the dashboard job says retain 0 builds", func() {
 			var fakeJob *dbfakes.FakeJob
 
@@ -548,3 +731,17 @@ func runningBuild(id int) db.Build {
 	build.IsRunningReturns(true)
 	return build
 }
+
+func succeededBuild(id int) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.StatusReturns(db.BuildStatusSucceeded)
+	return build
+}
+
+func finishedBuild(id int, endTime time.Time) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.EndTimeReturns(endTime)
+	return build
+}
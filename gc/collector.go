@@ -18,6 +18,8 @@ type aggregateCollector struct {
 	volumeCollector                     Collector
 	containerCollector                  Collector
 	resourceConfigCheckSessionCollector Collector
+	auditEventCollector                 Collector
+	resourceVersionCollector            Collector
 }
 
 func NewCollector(
@@ -30,6 +32,8 @@ func NewCollector(
 	volumes Collector,
 	containers Collector,
 	resourceConfigCheckSessionCollector Collector,
+	auditEvents Collector,
+	resourceVersions Collector,
 ) Collector {
 	return &aggregateCollector{
 		logger:                              logger,
@@ -41,6 +45,8 @@ func NewCollector(
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
 		resourceConfigCheckSessionCollector: resourceConfigCheckSessionCollector,
+		auditEventCollector:                 auditEvents,
+		resourceVersionCollector:            resourceVersions,
 	}
 }
 
@@ -87,5 +93,15 @@ func (c *aggregateCollector) Run() error {
 		c.logger.Error("volume-collector", err)
 	}
 
+	err = c.auditEventCollector.Run()
+	if err != nil {
+		c.logger.Error("audit-event-collector", err)
+	}
+
+	err = c.resourceVersionCollector.Run()
+	if err != nil {
+		c.logger.Error("resource-version-collector", err)
+	}
+
 	return nil
 }
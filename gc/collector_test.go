@@ -24,6 +24,8 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
 		fakeResourceConfigCheckSessionCollector *gcfakes.FakeCollector
+		fakeAuditEventCollector                 *gcfakes.FakeCollector
+		fakeResourceVersionCollector            *gcfakes.FakeCollector
 
 		err      error
 		disaster error
@@ -39,6 +41,8 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCheckSessionCollector = new(gcfakes.FakeCollector)
+		fakeAuditEventCollector = new(gcfakes.FakeCollector)
+		fakeResourceVersionCollector = new(gcfakes.FakeCollector)
 
 		subject = NewCollector(
 			logger,
@@ -50,6 +54,8 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeVolumeCollector,
 			fakeContainerCollector,
 			fakeResourceConfigCheckSessionCollector,
+			fakeAuditEventCollector,
+			fakeResourceVersionCollector,
 		)
 
 		disaster = errors.New("disaster")
@@ -64,6 +70,45 @@ var _ = Describe("Aggregate Collector", func() {
 			Expect(fakeBuildCollector.RunCallCount()).To(Equal(1))
 		})
 
+		It("runs the resource version collector", func() {
+			Expect(fakeResourceVersionCollector.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the resource version collector errors", func() {
+			BeforeEach(func() {
+				fakeResourceVersionCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeBuildCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeAuditEventCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		It("runs the audit event collector", func() {
+			Expect(fakeAuditEventCollector.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the audit event collector errors", func() {
+			BeforeEach(func() {
+				fakeAuditEventCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeBuildCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when the build collector errors", func() {
 			BeforeEach(func() {
 				fakeBuildCollector.RunReturns(disaster)
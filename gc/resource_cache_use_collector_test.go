@@ -69,6 +69,7 @@ var _ = Describe("ResourceCacheUseCollector", func() {
 					},
 					0,
 					db.PipelineNoChange,
+					"",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeTrue())
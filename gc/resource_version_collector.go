@@ -0,0 +1,98 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+type resourceVersionCollector struct {
+	logger          lager.Logger
+	pipelineFactory db.PipelineFactory
+	defaultLimit    int
+}
+
+func NewResourceVersionCollector(
+	logger lager.Logger,
+	pipelineFactory db.PipelineFactory,
+	defaultLimit int,
+) Collector {
+	return &resourceVersionCollector{
+		logger:          logger,
+		pipelineFactory: pipelineFactory,
+		defaultLimit:    defaultLimit,
+	}
+}
+
+func (c *resourceVersionCollector) Run() error {
+	c.logger.Debug("start")
+	defer c.logger.Debug("done")
+
+	pipelines, err := c.pipelineFactory.AllPipelines()
+	if err != nil {
+		c.logger.Error("failed-to-get-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		// failing to prune one pipeline must not keep the others from being
+		// pruned
+		c.prunePipeline(pipeline)
+	}
+
+	return nil
+}
+
+func (c *resourceVersionCollector) prunePipeline(pipeline db.Pipeline) {
+	logger := c.logger.WithData(lager.Data{"pipeline": pipeline.Name()})
+
+	resources, err := pipeline.Resources()
+	if err != nil {
+		logger.Error("failed-to-get-resources", err)
+		return
+	}
+
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		logger.Error("failed-to-get-jobs", err)
+		return
+	}
+
+	pinnedVersions := map[string][]atc.Version{}
+	upstreamJobs := map[string][]atc.PassedJob{}
+	for _, job := range jobs {
+		for _, input := range job.Config().Inputs() {
+			if input.Version != nil && input.Version.Pinned != nil {
+				pinnedVersions[input.Resource] = append(pinnedVersions[input.Resource], input.Version.Pinned)
+			}
+
+			for _, passed := range input.Passed {
+				passedJob := atc.ParsePassedJob(passed)
+				if passedJob.IsCrossPipeline() {
+					upstreamJobs[input.Resource] = append(upstreamJobs[input.Resource], passedJob)
+				}
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		limit := resource.VersionHistoryLimit()
+		if limit == 0 {
+			limit = c.defaultLimit
+		}
+
+		if limit == 0 {
+			continue
+		}
+
+		deleted, err := resource.PruneVersions(limit, pinnedVersions[resource.Name()], upstreamJobs[resource.Name()])
+		if err != nil {
+			logger.Error("failed-to-prune-resource-versions", err, lager.Data{"resource": resource.Name()})
+			continue
+		}
+
+		if deleted > 0 {
+			logger.Debug("pruned-resource-versions", lager.Data{"resource": resource.Name(), "count": deleted})
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceVersionCollector", func() {
+	var (
+		collector                 gc.Collector
+		fakePipelineFactory       *dbfakes.FakePipelineFactory
+		fakePipeline              *dbfakes.FakePipeline
+		fakeOtherPipeline         *dbfakes.FakePipeline
+		fakeOtherPipelineResource *dbfakes.FakeResource
+		fakeResource              *dbfakes.FakeResource
+		fakeOtherResource         *dbfakes.FakeResource
+		defaultLimit              int
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakeOtherPipeline = new(dbfakes.FakePipeline)
+		fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{fakePipeline, fakeOtherPipeline}, nil)
+
+		fakeResource = new(dbfakes.FakeResource)
+		fakeResource.NameReturns("some-resource")
+		fakeResource.VersionHistoryLimitReturns(10)
+
+		fakeOtherResource = new(dbfakes.FakeResource)
+		fakeOtherResource.NameReturns("some-other-resource")
+
+		fakePipeline.ResourcesReturns(db.Resources{fakeResource, fakeOtherResource}, nil)
+
+		fakeOtherPipelineResource = new(dbfakes.FakeResource)
+		fakeOtherPipelineResource.NameReturns("some-resource")
+		fakeOtherPipelineResource.VersionHistoryLimitReturns(10)
+
+		fakeOtherPipeline.ResourcesReturns(db.Resources{fakeOtherPipelineResource}, nil)
+
+		fakeJob := new(dbfakes.FakeJob)
+		fakeJob.ConfigReturns(atc.JobConfig{
+			Name: "some-job",
+			Plan: atc.PlanSequence{
+				{
+					Get:     "some-resource",
+					Version: &atc.VersionConfig{Pinned: atc.Version{"ref": "abc"}},
+				},
+				{
+					Get:    "some-other-resource",
+					Passed: []string{"some-job", "upstream-pipeline/upstream-job"},
+				},
+			},
+		})
+
+		fakePipeline.JobsReturns(db.Jobs{fakeJob}, nil)
+
+		defaultLimit = 0
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewResourceVersionCollector(
+			lagertest.NewTestLogger("test"),
+			fakePipelineFactory,
+			defaultLimit,
+		)
+
+		err = collector.Run()
+	})
+
+	It("prunes the versions of resources with a version history limit", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeResource.PruneVersionsCallCount()).To(Equal(1))
+
+		limit, keep, upstreamJobs := fakeResource.PruneVersionsArgsForCall(0)
+		Expect(limit).To(Equal(10))
+		Expect(keep).To(Equal([]atc.Version{{"ref": "abc"}}))
+		Expect(upstreamJobs).To(BeEmpty())
+	})
+
+	It("prunes the resources of every pipeline", func() {
+		Expect(fakeOtherPipelineResource.PruneVersionsCallCount()).To(Equal(1))
+	})
+
+	It("keeps every version of resources without a version history limit", func() {
+		Expect(fakeOtherResource.PruneVersionsCallCount()).To(BeZero())
+	})
+
+	Context("when there is a default version history limit", func() {
+		BeforeEach(func() {
+			defaultLimit = 100
+		})
+
+		It("prunes the versions of resources without a version history limit", func() {
+			Expect(fakeOtherResource.PruneVersionsCallCount()).To(Equal(1))
+
+			limit, keep, _ := fakeOtherResource.PruneVersionsArgsForCall(0)
+			Expect(limit).To(Equal(100))
+			Expect(keep).To(BeEmpty())
+		})
+
+		It("keeps the versions which made it through jobs in other pipelines", func() {
+			_, _, upstreamJobs := fakeOtherResource.PruneVersionsArgsForCall(0)
+			Expect(upstreamJobs).To(Equal([]atc.PassedJob{
+				{PipelineName: "upstream-pipeline", JobName: "upstream-job"},
+			}))
+		})
+
+		It("uses the resource's own limit over the default", func() {
+			limit, _, _ := fakeResource.PruneVersionsArgsForCall(0)
+			Expect(limit).To(Equal(10))
+		})
+	})
+
+	Context("when getting the pipelines fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakePipelineFactory.AllPipelinesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+
+	Context("when getting the resources of a pipeline fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakePipeline.ResourcesReturns(nil, disaster)
+		})
+
+		It("does not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("still prunes the other pipelines", func() {
+			Expect(fakeOtherPipelineResource.PruneVersionsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the jobs of a pipeline fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakePipeline.JobsReturns(nil, disaster)
+		})
+
+		It("does not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("still prunes the other pipelines", func() {
+			Expect(fakeOtherPipelineResource.PruneVersionsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when pruning a resource fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			defaultLimit = 100
+			fakeResource.PruneVersionsReturns(0, disaster)
+		})
+
+		It("does not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("still prunes the other resources", func() {
+			Expect(fakeOtherResource.PruneVersionsCallCount()).To(Equal(1))
+			Expect(fakeOtherPipelineResource.PruneVersionsCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package gc
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+type workerDrainReaper struct {
+	logger        lager.Logger
+	workerFactory db.WorkerFactory
+	buildFactory  db.BuildFactory
+	clock         clock.Clock
+	drainTimeout  time.Duration
+}
+
+// NewWorkerDrainReaper returns a Collector which aborts the builds still
+// keeping a worker from landing or retiring once it has been draining for
+// longer than drainTimeout.
+func NewWorkerDrainReaper(
+	logger lager.Logger,
+	workerFactory db.WorkerFactory,
+	buildFactory db.BuildFactory,
+	clock clock.Clock,
+	drainTimeout time.Duration,
+) Collector {
+	return &workerDrainReaper{
+		logger:        logger,
+		workerFactory: workerFactory,
+		buildFactory:  buildFactory,
+		clock:         clock,
+		drainTimeout:  drainTimeout,
+	}
+}
+
+func (r *workerDrainReaper) Run() error {
+	r.logger.Debug("start")
+	defer r.logger.Debug("done")
+
+	workers, err := r.workerFactory.Workers()
+	if err != nil {
+		r.logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	for _, worker := range workers {
+		if worker.State() != db.WorkerStateLanding && worker.State() != db.WorkerStateRetiring {
+			continue
+		}
+
+		if worker.DrainStartedAt().IsZero() || r.clock.Since(worker.DrainStartedAt()) < r.drainTimeout {
+			continue
+		}
+
+		logger := r.logger.Session("abort-builds", lager.Data{"worker": worker.Name()})
+
+		builds, err := r.buildFactory.GetBuildsBlockingWorkerDrain(worker.Name())
+		if err != nil {
+			logger.Error("failed-to-get-builds", err)
+			continue
+		}
+
+		for _, build := range builds {
+			err := build.SaveEvent(event.Error{
+				Message: fmt.Sprintf(
+					"worker '%s' is %s and was not drained within %s; aborting build",
+					worker.Name(),
+					worker.State(),
+					r.drainTimeout,
+				),
+			})
+			if err != nil {
+				logger.Error("failed-to-save-error-event", err, lager.Data{"build": build.ID()})
+				continue
+			}
+
+			err = build.MarkAsAborted()
+			if err != nil {
+				logger.Error("failed-to-abort-build", err, lager.Data{"build": build.ID()})
+				continue
+			}
+
+			logger.Info("aborted-build", lager.Data{"build": build.ID()})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package gc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerDrainReaper", func() {
+	var (
+		reaper            gc.Collector
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		fakeBuildFactory  *dbfakes.FakeBuildFactory
+		fakeClock         *fakeclock.FakeClock
+
+		overdueWorker  *dbfakes.FakeWorker
+		drainingWorker *dbfakes.FakeWorker
+		runningWorker  *dbfakes.FakeWorker
+
+		build1 *dbfakes.FakeBuild
+		build2 *dbfakes.FakeBuild
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		overdueWorker = new(dbfakes.FakeWorker)
+		overdueWorker.NameReturns("overdue-worker")
+		overdueWorker.StateReturns(db.WorkerStateLanding)
+		overdueWorker.DrainStartedAtReturns(fakeClock.Now().Add(-2 * time.Hour))
+
+		drainingWorker = new(dbfakes.FakeWorker)
+		drainingWorker.NameReturns("draining-worker")
+		drainingWorker.StateReturns(db.WorkerStateRetiring)
+		drainingWorker.DrainStartedAtReturns(fakeClock.Now().Add(-time.Minute))
+
+		runningWorker = new(dbfakes.FakeWorker)
+		runningWorker.NameReturns("running-worker")
+		runningWorker.StateReturns(db.WorkerStateRunning)
+
+		fakeWorkerFactory.WorkersReturns([]db.Worker{overdueWorker, drainingWorker, runningWorker}, nil)
+
+		build1 = new(dbfakes.FakeBuild)
+		build1.IDReturns(1)
+		build2 = new(dbfakes.FakeBuild)
+		build2.IDReturns(2)
+
+		fakeBuildFactory.GetBuildsBlockingWorkerDrainReturns([]db.Build{build1, build2}, nil)
+	})
+
+	JustBeforeEach(func() {
+		reaper = gc.NewWorkerDrainReaper(
+			lagertest.NewTestLogger("test"),
+			fakeWorkerFactory,
+			fakeBuildFactory,
+			fakeClock,
+			time.Hour,
+		)
+
+		err = reaper.Run()
+	})
+
+	It("only looks at the builds of workers draining for longer than the timeout", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeBuildFactory.GetBuildsBlockingWorkerDrainCallCount()).To(Equal(1))
+		Expect(fakeBuildFactory.GetBuildsBlockingWorkerDrainArgsForCall(0)).To(Equal("overdue-worker"))
+	})
+
+	It("aborts the builds blocking the drain with an error event", func() {
+		for _, build := range []*dbfakes.FakeBuild{build1, build2} {
+			Expect(build.SaveEventCallCount()).To(Equal(1))
+			Expect(build.SaveEventArgsForCall(0)).To(Equal(event.Error{
+				Message: "worker 'overdue-worker' is landing and was not drained within 1h0m0s; aborting build",
+			}))
+
+			Expect(build.MarkAsAbortedCallCount()).To(Equal(1))
+		}
+	})
+
+	Context("when saving the error event fails", func() {
+		BeforeEach(func() {
+			build1.SaveEventReturns(errors.New("nope"))
+		})
+
+		It("moves on to the next build", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build1.MarkAsAbortedCallCount()).To(Equal(0))
+			Expect(build2.MarkAsAbortedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the worker has been draining for exactly the timeout", func() {
+		BeforeEach(func() {
+			drainingWorker.DrainStartedAtReturns(fakeClock.Now().Add(-time.Hour))
+		})
+
+		It("aborts its builds too", func() {
+			Expect(fakeBuildFactory.GetBuildsBlockingWorkerDrainCallCount()).To(Equal(2))
+			Expect(fakeBuildFactory.GetBuildsBlockingWorkerDrainArgsForCall(1)).To(Equal("draining-worker"))
+		})
+	})
+
+	Context("when the worker never recorded when it started draining", func() {
+		BeforeEach(func() {
+			overdueWorker.DrainStartedAtReturns(time.Time{})
+		})
+
+		It("does not abort its builds", func() {
+			Expect(fakeBuildFactory.GetBuildsBlockingWorkerDrainCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when getting the workers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
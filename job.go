@@ -1,5 +1,7 @@
 package atc
 
+import "strings"
+
 type Job struct {
 	ID int `json:"id"`
 
@@ -34,6 +36,52 @@ type JobOutput struct {
 	Resource string `json:"resource"`
 }
 
+// JobSchedulingExplanation describes what would stop a job's next build
+// from being scheduled, as found by resolving its inputs without saving them.
+type JobSchedulingExplanation struct {
+	PausedPipeline   BuildPreparationStatus `json:"paused_pipeline"`
+	PausedJob        BuildPreparationStatus `json:"paused_job"`
+	MaxRunningBuilds BuildPreparationStatus `json:"max_running_builds"`
+	SerialGroups     BuildPreparationStatus `json:"serial_groups"`
+	InputsSatisfied  BuildPreparationStatus `json:"inputs_satisfied"`
+
+	RunningBuilds []RunningBuild     `json:"running_builds,omitempty"`
+	Inputs        []InputExplanation `json:"inputs"`
+}
+
+// RunningBuild is a build counting towards a job's max-in-flight, which may
+// belong to another job sharing one of its serial groups.
+type RunningBuild struct {
+	JobName   string `json:"job_name"`
+	BuildName string `json:"build_name"`
+}
+
+type InputExplanation struct {
+	Name     string                 `json:"name"`
+	Resource string                 `json:"resource"`
+	Passed   []string               `json:"passed,omitempty"`
+	Status   BuildPreparationStatus `json:"status"`
+	Reason   string                 `json:"reason,omitempty"`
+	Version  Version                `json:"version,omitempty"`
+
+	Versions []VersionExplanation `json:"versions"`
+}
+
+type VersionExplanation struct {
+	ID      int     `json:"id"`
+	Version Version `json:"version"`
+
+	// Considered is true if the version was a candidate for the input, which
+	// is only the latest or pinned version for inputs without passed
+	// constraints.
+	Considered bool `json:"considered"`
+	Disabled   bool `json:"disabled,omitempty"`
+
+	// NotPassed are the jobs in the input's passed constraints which the
+	// version has not made it through.
+	NotPassed []string `json:"not_passed,omitempty"`
+}
+
 type BuildInput struct {
 	Name     string   `json:"name"`
 	Resource string   `json:"resource"`
@@ -43,3 +91,30 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+// PassedJob is a job named by a passed constraint. Jobs in other pipelines of
+// the same team are referred to as pipeline-name/job-name.
+type PassedJob struct {
+	PipelineName string
+	JobName      string
+}
+
+// ParsePassedJob splits a passed constraint into the pipeline and job it
+// refers to. PipelineName is empty if the constraint names a job in the same
+// pipeline.
+func ParsePassedJob(passed string) PassedJob {
+	segments := strings.SplitN(passed, "/", 2)
+	if len(segments) != 2 {
+		return PassedJob{JobName: passed}
+	}
+
+	return PassedJob{
+		PipelineName: segments[0],
+		JobName:      segments[1],
+	}
+}
+
+// IsCrossPipeline returns true if the job is in another pipeline.
+func (job PassedJob) IsCrossPipeline() bool {
+	return job.PipelineName != ""
+}
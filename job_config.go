@@ -11,6 +11,20 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	// Limits on how many and how old builds' logs are kept, replacing
+	// BuildLogsToRetain.
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+
+	// Builds of jobs with a higher priority are started, and given workers
+	// when they are at capacity, ahead of builds of other jobs. Workers are
+	// only held back for higher priority builds waiting on the same ATC, as
+	// each ATC keeps track of the builds waiting on it in memory.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty" mapstructure:"priority"`
+
+	// Default limits for every task in the job, overridden by any limits set
+	// in a task's own config.
+	ContainerLimits *ContainerLimits `yaml:"container_limits,omitempty" json:"container_limits,omitempty" mapstructure:"container_limits"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
@@ -19,6 +33,17 @@ type JobConfig struct {
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 }
 
+// BuildLogRetention limits which builds of a job keep their logs. A build's
+// logs are deleted once it is no longer one of the most recent Builds builds,
+// or once it finished more than Days days ago. The logs of the most recent
+// MinimumSucceededBuilds succeeded builds are kept regardless. Zero values
+// impose no limit.
+type BuildLogRetention struct {
+	Builds                 int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days                   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	MinimumSucceededBuilds int `yaml:"minimum_succeeded_builds,omitempty" json:"minimum_succeeded_builds,omitempty" mapstructure:"minimum_succeeded_builds"`
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{Abort: config.Abort, Failure: config.Failure, Ensure: config.Ensure, Success: config.Success}
 }
@@ -94,6 +119,12 @@ func collectPlans(plan PlanConfig) []PlanConfig {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			plans = append(plans, collectPlans(p)...)
+		}
+	}
+
 	return append(plans, plan)
 }
 
@@ -462,6 +462,38 @@ var _ = Describe("JobConfig", func() {
 				})
 			})
 
+			Context("when an in_parallel plan is the first step", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							InParallel: &atc.InParallelConfig{
+								Limit: 1,
+								Steps: atc.PlanSequence{
+									{Get: "a"},
+									{Put: "y"},
+									{Get: "b", Trigger: true},
+								},
+							},
+						},
+					}
+				})
+
+				It("returns an input config for all get plans", func() {
+					Expect(inputs).To(Equal([]atc.JobInput{
+						{
+							Name:     "a",
+							Resource: "a",
+							Trigger:  false,
+						},
+						{
+							Name:     "b",
+							Resource: "b",
+							Trigger:  true,
+						},
+					}))
+				})
+			})
+
 			Context("when an overly complicated aggregate plan is the first step", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
@@ -0,0 +1,25 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParsePassedJob", func() {
+	It("parses a job in the same pipeline", func() {
+		passedJob := atc.ParsePassedJob("some-job")
+		Expect(passedJob).To(Equal(atc.PassedJob{JobName: "some-job"}))
+		Expect(passedJob.IsCrossPipeline()).To(BeFalse())
+	})
+
+	It("parses a job in another pipeline", func() {
+		passedJob := atc.ParsePassedJob("some-pipeline/some-job")
+		Expect(passedJob).To(Equal(atc.PassedJob{
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+		}))
+		Expect(passedJob.IsCrossPipeline()).To(BeTrue())
+	})
+})
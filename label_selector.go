@@ -0,0 +1,149 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LabelSelector selects workers by their labels. Each of its expressions is
+// a requirement the worker's labels must satisfy:
+//
+//	key in (a, b)     the label is set to one of the values
+//	key notin (a, b)  the label is unset or set to none of the values
+//	key               the label is set
+type LabelSelector []string
+
+type LabelOperator string
+
+const (
+	LabelOperatorIn     LabelOperator = "in"
+	LabelOperatorNotIn  LabelOperator = "notin"
+	LabelOperatorExists LabelOperator = "exists"
+)
+
+type LabelRequirement struct {
+	Key      string
+	Operator LabelOperator
+	Values   []string
+}
+
+var labelPattern = regexp.MustCompile(`^[A-Za-z0-9_./-]+$`)
+
+var labelSetRequirementPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(([^()]*)\)$`)
+
+func ValidLabel(s string) bool {
+	return labelPattern.MatchString(s)
+}
+
+func ParseLabelRequirement(expression string) (LabelRequirement, error) {
+	expression = strings.TrimSpace(expression)
+
+	if ValidLabel(expression) {
+		return LabelRequirement{
+			Key:      expression,
+			Operator: LabelOperatorExists,
+		}, nil
+	}
+
+	match := labelSetRequirementPattern.FindStringSubmatch(expression)
+	if match == nil {
+		return LabelRequirement{}, fmt.Errorf("invalid label selector '%s': must be of the form 'key', 'key in (values)' or 'key notin (values)'", expression)
+	}
+
+	if !ValidLabel(match[1]) {
+		return LabelRequirement{}, fmt.Errorf("invalid label selector '%s': invalid key '%s'", expression, match[1])
+	}
+
+	requirement := LabelRequirement{
+		Key:      match[1],
+		Operator: LabelOperator(match[2]),
+	}
+
+	for _, value := range strings.Split(match[3], ",") {
+		value = strings.TrimSpace(value)
+		if !ValidLabel(value) {
+			return LabelRequirement{}, fmt.Errorf("invalid label selector '%s': invalid value '%s'", expression, value)
+		}
+
+		requirement.Values = append(requirement.Values, value)
+	}
+
+	return requirement, nil
+}
+
+func (requirement LabelRequirement) Matches(labels map[string]string) bool {
+	value, found := labels[requirement.Key]
+
+	switch requirement.Operator {
+	case LabelOperatorExists:
+		return found
+	case LabelOperatorIn:
+		return found && requirement.hasValue(value)
+	case LabelOperatorNotIn:
+		return !found || !requirement.hasValue(value)
+	}
+
+	return false
+}
+
+func (requirement LabelRequirement) hasValue(value string) bool {
+	for _, v := range requirement.Values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (selector LabelSelector) Requirements() ([]LabelRequirement, error) {
+	requirements := []LabelRequirement{}
+
+	for _, expression := range selector {
+		requirement, err := ParseLabelRequirement(expression)
+		if err != nil {
+			return nil, err
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, nil
+}
+
+// Matches returns whether the labels satisfy all of the selector's
+// requirements. An invalid selector matches nothing.
+func (selector LabelSelector) Matches(labels map[string]string) bool {
+	requirements, err := selector.Requirements()
+	if err != nil {
+		return false
+	}
+
+	for _, requirement := range requirements {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LabelSelectorFor returns a selector matching any worker with all of the
+// given labels.
+func LabelSelectorFor(labels map[string]string) LabelSelector {
+	keys := []string{}
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	selector := LabelSelector{}
+	for _, key := range keys {
+		selector = append(selector, fmt.Sprintf("%s in (%s)", key, labels[key]))
+	}
+
+	return selector
+}
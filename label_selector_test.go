@@ -0,0 +1,103 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LabelSelector", func() {
+	Describe("ParseLabelRequirement", func() {
+		It("parses a set requirement", func() {
+			requirement, err := atc.ParseLabelRequirement("zone in (us-east-1a, us-east-1b)")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirement).To(Equal(atc.LabelRequirement{
+				Key:      "zone",
+				Operator: atc.LabelOperatorIn,
+				Values:   []string{"us-east-1a", "us-east-1b"},
+			}))
+		})
+
+		It("parses an exclusion", func() {
+			requirement, err := atc.ParseLabelRequirement(" gpu notin(true) ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirement).To(Equal(atc.LabelRequirement{
+				Key:      "gpu",
+				Operator: atc.LabelOperatorNotIn,
+				Values:   []string{"true"},
+			}))
+		})
+
+		It("parses a bare key as an existence requirement", func() {
+			requirement, err := atc.ParseLabelRequirement("example.com/arch")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirement).To(Equal(atc.LabelRequirement{
+				Key:      "example.com/arch",
+				Operator: atc.LabelOperatorExists,
+			}))
+		})
+
+		DescribeTable("rejecting invalid expressions",
+			func(expression string) {
+				_, err := atc.ParseLabelRequirement(expression)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid label selector"))
+			},
+			Entry("empty", ""),
+			Entry("equality", "arch = arm64"),
+			Entry("unknown operator", "arch within (arm64)"),
+			Entry("no values", "arch in ()"),
+			Entry("empty value", "arch in (arm64,)"),
+			Entry("invalid key", "ar*ch in (arm64)"),
+			Entry("invalid value", "arch in (arm 64)"),
+		)
+	})
+
+	Describe("Matches", func() {
+		var labels map[string]string
+
+		BeforeEach(func() {
+			labels = map[string]string{
+				"arch": "arm64",
+				"gpu":  "false",
+			}
+		})
+
+		DescribeTable("matching labels",
+			func(selector atc.LabelSelector, matches bool) {
+				Expect(selector.Matches(labels)).To(Equal(matches))
+			},
+			Entry("empty selector", atc.LabelSelector{}, true),
+			Entry("in with the value", atc.LabelSelector{"arch in (amd64, arm64)"}, true),
+			Entry("in without the value", atc.LabelSelector{"arch in (amd64)"}, false),
+			Entry("in with a missing label", atc.LabelSelector{"zone in (us-east-1a)"}, false),
+			Entry("notin with the value", atc.LabelSelector{"gpu notin (false)"}, false),
+			Entry("notin without the value", atc.LabelSelector{"gpu notin (true)"}, true),
+			Entry("notin with a missing label", atc.LabelSelector{"zone notin (us-east-1a)"}, true),
+			Entry("exists with the label", atc.LabelSelector{"gpu"}, true),
+			Entry("exists with a missing label", atc.LabelSelector{"zone"}, false),
+			Entry("all requirements met", atc.LabelSelector{"arch in (arm64)", "gpu"}, true),
+			Entry("one requirement unmet", atc.LabelSelector{"arch in (arm64)", "zone"}, false),
+			Entry("invalid selector", atc.LabelSelector{"arch = arm64"}, false),
+		)
+	})
+
+	Describe("LabelSelectorFor", func() {
+		It("requires each of the labels to have its value", func() {
+			selector := atc.LabelSelectorFor(map[string]string{
+				"gpu":  "false",
+				"arch": "arm64",
+			})
+
+			Expect(selector).To(Equal(atc.LabelSelector{"arch in (arm64)", "gpu in (false)"}))
+			Expect(selector.Matches(map[string]string{"arch": "arm64", "gpu": "false", "zone": "a"})).To(BeTrue())
+			Expect(selector.Matches(map[string]string{"arch": "amd64", "gpu": "false"})).To(BeFalse())
+		})
+
+		It("matches every worker without labels", func() {
+			Expect(atc.LabelSelectorFor(nil)).To(BeEmpty())
+		})
+	})
+})
@@ -20,6 +20,9 @@ var FailedVolumes = Meter(0)
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
 
+var CredentialCacheHits = Meter(0)
+var CredentialCacheMisses = Meter(0)
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
@@ -147,6 +150,35 @@ func (event WorkerVolumes) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerCapacityWaitDuration struct {
+	TeamID   int
+	Duration time.Duration
+}
+
+func (event WorkerCapacityWaitDuration) Emit(logger lager.Logger) {
+	state := EventStateOK
+
+	if event.Duration > time.Minute {
+		state = EventStateWarning
+	}
+
+	if event.Duration > 10*time.Minute {
+		state = EventStateCritical
+	}
+
+	emit(
+		logger.Session("worker-capacity-wait-duration"),
+		Event{
+			Name:  "worker capacity wait duration (ms)",
+			Value: ms(event.Duration),
+			State: state,
+			Attributes: map[string]string{
+				"team_id": strconv.Itoa(event.TeamID),
+			},
+		},
+	)
+}
+
 type CreatingContainersToBeGarbageCollected struct {
 	Containers int
 }
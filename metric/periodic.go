@@ -93,6 +93,24 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			},
 		)
 
+		emit(
+			logger.Session("credential-cache-hits"),
+			Event{
+				Name:  "credential cache hits",
+				Value: CredentialCacheHits.Delta(),
+				State: EventStateOK,
+			},
+		)
+
+		emit(
+			logger.Session("credential-cache-misses"),
+			Event{
+				Name:  "credential cache misses",
+				Value: CredentialCacheMisses.Delta(),
+				State: EventStateOK,
+			},
+		)
+
 		var memStats runtime.MemStats
 		runtime.ReadMemStats(&memStats)
 
@@ -4,18 +4,22 @@ type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
-	Aggregate *AggregatePlan `json:"aggregate,omitempty"`
-	Do        *DoPlan        `json:"do,omitempty"`
-	Get       *GetPlan       `json:"get,omitempty"`
-	Put       *PutPlan       `json:"put,omitempty"`
-	Task      *TaskPlan      `json:"task,omitempty"`
-	OnAbort   *OnAbortPlan   `json:"on_abort,ommitempty"`
-	Ensure    *EnsurePlan    `json:"ensure,omitempty"`
-	OnSuccess *OnSuccessPlan `json:"on_success,omitempty"`
-	OnFailure *OnFailurePlan `json:"on_failure,omitempty"`
-	Try       *TryPlan       `json:"try,omitempty"`
-	Timeout   *TimeoutPlan   `json:"timeout,omitempty"`
-	Retry     *RetryPlan     `json:"retry,omitempty"`
+	Aggregate   *AggregatePlan   `json:"aggregate,omitempty"`
+	InParallel  *InParallelPlan  `json:"in_parallel,omitempty"`
+	Do          *DoPlan          `json:"do,omitempty"`
+	Get         *GetPlan         `json:"get,omitempty"`
+	Put         *PutPlan         `json:"put,omitempty"`
+	Task        *TaskPlan        `json:"task,omitempty"`
+	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
+	OnAbort     *OnAbortPlan     `json:"on_abort,ommitempty"`
+	Ensure      *EnsurePlan      `json:"ensure,omitempty"`
+	OnSuccess   *OnSuccessPlan   `json:"on_success,omitempty"`
+	OnFailure   *OnFailurePlan   `json:"on_failure,omitempty"`
+	Try         *TryPlan         `json:"try,omitempty"`
+	Timeout     *TimeoutPlan     `json:"timeout,omitempty"`
+	Retry       *RetryPlan       `json:"retry,omitempty"`
+
+	Conditional *ConditionalPlan `json:"conditional,omitempty"`
 
 	// used for 'fly execute'
 	UserArtifact   *UserArtifactPlan   `json:"user_artifact,omitempty"`
@@ -64,8 +68,38 @@ type TryPlan struct {
 	Step Plan `json:"step"`
 }
 
+type ConditionalPlan struct {
+	Condition Condition `json:"condition"`
+	Step      Plan      `json:"step"`
+}
+
+type Condition struct {
+	Trigger  string             `json:"trigger,omitempty"`
+	Versions []VersionCondition `json:"versions,omitempty"`
+	Steps    []StepCondition    `json:"steps,omitempty"`
+}
+
+type VersionCondition struct {
+	Name  string   `json:"name"`
+	Field string   `json:"field"`
+	Match string   `json:"match"`
+	Plans []PlanID `json:"plans,omitempty"`
+}
+
+type StepCondition struct {
+	Name   string      `json:"name"`
+	Status BuildStatus `json:"status"`
+	Plans  []PlanID    `json:"plans,omitempty"`
+}
+
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -78,6 +112,8 @@ type GetPlan struct {
 	VersionFrom *PlanID  `json:"version_from,omitempty"`
 	Tags        Tags     `json:"tags,omitempty"`
 
+	LabelSelector LabelSelector `json:"label_selector,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -89,6 +125,8 @@ type PutPlan struct {
 	Params   Params `json:"params,omitempty"`
 	Tags     Tags   `json:"tags,omitempty"`
 
+	LabelSelector LabelSelector `json:"label_selector,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -98,17 +136,28 @@ type TaskPlan struct {
 	Privileged bool `json:"privileged"`
 	Tags       Tags `json:"tags,omitempty"`
 
+	LabelSelector LabelSelector `json:"label_selector,omitempty"`
+
 	ConfigPath string      `json:"config_path,omitempty"`
 	Config     *TaskConfig `json:"config,omitempty"`
 
 	Params            Params            `json:"params,omitempty"`
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
+	OutputSuffix      string            `json:"output_suffix,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 
+	// Limits to apply unless overridden by the task config, e.g. from the job.
+	DefaultLimits *ContainerLimits `json:"default_limits,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
+type SetPipelinePlan struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
@@ -295,6 +295,59 @@ var _ = Describe("Plan", func() {
 							Name: "some-name",
 						},
 					},
+
+					atc.Plan{
+						ID: "33",
+						Conditional: &atc.ConditionalPlan{
+							Condition: atc.Condition{
+								Trigger: "manual",
+								Versions: []atc.VersionCondition{
+									{Name: "some-input", Field: "ref", Match: "^v", Plans: []atc.PlanID{"3"}},
+								},
+								Steps: []atc.StepCondition{
+									{Name: "name", Status: atc.StatusSucceeded, Plans: []atc.PlanID{"5"}},
+								},
+							},
+							Step: atc.Plan{
+								ID: "34",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+						},
+					},
+
+					atc.Plan{
+						ID: "35",
+						InParallel: &atc.InParallelPlan{
+							Limit:    2,
+							FailFast: true,
+							Steps: []atc.Plan{
+								{
+									ID: "36",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: map[string]string{"some": "secret"},
+										},
+									},
+								},
+							},
+						},
+					},
+
+					atc.Plan{
+						ID: "37",
+						SetPipeline: &atc.SetPipelinePlan{
+							Name: "some-pipeline",
+							File: "some/pipeline.yml",
+						},
+					},
 				},
 			}
 
@@ -510,6 +563,50 @@ var _ = Describe("Plan", func() {
 			"artifact_output": {
 				"name": "some-name"
 			}
+		},
+		{
+			"id": "33",
+			"conditional": {
+				"condition": {
+					"trigger": "manual",
+					"versions": [
+						{"name": "some-input", "field": "ref", "match": "^v", "plans": ["3"]}
+					],
+					"steps": [
+						{"name": "name", "status": "succeeded", "plans": ["5"]}
+					]
+				},
+				"step": {
+					"id": "34",
+					"task": {
+						"name": "name",
+						"privileged": false
+					}
+				}
+			}
+		},
+		{
+			"id": "35",
+			"in_parallel": {
+				"limit": 2,
+				"fail_fast": true,
+				"steps": [
+					{
+						"id": "36",
+						"task": {
+							"name": "name",
+							"privileged": false
+						}
+					}
+				]
+			}
+		},
+		{
+			"id": "37",
+			"set_pipeline": {
+				"name": "some-pipeline",
+				"file": "some/pipeline.yml"
+			}
 		}
   ]
 }
@@ -226,6 +226,11 @@ func (scanner *resourceScanner) check(
 		return nil
 	}
 
+	if savedResource.PinnedVersionID() != 0 {
+		logger.Debug("resource-pinned")
+		return nil
+	}
+
 	found, err := scanner.dbPipeline.Reload()
 	if err != nil {
 		logger.Error("failed-to-reload-scannerdb", err)
@@ -246,9 +251,10 @@ func (scanner *resourceScanner) check(
 		ImageSpec: worker.ImageSpec{
 			ResourceType: savedResource.Type(),
 		},
-		Tags:   savedResource.Tags(),
-		TeamID: scanner.dbPipeline.TeamID(),
-		Env:    metadata.Env(),
+		Tags:          savedResource.Tags(),
+		LabelSelector: savedResource.LabelSelector(),
+		TeamID:        scanner.dbPipeline.TeamID(),
+		Env:           metadata.Env(),
 	}
 
 	res, err := scanner.resourceFactory.NewResource(
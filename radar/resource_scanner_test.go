@@ -444,6 +444,28 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource is pinned to a version", func() {
+				var anotherFakeResource *dbfakes.FakeResource
+				BeforeEach(func() {
+					anotherFakeResource = new(dbfakes.FakeResource)
+					anotherFakeResource.NameReturns("some-resource")
+					anotherFakeResource.PinnedVersionIDReturns(42)
+					fakeDBPipeline.ResourceReturns(anotherFakeResource, true, nil)
+				})
+
+				It("does not check", func() {
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("returns the default interval", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+
+				It("does not return an error", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("when checking if the resource is paused fails", func() {
 				disaster := errors.New("disaster")
 
@@ -201,8 +201,9 @@ func (scanner *resourceTypeScanner) check(
 		ImageSpec: worker.ImageSpec{
 			ResourceType: savedResourceType.Type(),
 		},
-		Tags:   savedResourceType.Tags(),
-		TeamID: scanner.dbPipeline.TeamID(),
+		Tags:          savedResourceType.Tags(),
+		LabelSelector: savedResourceType.LabelSelector(),
+		TeamID:        scanner.dbPipeline.TeamID(),
 	}
 
 	res, err := scanner.resourceFactory.NewResource(
@@ -12,4 +12,13 @@ type Resource struct {
 
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
+
+	PinnedVersion Version `json:"pinned_version,omitempty"`
+	PinnedBy      string  `json:"pinned_by,omitempty"`
+	PinComment    string  `json:"pin_comment,omitempty"`
+}
+
+// PinVersionRequest is the request body for pinning a resource to a version.
+type PinVersionRequest struct {
+	Comment string `json:"comment"`
 }
@@ -30,6 +30,7 @@ var _ = Describe("FetchSourceProvider", func() {
 		metadata                 = resource.EmptyMetadata{}
 		session                  = resource.Session{}
 		tags                     atc.Tags
+		labelSelector            atc.LabelSelector
 		resourceTypes            creds.VersionedResourceTypes
 		teamID                   = 3
 		resourceCache            *db.UsedResourceCache
@@ -43,6 +44,7 @@ var _ = Describe("FetchSourceProvider", func() {
 		logger = lagertest.NewTestLogger("test")
 		resourceInstance = new(resourcefakes.FakeResourceInstance)
 		tags = atc.Tags{"some", "tags"}
+		labelSelector = atc.LabelSelector{"arch in (arm64)"}
 
 		variables := template.StaticVariables{
 			"secret-repository": "repository",
@@ -69,6 +71,7 @@ var _ = Describe("FetchSourceProvider", func() {
 			session,
 			metadata,
 			tags,
+			labelSelector,
 			teamID,
 			resourceTypes,
 			resourceInstance,
@@ -83,9 +86,10 @@ var _ = Describe("FetchSourceProvider", func() {
 			Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
 			_, resourceSpec, actualResourceTypes := fakeWorkerClient.SatisfyingArgsForCall(0)
 			Expect(resourceSpec).To(Equal(worker.WorkerSpec{
-				ResourceType: "some-resource-type",
-				Tags:         tags,
-				TeamID:       teamID,
+				ResourceType:  "some-resource-type",
+				Tags:          tags,
+				LabelSelector: labelSelector,
+				TeamID:        teamID,
 			}))
 			Expect(actualResourceTypes).To(Equal(resourceTypes))
 		})
@@ -62,6 +62,7 @@ var _ = Describe("Fetcher", func() {
 			lagertest.NewTestLogger("test"),
 			resource.Session{},
 			atc.Tags{},
+			atc.LabelSelector{},
 			teamID,
 			creds.VersionedResourceTypes{},
 			new(resourcefakes.FakeResourceInstance),
@@ -12,13 +12,14 @@ import (
 )
 
 type FakeFetchSourceProviderFactory struct {
-	NewFetchSourceProviderStub        func(logger lager.Logger, session resource.Session, metadata resource.Metadata, tags atc.Tags, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, imageFetchingDelegate worker.ImageFetchingDelegate) resource.FetchSourceProvider
+	NewFetchSourceProviderStub        func(logger lager.Logger, session resource.Session, metadata resource.Metadata, tags atc.Tags, labelSelector atc.LabelSelector, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, imageFetchingDelegate worker.ImageFetchingDelegate) resource.FetchSourceProvider
 	newFetchSourceProviderMutex       sync.RWMutex
 	newFetchSourceProviderArgsForCall []struct {
 		logger                lager.Logger
 		session               resource.Session
 		metadata              resource.Metadata
 		tags                  atc.Tags
+		labelSelector         atc.LabelSelector
 		teamID                int
 		resourceTypes         creds.VersionedResourceTypes
 		resourceInstance      resource.ResourceInstance
@@ -34,7 +35,7 @@ type FakeFetchSourceProviderFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFetchSourceProviderFactory) NewFetchSourceProvider(logger lager.Logger, session resource.Session, metadata resource.Metadata, tags atc.Tags, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, imageFetchingDelegate worker.ImageFetchingDelegate) resource.FetchSourceProvider {
+func (fake *FakeFetchSourceProviderFactory) NewFetchSourceProvider(logger lager.Logger, session resource.Session, metadata resource.Metadata, tags atc.Tags, labelSelector atc.LabelSelector, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, imageFetchingDelegate worker.ImageFetchingDelegate) resource.FetchSourceProvider {
 	fake.newFetchSourceProviderMutex.Lock()
 	ret, specificReturn := fake.newFetchSourceProviderReturnsOnCall[len(fake.newFetchSourceProviderArgsForCall)]
 	fake.newFetchSourceProviderArgsForCall = append(fake.newFetchSourceProviderArgsForCall, struct {
@@ -42,15 +43,16 @@ func (fake *FakeFetchSourceProviderFactory) NewFetchSourceProvider(logger lager.
 		session               resource.Session
 		metadata              resource.Metadata
 		tags                  atc.Tags
+		labelSelector         atc.LabelSelector
 		teamID                int
 		resourceTypes         creds.VersionedResourceTypes
 		resourceInstance      resource.ResourceInstance
 		imageFetchingDelegate worker.ImageFetchingDelegate
-	}{logger, session, metadata, tags, teamID, resourceTypes, resourceInstance, imageFetchingDelegate})
-	fake.recordInvocation("NewFetchSourceProvider", []interface{}{logger, session, metadata, tags, teamID, resourceTypes, resourceInstance, imageFetchingDelegate})
+	}{logger, session, metadata, tags, labelSelector, teamID, resourceTypes, resourceInstance, imageFetchingDelegate})
+	fake.recordInvocation("NewFetchSourceProvider", []interface{}{logger, session, metadata, tags, labelSelector, teamID, resourceTypes, resourceInstance, imageFetchingDelegate})
 	fake.newFetchSourceProviderMutex.Unlock()
 	if fake.NewFetchSourceProviderStub != nil {
-		return fake.NewFetchSourceProviderStub(logger, session, metadata, tags, teamID, resourceTypes, resourceInstance, imageFetchingDelegate)
+		return fake.NewFetchSourceProviderStub(logger, session, metadata, tags, labelSelector, teamID, resourceTypes, resourceInstance, imageFetchingDelegate)
 	}
 	if specificReturn {
 		return ret.result1
@@ -64,10 +66,10 @@ func (fake *FakeFetchSourceProviderFactory) NewFetchSourceProviderCallCount() in
 	return len(fake.newFetchSourceProviderArgsForCall)
 }
 
-func (fake *FakeFetchSourceProviderFactory) NewFetchSourceProviderArgsForCall(i int) (lager.Logger, resource.Session, resource.Metadata, atc.Tags, int, creds.VersionedResourceTypes, resource.ResourceInstance, worker.ImageFetchingDelegate) {
+func (fake *FakeFetchSourceProviderFactory) NewFetchSourceProviderArgsForCall(i int) (lager.Logger, resource.Session, resource.Metadata, atc.Tags, atc.LabelSelector, int, creds.VersionedResourceTypes, resource.ResourceInstance, worker.ImageFetchingDelegate) {
 	fake.newFetchSourceProviderMutex.RLock()
 	defer fake.newFetchSourceProviderMutex.RUnlock()
-	return fake.newFetchSourceProviderArgsForCall[i].logger, fake.newFetchSourceProviderArgsForCall[i].session, fake.newFetchSourceProviderArgsForCall[i].metadata, fake.newFetchSourceProviderArgsForCall[i].tags, fake.newFetchSourceProviderArgsForCall[i].teamID, fake.newFetchSourceProviderArgsForCall[i].resourceTypes, fake.newFetchSourceProviderArgsForCall[i].resourceInstance, fake.newFetchSourceProviderArgsForCall[i].imageFetchingDelegate
+	return fake.newFetchSourceProviderArgsForCall[i].logger, fake.newFetchSourceProviderArgsForCall[i].session, fake.newFetchSourceProviderArgsForCall[i].metadata, fake.newFetchSourceProviderArgsForCall[i].tags, fake.newFetchSourceProviderArgsForCall[i].labelSelector, fake.newFetchSourceProviderArgsForCall[i].teamID, fake.newFetchSourceProviderArgsForCall[i].resourceTypes, fake.newFetchSourceProviderArgsForCall[i].resourceInstance, fake.newFetchSourceProviderArgsForCall[i].imageFetchingDelegate
 }
 
 func (fake *FakeFetchSourceProviderFactory) NewFetchSourceProviderReturns(result1 resource.FetchSourceProvider) {
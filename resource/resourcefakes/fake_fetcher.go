@@ -13,13 +13,14 @@ import (
 )
 
 type FakeFetcher struct {
-	FetchStub        func(ctx context.Context, logger lager.Logger, session resource.Session, tags atc.Tags, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, metadata resource.Metadata, imageFetchingDelegate worker.ImageFetchingDelegate) (resource.VersionedSource, error)
+	FetchStub        func(ctx context.Context, logger lager.Logger, session resource.Session, tags atc.Tags, labelSelector atc.LabelSelector, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, metadata resource.Metadata, imageFetchingDelegate worker.ImageFetchingDelegate) (resource.VersionedSource, error)
 	fetchMutex       sync.RWMutex
 	fetchArgsForCall []struct {
 		ctx                   context.Context
 		logger                lager.Logger
 		session               resource.Session
 		tags                  atc.Tags
+		labelSelector         atc.LabelSelector
 		teamID                int
 		resourceTypes         creds.VersionedResourceTypes
 		resourceInstance      resource.ResourceInstance
@@ -38,7 +39,7 @@ type FakeFetcher struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFetcher) Fetch(ctx context.Context, logger lager.Logger, session resource.Session, tags atc.Tags, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, metadata resource.Metadata, imageFetchingDelegate worker.ImageFetchingDelegate) (resource.VersionedSource, error) {
+func (fake *FakeFetcher) Fetch(ctx context.Context, logger lager.Logger, session resource.Session, tags atc.Tags, labelSelector atc.LabelSelector, teamID int, resourceTypes creds.VersionedResourceTypes, resourceInstance resource.ResourceInstance, metadata resource.Metadata, imageFetchingDelegate worker.ImageFetchingDelegate) (resource.VersionedSource, error) {
 	fake.fetchMutex.Lock()
 	ret, specificReturn := fake.fetchReturnsOnCall[len(fake.fetchArgsForCall)]
 	fake.fetchArgsForCall = append(fake.fetchArgsForCall, struct {
@@ -46,16 +47,17 @@ func (fake *FakeFetcher) Fetch(ctx context.Context, logger lager.Logger, session
 		logger                lager.Logger
 		session               resource.Session
 		tags                  atc.Tags
+		labelSelector         atc.LabelSelector
 		teamID                int
 		resourceTypes         creds.VersionedResourceTypes
 		resourceInstance      resource.ResourceInstance
 		metadata              resource.Metadata
 		imageFetchingDelegate worker.ImageFetchingDelegate
-	}{ctx, logger, session, tags, teamID, resourceTypes, resourceInstance, metadata, imageFetchingDelegate})
-	fake.recordInvocation("Fetch", []interface{}{ctx, logger, session, tags, teamID, resourceTypes, resourceInstance, metadata, imageFetchingDelegate})
+	}{ctx, logger, session, tags, labelSelector, teamID, resourceTypes, resourceInstance, metadata, imageFetchingDelegate})
+	fake.recordInvocation("Fetch", []interface{}{ctx, logger, session, tags, labelSelector, teamID, resourceTypes, resourceInstance, metadata, imageFetchingDelegate})
 	fake.fetchMutex.Unlock()
 	if fake.FetchStub != nil {
-		return fake.FetchStub(ctx, logger, session, tags, teamID, resourceTypes, resourceInstance, metadata, imageFetchingDelegate)
+		return fake.FetchStub(ctx, logger, session, tags, labelSelector, teamID, resourceTypes, resourceInstance, metadata, imageFetchingDelegate)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -69,10 +71,10 @@ func (fake *FakeFetcher) FetchCallCount() int {
 	return len(fake.fetchArgsForCall)
 }
 
-func (fake *FakeFetcher) FetchArgsForCall(i int) (context.Context, lager.Logger, resource.Session, atc.Tags, int, creds.VersionedResourceTypes, resource.ResourceInstance, resource.Metadata, worker.ImageFetchingDelegate) {
+func (fake *FakeFetcher) FetchArgsForCall(i int) (context.Context, lager.Logger, resource.Session, atc.Tags, atc.LabelSelector, int, creds.VersionedResourceTypes, resource.ResourceInstance, resource.Metadata, worker.ImageFetchingDelegate) {
 	fake.fetchMutex.RLock()
 	defer fake.fetchMutex.RUnlock()
-	return fake.fetchArgsForCall[i].ctx, fake.fetchArgsForCall[i].logger, fake.fetchArgsForCall[i].session, fake.fetchArgsForCall[i].tags, fake.fetchArgsForCall[i].teamID, fake.fetchArgsForCall[i].resourceTypes, fake.fetchArgsForCall[i].resourceInstance, fake.fetchArgsForCall[i].metadata, fake.fetchArgsForCall[i].imageFetchingDelegate
+	return fake.fetchArgsForCall[i].ctx, fake.fetchArgsForCall[i].logger, fake.fetchArgsForCall[i].session, fake.fetchArgsForCall[i].tags, fake.fetchArgsForCall[i].labelSelector, fake.fetchArgsForCall[i].teamID, fake.fetchArgsForCall[i].resourceTypes, fake.fetchArgsForCall[i].resourceInstance, fake.fetchArgsForCall[i].metadata, fake.fetchArgsForCall[i].imageFetchingDelegate
 }
 
 func (fake *FakeFetcher) FetchReturns(result1 resource.VersionedSource, result2 error) {
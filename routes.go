@@ -6,6 +6,11 @@ const (
 	SaveConfig = "SaveConfig"
 	GetConfig  = "GetConfig"
 
+	ListConfigVersions   = "ListConfigVersions"
+	GetConfigVersion     = "GetConfigVersion"
+	DiffConfigVersions   = "DiffConfigVersions"
+	RestoreConfigVersion = "RestoreConfigVersion"
+
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
 	CreateBuild         = "CreateBuild"
@@ -28,6 +33,9 @@ const (
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
 
+	ExplainJobScheduling = "ExplainJobScheduling"
+	SearchJobBuildLogs   = "SearchJobBuildLogs"
+
 	ListResources        = "ListResources"
 	ListResourceTypes    = "ListResourceTypes"
 	GetResource          = "GetResource"
@@ -40,31 +48,35 @@ const (
 	GetResourceVersion            = "GetResourceVersion"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PinResourceVersion            = "PinResourceVersion"
+	UnpinResource                 = "UnpinResource"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 	GetResourceCausality          = "GetResourceCausality"
 
-	ListAllPipelines    = "ListAllPipelines"
-	ListPipelines       = "ListPipelines"
-	GetPipeline         = "GetPipeline"
-	DeletePipeline      = "DeletePipeline"
-	OrderPipelines      = "OrderPipelines"
-	PausePipeline       = "PausePipeline"
-	UnpausePipeline     = "UnpausePipeline"
-	ExposePipeline      = "ExposePipeline"
-	HidePipeline        = "HidePipeline"
-	RenamePipeline      = "RenamePipeline"
-	ListPipelineBuilds  = "ListPipelineBuilds"
-	CreatePipelineBuild = "CreatePipelineBuild"
-	PipelineBadge       = "PipelineBadge"
-
-	RegisterWorker  = "RegisterWorker"
-	LandWorker      = "LandWorker"
-	RetireWorker    = "RetireWorker"
-	PruneWorker     = "PruneWorker"
-	HeartbeatWorker = "HeartbeatWorker"
-	ListWorkers     = "ListWorkers"
-	DeleteWorker    = "DeleteWorker"
+	ListAllPipelines        = "ListAllPipelines"
+	ListPipelines           = "ListPipelines"
+	GetPipeline             = "GetPipeline"
+	DeletePipeline          = "DeletePipeline"
+	OrderPipelines          = "OrderPipelines"
+	PausePipeline           = "PausePipeline"
+	UnpausePipeline         = "UnpausePipeline"
+	ExposePipeline          = "ExposePipeline"
+	HidePipeline            = "HidePipeline"
+	RenamePipeline          = "RenamePipeline"
+	ListPipelineBuilds      = "ListPipelineBuilds"
+	SearchPipelineBuildLogs = "SearchPipelineBuildLogs"
+	CreatePipelineBuild     = "CreatePipelineBuild"
+	PipelineBadge           = "PipelineBadge"
+
+	RegisterWorker       = "RegisterWorker"
+	LandWorker           = "LandWorker"
+	RetireWorker         = "RetireWorker"
+	PruneWorker          = "PruneWorker"
+	HeartbeatWorker      = "HeartbeatWorker"
+	ListWorkers          = "ListWorkers"
+	DeleteWorker         = "DeleteWorker"
+	GetWorkerDrainStatus = "GetWorkerDrainStatus"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -82,19 +94,30 @@ const (
 	LegacyGetAuthToken    = "LegacyGetAuthToken"
 	LegacyGetUser         = "LegacyGetUser"
 
-	ListTeams      = "ListTeams"
-	SetTeam        = "SetTeam"
-	RenameTeam     = "RenameTeam"
-	DestroyTeam    = "DestroyTeam"
-	ListTeamBuilds = "ListTeamBuilds"
+	ListTeams           = "ListTeams"
+	SetTeam             = "SetTeam"
+	RenameTeam          = "RenameTeam"
+	DestroyTeam         = "DestroyTeam"
+	ListTeamBuilds      = "ListTeamBuilds"
+	SearchTeamBuildLogs = "SearchTeamBuildLogs"
+
+	ListTeamVars  = "ListTeamVars"
+	SetTeamVar    = "SetTeamVar"
+	DeleteTeamVar = "DeleteTeamVar"
 
 	SendInputToBuildPlan    = "SendInputToBuildPlan"
 	ReadOutputFromBuildPlan = "ReadOutputFromBuildPlan"
+
+	ListAuditEvents = "ListAuditEvents"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", Method: "GET", Name: ListConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", Method: "GET", Name: GetConfigVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/diff", Method: "GET", Name: DiffConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/restore", Method: "PUT", Name: RestoreConfigVersion},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -114,6 +137,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling_explanation", Method: "GET", Name: ExplainJobScheduling},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/build_logs", Method: "GET", Name: SearchJobBuildLogs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
@@ -133,6 +158,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/build_logs", Method: "GET", Name: SearchPipelineBuildLogs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -147,6 +173,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/causality", Method: "GET", Name: GetResourceCausality},
@@ -155,6 +183,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
 	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
 	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
+	{Path: "/api/v1/workers/:worker_name/drain", Method: "GET", Name: GetWorkerDrainStatus},
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
@@ -180,4 +209,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/build_logs", Method: "GET", Name: SearchTeamBuildLogs},
+
+	{Path: "/api/v1/teams/:team_name/vars", Method: "GET", Name: ListTeamVars},
+	{Path: "/api/v1/teams/:team_name/vars/:var_name", Method: "PUT", Name: SetTeamVar},
+	{Path: "/api/v1/teams/:team_name/vars/:var_name", Method: "DELETE", Name: DeleteTeamVar},
+
+	{Path: "/api/v1/audit_events", Method: "GET", Name: ListAuditEvents},
 })
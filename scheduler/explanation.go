@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
+)
+
+// ExplanationVersionLimit is the number of each input's most recently checked
+// versions included in a scheduling explanation.
+const ExplanationVersionLimit = 100
+
+const NoVersionsSatisfiedWithOtherInputs = "no versions satisfy passed constraints along with the other inputs"
+
+// ExplainScheduling works out what is stopping the job's next build from
+// being scheduled, without saving any input mappings or starting any builds.
+func (s *Scheduler) ExplainScheduling(logger lager.Logger, job db.Job) (atc.JobSchedulingExplanation, error) {
+	logger = logger.Session("explain-scheduling", lager.Data{"job_name": job.Name()})
+
+	explanation := atc.JobSchedulingExplanation{
+		PausedPipeline:   atc.BuildPreparationStatusNotBlocking,
+		PausedJob:        atc.BuildPreparationStatusNotBlocking,
+		MaxRunningBuilds: atc.BuildPreparationStatusNotBlocking,
+		SerialGroups:     atc.BuildPreparationStatusNotBlocking,
+		InputsSatisfied:  atc.BuildPreparationStatusNotBlocking,
+		Inputs:           []atc.InputExplanation{},
+	}
+
+	pipelinePaused, err := s.Pipeline.CheckPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	if pipelinePaused {
+		explanation.PausedPipeline = atc.BuildPreparationStatusBlocking
+	}
+
+	if job.Paused() {
+		explanation.PausedJob = atc.BuildPreparationStatusBlocking
+	}
+
+	maxInFlight := job.Config().MaxInFlight()
+	if maxInFlight > 0 {
+		runningBuilds, err := job.GetRunningBuildsBySerialGroup(job.Config().GetSerialGroups())
+		if err != nil {
+			logger.Error("failed-to-get-running-builds-by-serial-group", err)
+			return atc.JobSchedulingExplanation{}, err
+		}
+
+		for _, build := range runningBuilds {
+			explanation.RunningBuilds = append(explanation.RunningBuilds, atc.RunningBuild{
+				JobName:   build.JobName(),
+				BuildName: build.Name(),
+			})
+		}
+
+		if len(runningBuilds) >= maxInFlight {
+			explanation.MaxRunningBuilds = atc.BuildPreparationStatusBlocking
+
+			for _, build := range runningBuilds {
+				if build.JobName() != job.Name() {
+					explanation.SerialGroups = atc.BuildPreparationStatusBlocking
+					break
+				}
+			}
+		}
+	}
+
+	versions, err := s.Pipeline.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	inputExplanations, err := s.InputMapper.ExplainNextInputMapping(logger, versions, job)
+	if err != nil {
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	jobNames := map[int]string{}
+	for name, id := range versions.JobIDs {
+		jobNames[id] = name
+	}
+
+	resources, err := s.Pipeline.Resources()
+	if err != nil {
+		logger.Error("failed-to-get-resources", err)
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	for _, input := range job.Config().Inputs() {
+		var inputExplanation *algorithm.InputExplanation
+		for i, candidate := range inputExplanations {
+			if candidate.Name == input.Name {
+				inputExplanation = &inputExplanations[i]
+				break
+			}
+		}
+
+		explainedInput, err := s.explainInput(input, inputExplanation, jobNames, resources)
+		if err != nil {
+			logger.Error("failed-to-explain-input", err, lager.Data{"input": input.Name})
+			return atc.JobSchedulingExplanation{}, err
+		}
+
+		if inputExplanation == nil || inputExplanation.VersionID == 0 {
+			explanation.InputsSatisfied = atc.BuildPreparationStatusBlocking
+		}
+
+		explanation.Inputs = append(explanation.Inputs, explainedInput)
+	}
+
+	return explanation, nil
+}
+
+func (s *Scheduler) explainInput(
+	input atc.JobInput,
+	inputExplanation *algorithm.InputExplanation,
+	jobNames map[int]string,
+	resources db.Resources,
+) (atc.InputExplanation, error) {
+	explained := atc.InputExplanation{
+		Name:     input.Name,
+		Resource: input.Resource,
+		Passed:   input.Passed,
+		Status:   atc.BuildPreparationStatusNotBlocking,
+		Versions: []atc.VersionExplanation{},
+	}
+
+	var pinnedVersion atc.Version
+	if resource, found := resources.Lookup(input.Resource); found && resource.PinnedVersionID() != 0 {
+		// a version pinned through the API takes precedence over the config
+		pinnedVersion = resource.PinnedVersion()
+	} else if input.Version != nil {
+		pinnedVersion = input.Version.Pinned
+	}
+
+	pinned := pinnedVersion != nil
+
+	switch {
+	case pinned && (inputExplanation == nil || inputExplanation.IndependentVersionID == 0):
+		// the transformer leaves out inputs pinned to versions it can't find
+		versionJSON, err := json.Marshal(pinnedVersion)
+		if err != nil {
+			return atc.InputExplanation{}, err
+		}
+
+		explained.Status = atc.BuildPreparationStatusBlocking
+		explained.Reason = fmt.Sprintf(db.PinnedVersionUnavailable, string(versionJSON))
+
+	case inputExplanation == nil:
+		explained.Status = atc.BuildPreparationStatusBlocking
+		explained.Reason = db.NoVersionsAvailable
+
+	case inputExplanation.IndependentVersionID == 0 && len(input.Passed) > 0:
+		explained.Status = atc.BuildPreparationStatusBlocking
+		explained.Reason = db.NoVerionsSatisfiedPassedConstraints
+
+	case inputExplanation.IndependentVersionID == 0:
+		explained.Status = atc.BuildPreparationStatusBlocking
+		explained.Reason = db.NoVersionsAvailable
+
+	case inputExplanation.VersionID == 0 && len(input.Passed) > 0:
+		explained.Status = atc.BuildPreparationStatusBlocking
+		explained.Reason = NoVersionsSatisfiedWithOtherInputs
+
+	case inputExplanation.VersionID != 0:
+		savedVersion, found, err := s.Pipeline.VersionedResource(inputExplanation.VersionID)
+		if err != nil {
+			return atc.InputExplanation{}, err
+		}
+
+		if found {
+			explained.Version = atc.Version(savedVersion.Version)
+		}
+	}
+
+	candidates := map[int]algorithm.VersionExplanation{}
+	if inputExplanation != nil {
+		for _, candidate := range inputExplanation.Candidates {
+			candidates[candidate.VersionID] = candidate
+		}
+	}
+
+	savedVersions, _, _, err := s.Pipeline.GetResourceVersions(input.Resource, db.Page{Limit: ExplanationVersionLimit})
+	if err != nil {
+		return atc.InputExplanation{}, err
+	}
+
+	for _, savedVersion := range savedVersions {
+		candidate, considered := candidates[savedVersion.ID]
+
+		var notPassed []string
+		for jobID := range candidate.NotPassed {
+			notPassed = append(notPassed, jobNames[jobID])
+		}
+
+		sort.Strings(notPassed)
+
+		explained.Versions = append(explained.Versions, atc.VersionExplanation{
+			ID:         savedVersion.ID,
+			Version:    atc.Version(savedVersion.Version),
+			Considered: considered,
+			Disabled:   !savedVersion.Enabled,
+			NotPassed:  notPassed,
+		})
+	}
+
+	return explained, nil
+}
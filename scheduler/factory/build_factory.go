@@ -38,13 +38,26 @@ func (factory *buildFactory) Create(
 		return atc.Plan{}, err
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         job.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	stepIDs := map[string][]atc.PlanID{}
+	collectStepIDs(plan, stepIDs)
+	resolveConditions(plan, stepIDs)
+
+	if job.ContainerLimits != nil {
+		applyDefaultLimits(plan, job.ContainerLimits)
+	}
+
+	return plan, nil
 }
 
 func (factory *buildFactory) constructPlanFromJob(
@@ -93,6 +106,31 @@ func (factory *buildFactory) do(
 	return factory.planFactory.NewPlan(do), err
 }
 
+func (factory *buildFactory) matrix(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	aggregate := atc.AggregatePlan{}
+
+	for _, instance := range planConfig.MatrixInstances() {
+		nextStep, err := factory.constructPlanFromConfig(
+			instance,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		aggregate = append(aggregate, nextStep)
+	}
+
+	return factory.planFactory.NewPlan(aggregate), nil
+}
+
 func (factory *buildFactory) constructPlanFromConfig(
 	planConfig atc.PlanConfig,
 	resources atc.ResourceConfigs,
@@ -102,7 +140,12 @@ func (factory *buildFactory) constructPlanFromConfig(
 	var plan atc.Plan
 	var err error
 
-	if planConfig.Attempts == 0 {
+	if planConfig.Matrix != nil {
+		plan, err = factory.matrix(planConfig, resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+	} else if planConfig.Attempts == 0 {
 		plan, err = factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
 		if err != nil {
 			return atc.Plan{}, err
@@ -122,13 +165,25 @@ func (factory *buildFactory) constructPlanFromConfig(
 		plan = factory.planFactory.NewPlan(retryStep)
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         planConfig.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	if planConfig.If != nil {
+		plan = factory.planFactory.NewPlan(atc.ConditionalPlan{
+			Condition: conditionFromConfig(*planConfig.If),
+			Step:      plan,
+		})
+	}
+
+	return plan, nil
 }
 
 func (factory *buildFactory) constructUnhookedPlan(
@@ -173,6 +228,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Params:   planConfig.Params,
 			Tags:     planConfig.Tags,
 
+			LabelSelector: planConfig.LabelSelector,
+
 			VersionedResourceTypes: resourceTypes,
 		})
 
@@ -186,6 +243,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Tags:   planConfig.Tags,
 			Source: resource.Source,
 
+			LabelSelector: planConfig.LabelSelector,
+
 			VersionedResourceTypes: resourceTypes,
 		})
 
@@ -223,6 +282,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Version:  &version,
 			Tags:     planConfig.Tags,
 
+			LabelSelector: planConfig.LabelSelector,
+
 			VersionedResourceTypes: resourceTypes,
 		})
 
@@ -236,10 +297,19 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Params:            planConfig.Params,
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
+			OutputSuffix:      planConfig.OutputSuffix,
 			ImageArtifactName: planConfig.ImageArtifactName,
 
+			LabelSelector: planConfig.LabelSelector,
+
 			VersionedResourceTypes: resourceTypes,
 		})
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name: planConfig.SetPipeline,
+			File: planConfig.TaskConfigPath,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -273,6 +343,29 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		steps := []atc.Plan{}
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			steps = append(steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(atc.InParallelPlan{
+			Steps:    steps,
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		})
 	}
 
 	if planConfig.Timeout != "" {
@@ -401,3 +494,108 @@ func (factory *buildFactory) abortIfPresent(cp constructionParams) (construction
 
 	return cp, nil
 }
+
+func conditionFromConfig(config atc.ConditionConfig) atc.Condition {
+	condition := atc.Condition{
+		Trigger: config.Trigger,
+	}
+
+	for _, name := range config.VersionNames() {
+		fields := config.Versions[name]
+
+		for _, field := range config.VersionFields(name) {
+			condition.Versions = append(condition.Versions, atc.VersionCondition{
+				Name:  name,
+				Field: field,
+				Match: fields[field],
+			})
+		}
+	}
+
+	for _, name := range config.StepNames() {
+		condition.Steps = append(condition.Steps, atc.StepCondition{
+			Name:   name,
+			Status: config.Steps[name],
+		})
+	}
+
+	return condition
+}
+
+// collectStepIDs records the IDs of every get, put and task plan by step
+// name. Retried steps result in more than one ID for the same name.
+func collectStepIDs(plan atc.Plan, ids map[string][]atc.PlanID) {
+	switch {
+	case plan.Get != nil:
+		ids[plan.Get.Name] = append(ids[plan.Get.Name], plan.ID)
+	case plan.Put != nil:
+		ids[plan.Put.Name] = append(ids[plan.Put.Name], plan.ID)
+	case plan.Task != nil:
+		ids[plan.Task.Name] = append(ids[plan.Task.Name], plan.ID)
+	}
+
+	for _, sub := range subPlans(plan) {
+		collectStepIDs(sub, ids)
+	}
+}
+
+// resolveConditions fills in the plan IDs of the steps that each condition
+// refers to by name, now that the whole plan has been constructed.
+func resolveConditions(plan atc.Plan, ids map[string][]atc.PlanID) {
+	if plan.Conditional != nil {
+		condition := &plan.Conditional.Condition
+
+		for i, version := range condition.Versions {
+			condition.Versions[i].Plans = ids[version.Name]
+		}
+
+		for i, step := range condition.Steps {
+			condition.Steps[i].Plans = ids[step.Name]
+		}
+	}
+
+	for _, sub := range subPlans(plan) {
+		resolveConditions(sub, ids)
+	}
+}
+
+func applyDefaultLimits(plan atc.Plan, limits *atc.ContainerLimits) {
+	if plan.Task != nil {
+		plan.Task.DefaultLimits = limits
+	}
+
+	for _, sub := range subPlans(plan) {
+		applyDefaultLimits(sub, limits)
+	}
+}
+
+func subPlans(plan atc.Plan) []atc.Plan {
+	var plans []atc.Plan
+
+	switch {
+	case plan.Aggregate != nil:
+		plans = *plan.Aggregate
+	case plan.InParallel != nil:
+		plans = plan.InParallel.Steps
+	case plan.Do != nil:
+		plans = *plan.Do
+	case plan.Retry != nil:
+		plans = *plan.Retry
+	case plan.OnAbort != nil:
+		plans = []atc.Plan{plan.OnAbort.Step, plan.OnAbort.Next}
+	case plan.OnSuccess != nil:
+		plans = []atc.Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.OnFailure != nil:
+		plans = []atc.Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.Ensure != nil:
+		plans = []atc.Plan{plan.Ensure.Step, plan.Ensure.Next}
+	case plan.Try != nil:
+		plans = []atc.Plan{plan.Try.Step}
+	case plan.Timeout != nil:
+		plans = []atc.Plan{plan.Timeout.Step}
+	case plan.Conditional != nil:
+		plans = []atc.Plan{plan.Conditional.Step}
+	}
+
+	return plans
+}
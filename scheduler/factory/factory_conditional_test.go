@@ -0,0 +1,159 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Conditional Step", func() {
+	var (
+		resources     atc.ResourceConfigs
+		resourceTypes atc.VersionedResourceTypes
+		version       atc.Version
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(321)
+		expectedPlanFactory = atc.NewPlanFactory(321)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "docker-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+	})
+
+	Context("when a step has a condition", func() {
+		It("wraps the step and resolves the steps it refers to", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get: "some-resource",
+					},
+					{
+						Task: "unit",
+					},
+					{
+						Task: "release",
+						If: &atc.ConditionConfig{
+							Trigger:  atc.ConditionTriggerManual,
+							Versions: map[string]atc.Version{"some-resource": {"tag": "^v"}},
+							Steps:    map[string]atc.BuildStatus{"unit": atc.StatusSucceeded},
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			getPlan := expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:     "git",
+				Name:     "some-resource",
+				Resource: "some-resource",
+				Source:   atc.Source{"uri": "git://some-resource"},
+				Version:  &version,
+
+				VersionedResourceTypes: resourceTypes,
+			})
+
+			unitPlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "unit",
+
+				VersionedResourceTypes: resourceTypes,
+			})
+
+			releasePlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "release",
+
+				VersionedResourceTypes: resourceTypes,
+			})
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				getPlan,
+				unitPlan,
+				expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+					Condition: atc.Condition{
+						Trigger: atc.ConditionTriggerManual,
+						Versions: []atc.VersionCondition{
+							{
+								Name:  "some-resource",
+								Field: "tag",
+								Match: "^v",
+								Plans: []atc.PlanID{getPlan.ID},
+							},
+						},
+						Steps: []atc.StepCondition{
+							{
+								Name:   "unit",
+								Status: atc.StatusSucceeded,
+								Plans:  []atc.PlanID{unitPlan.ID},
+							},
+						},
+					},
+					Step: releasePlan,
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when a step with a condition also has a hook", func() {
+		It("skips the hook along with the step", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "release",
+						If: &atc.ConditionConfig{
+							Trigger: atc.ConditionTriggerAutomatic,
+						},
+						Success: &atc.PlanConfig{
+							Task: "announce",
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			releasePlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "release",
+			})
+
+			announcePlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "announce",
+			})
+
+			expected := expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+				Condition: atc.Condition{
+					Trigger: atc.ConditionTriggerAutomatic,
+				},
+				Step: expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+					Step: releasePlan,
+					Next: announcePlan,
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -0,0 +1,94 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Container Limits", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+
+		limits *atc.ContainerLimits
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		memory := uint64(1024 * 1024 * 1024)
+		limits = &atc.ContainerLimits{Memory: &memory}
+	})
+
+	Context("when the job has default container limits", func() {
+		It("applies them to every task, including hooks", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				ContainerLimits: limits,
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+					},
+					{
+						Aggregate: &atc.PlanSequence{
+							{Task: "some-other-task"},
+							{Task: "yet-another-task"},
+						},
+					},
+				},
+				Ensure: &atc.PlanConfig{
+					Task: "some-ensure-task",
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.EnsurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.DoPlan{
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some-task",
+						DefaultLimits: limits,
+					}),
+					expectedPlanFactory.NewPlan(atc.AggregatePlan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "some-other-task",
+							DefaultLimits: limits,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "yet-another-task",
+							DefaultLimits: limits,
+						}),
+					}),
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some-ensure-task",
+					DefaultLimits: limits,
+				}),
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when the job has no default container limits", func() {
+		It("leaves the tasks without defaults", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "some-task",
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
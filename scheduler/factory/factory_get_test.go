@@ -75,6 +75,40 @@ var _ = Describe("Factory Get", func() {
 		})
 	})
 
+	Context("with a get with tags and a label selector", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:           "some-get",
+						Resource:      "some-resource",
+						Tags:          atc.Tags{"some-tag"},
+						LabelSelector: atc.LabelSelector{"arch in (arm64)"},
+					},
+				},
+			}
+		})
+
+		It("carries them over to the plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:     "git",
+				Name:     "some-get",
+				Resource: "some-resource",
+				Source: atc.Source{
+					"uri": "git://some-resource",
+				},
+				Version:                &version,
+				Tags:                   atc.Tags{"some-tag"},
+				LabelSelector:          atc.LabelSelector{"arch in (arm64)"},
+				VersionedResourceTypes: resourceTypes,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("with a get for a non-existent resource", func() {
 		BeforeEach(func() {
 			input = atc.JobConfig{
@@ -0,0 +1,112 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Matrix Step", func() {
+	var (
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	Context("when a task has a matrix", func() {
+		It("runs a task per combination in an aggregate", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "unit",
+						TaskConfigPath: "ci/unit.yml",
+						Matrix: atc.MatrixConfig{
+							"go_version": {"1.9", "1.10"},
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:         "unit-1.9",
+					ConfigPath:   "ci/unit.yml",
+					Params:       atc.Params{"go_version": "1.9"},
+					OutputSuffix: "-1.9",
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:         "unit-1.10",
+					ConfigPath:   "ci/unit.yml",
+					Params:       atc.Params{"go_version": "1.10"},
+					OutputSuffix: "-1.10",
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when a task with a matrix has attempts, a timeout and a hook", func() {
+		It("retries and times out each instance but hooks the whole matrix", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "unit",
+						TaskConfigPath: "ci/unit.yml",
+						Attempts:       2,
+						Timeout:        "1h",
+						Matrix: atc.MatrixConfig{
+							"os": {"linux"},
+						},
+						Failure: &atc.PlanConfig{
+							Task: "alert",
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskPlan := atc.TaskPlan{
+				Name:         "unit-linux",
+				ConfigPath:   "ci/unit.yml",
+				Params:       atc.Params{"os": "linux"},
+				OutputSuffix: "-linux",
+			}
+
+			firstAttempt := expectedPlanFactory.NewPlan(atc.TimeoutPlan{
+				Duration: "1h",
+				Step:     expectedPlanFactory.NewPlan(taskPlan),
+			})
+
+			secondAttempt := expectedPlanFactory.NewPlan(atc.TimeoutPlan{
+				Duration: "1h",
+				Step:     expectedPlanFactory.NewPlan(taskPlan),
+			})
+
+			aggregate := expectedPlanFactory.NewPlan(atc.AggregatePlan{
+				expectedPlanFactory.NewPlan(atc.RetryPlan{
+					firstAttempt,
+					secondAttempt,
+				}),
+			})
+
+			expected := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+				Step: aggregate,
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "alert",
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory SetPipeline", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	Context("when I have a set_pipeline step", func() {
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-input/pipeline.yml",
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+				Name: "some-pipeline",
+				File: "some-input/pipeline.yml",
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when I have a set_pipeline step with hooks", func() {
+		It("wraps the step", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-input/pipeline.yml",
+						Failure: &atc.PlanConfig{
+							Task: "some-failure-task",
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+					Name: "some-pipeline",
+					File: "some-input/pipeline.yml",
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "some-failure-task",
+				}),
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -23,13 +23,24 @@ type transformer struct {
 func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName string, inputs []atc.JobInput) (algorithm.InputConfigs, error) {
 	inputConfigs := algorithm.InputConfigs{}
 
+	resources, err := i.pipeline.Resources()
+	if err != nil {
+		return nil, err
+	}
+
 	for _, input := range inputs {
 		if input.Version == nil {
 			input.Version = &atc.VersionConfig{Latest: true}
 		}
 
+		useEveryVersion := input.Version.Every
+
 		pinnedVersionID := 0
-		if input.Version.Pinned != nil {
+		if resource, found := resources.Lookup(input.Resource); found && resource.PinnedVersionID() != 0 {
+			// a version pinned through the API takes precedence over the config
+			pinnedVersionID = resource.PinnedVersionID()
+			useEveryVersion = false
+		} else if input.Version.Pinned != nil {
 			savedVersion, found, err := i.pipeline.GetVersionedResourceByVersion(input.Version.Pinned, input.Resource)
 			if err != nil {
 				return nil, err
@@ -49,7 +60,7 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 
 		inputConfigs = append(inputConfigs, algorithm.InputConfig{
 			Name:            input.Name,
-			UseEveryVersion: input.Version.Every,
+			UseEveryVersion: useEveryVersion,
 			PinnedVersionID: pinnedVersionID,
 			ResourceID:      db.ResourceIDs[input.Resource],
 			Passed:          jobs,
@@ -187,6 +187,47 @@ var _ = Describe("Transformer", func() {
 					})
 				})
 			})
+
+			Context("when the resource is pinned to a version through the API", func() {
+				BeforeEach(func() {
+					pinnedResource := new(dbfakes.FakeResource)
+					pinnedResource.NameReturns("r1")
+					pinnedResource.PinnedVersionIDReturns(42)
+					fakePipeline.ResourcesReturns(db.Resources{pinnedResource}, nil)
+
+					jobInputs = []atc.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version:  &atc.VersionConfig{Every: true, Pinned: atc.Version{"version": "v1"}},
+					}}
+				})
+
+				It("uses the pinned version in place of the configured one", func() {
+					Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+						Name:            "job-input-1",
+						UseEveryVersion: false,
+						PinnedVersionID: 42,
+						ResourceID:      11,
+						Passed:          algorithm.JobSet{},
+						JobID:           1,
+					}))
+
+					Expect(fakePipeline.GetVersionedResourceByVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the resources fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("bad thing")
+					fakePipeline.ResourcesReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tranformErr).To(Equal(disaster))
+				})
+			})
 		})
 
 		Context("when an input has things that don't exist", func() {
@@ -15,6 +15,12 @@ type InputMapper interface {
 		versions *algorithm.VersionsDB,
 		job db.Job,
 	) (algorithm.InputMapping, error)
+
+	ExplainNextInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+	) ([]algorithm.InputExplanation, error)
 }
 
 func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer) InputMapper {
@@ -83,3 +89,22 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+// ExplainNextInputMapping resolves the job's inputs without saving the
+// mapping, describing the versions considered for each input. Inputs pinned
+// to a version which does not exist are not included.
+func (i *inputMapper) ExplainNextInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+) ([]algorithm.InputExplanation, error) {
+	logger = logger.Session("explain-next-input-mapping")
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), job.Config().Inputs())
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return nil, err
+	}
+
+	return algorithmInputConfigs.Explain(versions), nil
+}
@@ -378,4 +378,91 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 	})
+
+	Describe("ExplainNextInputMapping", func() {
+		var (
+			versionsDB   *algorithm.VersionsDB
+			fakeJob      *dbfakes.FakeJob
+			explanations []algorithm.InputExplanation
+			explainErr   error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 11, CheckOrder: 2},
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+						BuildID:         98,
+						JobID:           2,
+					},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "a", Passed: []string{"upstream"}},
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			explanations, explainErr = inputMapper.ExplainNextInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+			)
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when transforming the input configs succeeds", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{
+						Name:       "a",
+						ResourceID: 11,
+						Passed:     algorithm.JobSet{2: struct{}{}},
+						JobID:      1,
+					},
+				}, nil)
+			})
+
+			It("explains the inputs", func() {
+				Expect(explainErr).NotTo(HaveOccurred())
+				Expect(explanations).To(Equal([]algorithm.InputExplanation{
+					{
+						Name: "a",
+						Candidates: []algorithm.VersionExplanation{
+							{VersionID: 2, CheckOrder: 2, NotPassed: algorithm.JobSet{2: struct{}{}}},
+							{VersionID: 1, CheckOrder: 1, NotPassed: algorithm.JobSet{}},
+						},
+						IndependentVersionID: 1,
+						VersionID:            1,
+					},
+				}))
+			})
+
+			It("does not save any input mapping", func() {
+				Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+				Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeJob.DeleteNextInputMappingCallCount()).To(BeZero())
+			})
+		})
+	})
 })
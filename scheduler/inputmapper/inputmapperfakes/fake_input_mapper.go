@@ -26,6 +26,21 @@ type FakeInputMapper struct {
 		result1 algorithm.InputMapping
 		result2 error
 	}
+	ExplainNextInputMappingStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job) ([]algorithm.InputExplanation, error)
+	explainNextInputMappingMutex       sync.RWMutex
+	explainNextInputMappingArgsForCall []struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      db.Job
+	}
+	explainNextInputMappingReturns struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}
+	explainNextInputMappingReturnsOnCall map[int]struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -83,11 +98,66 @@ func (fake *FakeInputMapper) SaveNextInputMappingReturnsOnCall(i int, result1 al
 	}{result1, result2}
 }
 
+func (fake *FakeInputMapper) ExplainNextInputMapping(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job) ([]algorithm.InputExplanation, error) {
+	fake.explainNextInputMappingMutex.Lock()
+	ret, specificReturn := fake.explainNextInputMappingReturnsOnCall[len(fake.explainNextInputMappingArgsForCall)]
+	fake.explainNextInputMappingArgsForCall = append(fake.explainNextInputMappingArgsForCall, struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      db.Job
+	}{logger, versions, job})
+	fake.recordInvocation("ExplainNextInputMapping", []interface{}{logger, versions, job})
+	fake.explainNextInputMappingMutex.Unlock()
+	if fake.ExplainNextInputMappingStub != nil {
+		return fake.ExplainNextInputMappingStub(logger, versions, job)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.explainNextInputMappingReturns.result1, fake.explainNextInputMappingReturns.result2
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingCallCount() int {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return len(fake.explainNextInputMappingArgsForCall)
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, db.Job) {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return fake.explainNextInputMappingArgsForCall[i].logger, fake.explainNextInputMappingArgsForCall[i].versions, fake.explainNextInputMappingArgsForCall[i].job
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingReturns(result1 []algorithm.InputExplanation, result2 error) {
+	fake.ExplainNextInputMappingStub = nil
+	fake.explainNextInputMappingReturns = struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingReturnsOnCall(i int, result1 []algorithm.InputExplanation, result2 error) {
+	fake.ExplainNextInputMappingStub = nil
+	if fake.explainNextInputMappingReturnsOnCall == nil {
+		fake.explainNextInputMappingReturnsOnCall = make(map[int]struct {
+			result1 []algorithm.InputExplanation
+			result2 error
+		})
+	}
+	fake.explainNextInputMappingReturnsOnCall[i] = struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInputMapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
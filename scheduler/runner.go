@@ -31,10 +31,18 @@ type BuildScheduler interface {
 	) (db.Build, Waiter, error)
 
 	SaveNextInputMapping(logger lager.Logger, job db.Job) error
+
+	ExplainScheduling(logger lager.Logger, job db.Job) (atc.JobSchedulingExplanation, error)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
 
+// upstreamLockRetryInterval is how soon scheduling is retried when an upstream
+// pipeline was being scheduled.
+const upstreamLockRetryInterval = time.Second
+
+// Runner schedules the pipeline whenever the pipeline's SchedulingNotifier
+// fires, falling back to scheduling it every Interval.
 type Runner struct {
 	Logger    lager.Logger
 	Pipeline  db.Pipeline
@@ -56,15 +64,37 @@ func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	defer runner.Logger.Info("done")
 
+	notifier, err := runner.Pipeline.SchedulingNotifier()
+	if err != nil {
+		runner.Logger.Error("failed-to-listen-for-scheduling-notifications", err)
+		return err
+	}
+
+	defer notifier.Close()
+
+	// when notified, schedule regardless of how recently the pipeline was
+	// last scheduled; the interval only throttles the fallback
+	lockInterval := runner.Interval
+
 dance:
 	for {
-		err := runner.tick(runner.Logger.Session("tick"))
+		retry, err := runner.tick(runner.Logger.Session("tick"), lockInterval)
 		if err != nil {
 			return err
 		}
 
+		wait := runner.Interval
+		nextLockInterval := runner.Interval
+		if retry {
+			wait = upstreamLockRetryInterval
+			nextLockInterval = 0
+		}
+
 		select {
-		case <-time.After(runner.Interval):
+		case <-notifier.Notify():
+			lockInterval = 0
+		case <-time.After(wait):
+			lockInterval = nextLockInterval
 		case <-signals:
 			break dance
 		}
@@ -73,23 +103,37 @@ dance:
 	return nil
 }
 
-func (runner *Runner) tick(logger lager.Logger) error {
+// tick schedules the pipeline if its scheduling lock can be acquired. It
+// returns true if it should be retried shortly because an upstream pipeline
+// was being scheduled.
+func (runner *Runner) tick(logger lager.Logger, lockInterval time.Duration) (bool, error) {
 	if runner.Noop {
-		return nil
+		return false, nil
 	}
 
-	schedulingLock, acquired, err := runner.Pipeline.AcquireSchedulingLock(logger, runner.Interval)
+	schedulingLock, acquired, err := runner.Pipeline.AcquireSchedulingLock(logger, lockInterval)
 	if err != nil {
 		logger.Error("failed-to-acquire-scheduling-lock", err)
-		return nil
+		return false, nil
 	}
 
 	if !acquired {
-		return nil
+		return false, nil
 	}
 
 	defer schedulingLock.Release()
 
+	upstreamLock, acquired, err := runner.Pipeline.AcquireUpstreamSchedulingLocks(logger)
+	if err != nil {
+		logger.Error("failed-to-acquire-upstream-scheduling-locks", err)
+		return false, nil
+	}
+
+	if !acquired {
+		logger.Debug("upstream-pipeline-being-scheduled")
+		return true, nil
+	}
+
 	start := time.Now()
 
 	defer func() {
@@ -100,9 +144,10 @@ func (runner *Runner) tick(logger lager.Logger) error {
 	}()
 
 	versions, err := runner.Pipeline.LoadVersionsDB()
+	upstreamLock.Release()
 	if err != nil {
 		logger.Error("failed-to-load-versions-db", err)
-		return err
+		return false, err
 	}
 
 	metric.SchedulingLoadVersionsDuration{
@@ -113,29 +158,29 @@ func (runner *Runner) tick(logger lager.Logger) error {
 	found, err := runner.Pipeline.Reload()
 	if err != nil {
 		logger.Error("failed-to-update-pipeline-config", err)
-		return nil
+		return false, nil
 	}
 
 	if !found {
-		return errPipelineRemoved
+		return false, errPipelineRemoved
 	}
 
 	resources, err := runner.Pipeline.Resources()
 	if err != nil {
 		logger.Error("failed-to-get-resources", err)
-		return err
+		return false, err
 	}
 
 	jobs, err := runner.Pipeline.Jobs()
 	if err != nil {
 		logger.Error("failed-to-get-jobs", err)
-		return err
+		return false, err
 	}
 
 	resourceTypes, err := runner.Pipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resource-types", err)
-		return err
+		return false, err
 	}
 
 	sLog := logger.Session("scheduling")
@@ -156,5 +201,5 @@ func (runner *Runner) tick(logger lager.Logger) error {
 		}.Emit(sLog)
 	}
 
-	return err
+	return false, err
 }
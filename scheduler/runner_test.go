@@ -25,7 +25,12 @@ var _ = Describe("Runner", func() {
 		scheduler    *schedulerfakes.FakeBuildScheduler
 		noop         bool
 
-		lock *lockfakes.FakeLock
+		lock         *lockfakes.FakeLock
+		upstreamLock *lockfakes.FakeLock
+
+		fakeNotifier *dbfakes.FakeNotifier
+		notify       chan struct{}
+		interval     time.Duration
 
 		initialConfig atc.Config
 
@@ -146,15 +151,25 @@ var _ = Describe("Runner", func() {
 
 		lock = new(lockfakes.FakeLock)
 		fakePipeline.AcquireSchedulingLockReturns(lock, true, nil)
+
+		upstreamLock = new(lockfakes.FakeLock)
+		fakePipeline.AcquireUpstreamSchedulingLocksReturns(upstreamLock, true, nil)
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+		fakePipeline.SchedulingNotifierReturns(fakeNotifier, nil)
+
+		interval = 100 * time.Millisecond
 	})
 
 	JustBeforeEach(func() {
-		process = ginkgomon.Invoke(&Runner{
+		process = ifrit.Invoke(&Runner{
 			Logger:    lagertest.NewTestLogger("test"),
 			Pipeline:  fakePipeline,
 			Scheduler: scheduler,
 			Noop:      noop,
-			Interval:  100 * time.Millisecond,
+			Interval:  interval,
 		})
 	})
 
@@ -162,6 +177,56 @@ var _ = Describe("Runner", func() {
 		ginkgomon.Interrupt(process)
 	})
 
+	It("closes the scheduling notifier when interrupted", func() {
+		Eventually(scheduler.ScheduleCallCount).Should(Equal(1))
+
+		ginkgomon.Interrupt(process)
+		Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when notified of a change", func() {
+		BeforeEach(func() {
+			interval = time.Hour
+		})
+
+		It("schedules again without waiting for the interval", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(1))
+			Consistently(scheduler.ScheduleCallCount).Should(Equal(1))
+
+			notify <- struct{}{}
+
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
+		})
+
+		It("acquires the scheduling lock regardless of when the pipeline was last scheduled", func() {
+			Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(Equal(1))
+
+			notify <- struct{}{}
+
+			Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(Equal(2))
+
+			_, duration := fakePipeline.AcquireSchedulingLockArgsForCall(1)
+			Expect(duration).To(BeZero())
+		})
+	})
+
+	Context("when listening for scheduling notifications fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakePipeline.SchedulingNotifierReturns(nil, disaster)
+		})
+
+		It("exits with the error", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+		})
+
+		It("does not do any scheduling", func() {
+			Eventually(process.Wait()).Should(Receive())
+			Expect(scheduler.ScheduleCallCount()).To(BeZero())
+		})
+	})
+
 	It("signs the scheduling lock for the pipeline", func() {
 		Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(BeNumerically(">=", 1))
 
@@ -181,6 +246,50 @@ var _ = Describe("Runner", func() {
 		})
 	})
 
+	It("holds the scheduling locks of upstream pipelines while loading the versions", func() {
+		Eventually(fakePipeline.LoadVersionsDBCallCount).Should(BeNumerically(">=", 1))
+		Eventually(upstreamLock.ReleaseCallCount).Should(BeNumerically(">=", 1))
+
+		Expect(fakePipeline.AcquireUpstreamSchedulingLocksCallCount()).To(BeNumerically(">=", 1))
+	})
+
+	Context("when an upstream pipeline is being scheduled", func() {
+		BeforeEach(func() {
+			interval = time.Hour
+			fakePipeline.AcquireUpstreamSchedulingLocksReturns(nil, false, nil)
+		})
+
+		It("does not do any scheduling", func() {
+			Eventually(fakePipeline.AcquireUpstreamSchedulingLocksCallCount).Should(Equal(1))
+
+			Expect(fakePipeline.LoadVersionsDBCallCount()).To(BeZero())
+			Expect(scheduler.ScheduleCallCount()).To(BeZero())
+		})
+
+		It("releases the pipeline's scheduling lock", func() {
+			Eventually(lock.ReleaseCallCount).Should(Equal(1))
+		})
+
+		It("retries without waiting for the interval", func() {
+			Eventually(fakePipeline.AcquireSchedulingLockCallCount, 2*time.Second).Should(Equal(2))
+
+			_, duration := fakePipeline.AcquireSchedulingLockArgsForCall(1)
+			Expect(duration).To(BeZero())
+		})
+	})
+
+	Context("when acquiring the upstream pipelines' locks blows up", func() {
+		BeforeEach(func() {
+			fakePipeline.AcquireUpstreamSchedulingLocksReturns(nil, false, errors.New(":3"))
+		})
+
+		It("does not do any scheduling", func() {
+			Eventually(fakePipeline.AcquireUpstreamSchedulingLocksCallCount).Should(Equal(2))
+
+			Expect(scheduler.ScheduleCallCount()).To(BeZero())
+		})
+	})
+
 	Context("when getting the lock blows up", func() {
 		BeforeEach(func() {
 			fakePipeline.AcquireSchedulingLockReturns(nil, false, errors.New(":3"))
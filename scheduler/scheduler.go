@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -49,7 +50,7 @@ func (s *Scheduler) Schedule(
 		return jobSchedulingTime, err
 	}
 
-	for _, job := range jobs {
+	for _, job := range byPriority(jobs) {
 		jStart := time.Now()
 		nextPendingBuildsForJob, ok := nextPendingBuilds[job.Name()]
 		if !ok {
@@ -95,6 +96,19 @@ func (s *Scheduler) ensurePendingBuildExists(
 	return nil
 }
 
+// byPriority orders the jobs so that those with a higher priority have their
+// pending builds started first, otherwise keeping their configured order.
+func byPriority(jobs []db.Job) []db.Job {
+	sorted := make([]db.Job, len(jobs))
+	copy(sorted, jobs)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Config().Priority > sorted[j].Config().Priority
+	})
+
+	return sorted
+}
+
 type Waiter interface {
 	Wait()
 }
@@ -164,6 +164,29 @@ var _ = Describe("Scheduler", func() {
 						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
 						Expect(fakeJob2.EnsurePendingBuildExistsCallCount()).To(BeZero())
 					})
+
+					It("starts pending builds for the jobs in their configured order", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(2))
+						_, actualJob, _, _, _ := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
+						_, actualJob, _, _, _ = fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(1)
+						Expect(actualJob.Name()).To(Equal(fakeJob2.Name()))
+					})
+
+					Context("when a later job has a higher priority", func() {
+						BeforeEach(func() {
+							fakeJob2.ConfigReturns(atc.JobConfig{Priority: 10})
+						})
+
+						It("starts pending builds for the higher priority job first", func() {
+							Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(2))
+							_, actualJob, _, _, actualPendingBuilds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+							Expect(actualJob.Name()).To(Equal(fakeJob2.Name()))
+							Expect(actualPendingBuilds).To(Equal(nextPendingBuildsJob2))
+							_, actualJob, _, _, _ = fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(1)
+							Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
+						})
+					})
 				})
 			})
 		})
@@ -406,4 +429,215 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 	})
+
+	Describe("ExplainScheduling", func() {
+		var (
+			fakeJob     *dbfakes.FakeJob
+			versionsDB  *algorithm.VersionsDB
+			explanation atc.JobSchedulingExplanation
+			explainErr  error
+		)
+
+		BeforeEach(func() {
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-input", Resource: "some-resource", Passed: []string{"upstream-job"}},
+				},
+			})
+
+			versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"some-job": 1, "upstream-job": 2}}
+			fakePipeline.LoadVersionsDBReturns(versionsDB, nil)
+
+			fakePipeline.GetResourceVersionsReturns([]db.SavedVersionedResource{
+				{
+					ID:                3,
+					Enabled:           false,
+					VersionedResource: db.VersionedResource{Version: db.ResourceVersion{"ref": "v3"}},
+				},
+				{
+					ID:                2,
+					Enabled:           true,
+					VersionedResource: db.VersionedResource{Version: db.ResourceVersion{"ref": "v2"}},
+				},
+				{
+					ID:                1,
+					Enabled:           true,
+					VersionedResource: db.VersionedResource{Version: db.ResourceVersion{"ref": "v1"}},
+				},
+			}, db.Pagination{}, true, nil)
+
+			fakePipeline.VersionedResourceReturns(db.SavedVersionedResource{
+				ID:                1,
+				Enabled:           true,
+				VersionedResource: db.VersionedResource{Version: db.ResourceVersion{"ref": "v1"}},
+			}, true, nil)
+
+			fakeInputMapper.ExplainNextInputMappingReturns([]algorithm.InputExplanation{
+				{
+					Name: "some-input",
+					Candidates: []algorithm.VersionExplanation{
+						{VersionID: 2, CheckOrder: 2, NotPassed: algorithm.JobSet{2: struct{}{}}},
+						{VersionID: 1, CheckOrder: 1, NotPassed: algorithm.JobSet{}},
+					},
+					IndependentVersionID: 1,
+					VersionID:            1,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			explanation, explainErr = scheduler.ExplainScheduling(lagertest.NewTestLogger("test"), fakeJob)
+		})
+
+		It("explains the inputs without saving a mapping", func() {
+			Expect(explainErr).NotTo(HaveOccurred())
+			Expect(explanation).To(Equal(atc.JobSchedulingExplanation{
+				PausedPipeline:   atc.BuildPreparationStatusNotBlocking,
+				PausedJob:        atc.BuildPreparationStatusNotBlocking,
+				MaxRunningBuilds: atc.BuildPreparationStatusNotBlocking,
+				SerialGroups:     atc.BuildPreparationStatusNotBlocking,
+				InputsSatisfied:  atc.BuildPreparationStatusNotBlocking,
+				Inputs: []atc.InputExplanation{
+					{
+						Name:     "some-input",
+						Resource: "some-resource",
+						Passed:   []string{"upstream-job"},
+						Status:   atc.BuildPreparationStatusNotBlocking,
+						Version:  atc.Version{"ref": "v1"},
+						Versions: []atc.VersionExplanation{
+							{ID: 3, Version: atc.Version{"ref": "v3"}, Considered: false, Disabled: true},
+							{ID: 2, Version: atc.Version{"ref": "v2"}, Considered: true, NotPassed: []string{"upstream-job"}},
+							{ID: 1, Version: atc.Version{"ref": "v1"}, Considered: true},
+						},
+					},
+				},
+			}))
+
+			Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+
+			_, actualVersionsDB, actualJob := fakeInputMapper.ExplainNextInputMappingArgsForCall(0)
+			Expect(actualVersionsDB).To(Equal(versionsDB))
+			Expect(actualJob).To(Equal(fakeJob))
+
+			resourceName, page := fakePipeline.GetResourceVersionsArgsForCall(0)
+			Expect(resourceName).To(Equal("some-resource"))
+			Expect(page).To(Equal(db.Page{Limit: ExplanationVersionLimit}))
+		})
+
+		Context("when the pipeline and job are paused", func() {
+			BeforeEach(func() {
+				fakePipeline.CheckPausedReturns(true, nil)
+				fakeJob.PausedReturns(true)
+			})
+
+			It("explains that they are blocking", func() {
+				Expect(explanation.PausedPipeline).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.PausedJob).To(Equal(atc.BuildPreparationStatusBlocking))
+			})
+		})
+
+		Context("when the job is in a serial group with a running build of another job", func() {
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name:         "some-job",
+					SerialGroups: []string{"some-group"},
+				})
+
+				runningBuild := new(dbfakes.FakeBuild)
+				runningBuild.JobNameReturns("some-other-job")
+				runningBuild.NameReturns("42")
+				fakeJob.GetRunningBuildsBySerialGroupReturns([]db.Build{runningBuild}, nil)
+			})
+
+			It("explains that the serial group and max running builds are blocking", func() {
+				Expect(fakeJob.GetRunningBuildsBySerialGroupArgsForCall(0)).To(Equal([]string{"some-group"}))
+				Expect(explanation.MaxRunningBuilds).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.SerialGroups).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.RunningBuilds).To(Equal([]atc.RunningBuild{
+					{JobName: "some-other-job", BuildName: "42"},
+				}))
+			})
+		})
+
+		Context("when no version satisfies the passed constraints", func() {
+			BeforeEach(func() {
+				fakeInputMapper.ExplainNextInputMappingReturns([]algorithm.InputExplanation{
+					{
+						Name: "some-input",
+						Candidates: []algorithm.VersionExplanation{
+							{VersionID: 2, CheckOrder: 2, NotPassed: algorithm.JobSet{2: struct{}{}}},
+						},
+					},
+				}, nil)
+			})
+
+			It("explains that the input is blocking", func() {
+				Expect(explanation.InputsSatisfied).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.Inputs[0].Status).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.Inputs[0].Reason).To(Equal("no versions satisfy passed constraints"))
+				Expect(explanation.Inputs[0].Version).To(BeNil())
+			})
+		})
+
+		Context("when the input is pinned to a version which does not exist", func() {
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-input", Resource: "some-resource", Version: &atc.VersionConfig{Pinned: atc.Version{"ref": "v4"}}},
+					},
+				})
+
+				fakeInputMapper.ExplainNextInputMappingReturns([]algorithm.InputExplanation{}, nil)
+			})
+
+			It("explains that the pinned version is unavailable", func() {
+				Expect(explanation.InputsSatisfied).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.Inputs[0].Status).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.Inputs[0].Reason).To(Equal(`pinned version {"ref":"v4"} is not available`))
+			})
+		})
+
+		Context("when the input's resource is pinned to a version which is not available", func() {
+			BeforeEach(func() {
+				fakeResource := new(dbfakes.FakeResource)
+				fakeResource.NameReturns("some-resource")
+				fakeResource.PinnedVersionIDReturns(42)
+				fakeResource.PinnedVersionReturns(atc.Version{"ref": "v5"})
+
+				fakePipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
+
+				fakeInputMapper.ExplainNextInputMappingReturns([]algorithm.InputExplanation{}, nil)
+			})
+
+			It("explains that the pinned version is unavailable", func() {
+				Expect(explanation.InputsSatisfied).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.Inputs[0].Status).To(Equal(atc.BuildPreparationStatusBlocking))
+				Expect(explanation.Inputs[0].Reason).To(Equal(`pinned version {"ref":"v5"} is not available`))
+			})
+		})
+
+		Context("when getting the resources fails", func() {
+			BeforeEach(func() {
+				fakePipeline.ResourcesReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when explaining the input mapping fails", func() {
+			BeforeEach(func() {
+				fakeInputMapper.ExplainNextInputMappingReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+	})
 })
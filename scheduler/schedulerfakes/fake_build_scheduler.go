@@ -60,6 +60,20 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExplainSchedulingStub        func(logger lager.Logger, job db.Job) (atc.JobSchedulingExplanation, error)
+	explainSchedulingMutex       sync.RWMutex
+	explainSchedulingArgsForCall []struct {
+		logger lager.Logger
+		job    db.Job
+	}
+	explainSchedulingReturns struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}
+	explainSchedulingReturnsOnCall map[int]struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -230,6 +244,58 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) ExplainScheduling(logger lager.Logger, job db.Job) (atc.JobSchedulingExplanation, error) {
+	fake.explainSchedulingMutex.Lock()
+	ret, specificReturn := fake.explainSchedulingReturnsOnCall[len(fake.explainSchedulingArgsForCall)]
+	fake.explainSchedulingArgsForCall = append(fake.explainSchedulingArgsForCall, struct {
+		logger lager.Logger
+		job    db.Job
+	}{logger, job})
+	fake.recordInvocation("ExplainScheduling", []interface{}{logger, job})
+	fake.explainSchedulingMutex.Unlock()
+	if fake.ExplainSchedulingStub != nil {
+		return fake.ExplainSchedulingStub(logger, job)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.explainSchedulingReturns.result1, fake.explainSchedulingReturns.result2
+}
+
+func (fake *FakeBuildScheduler) ExplainSchedulingCallCount() int {
+	fake.explainSchedulingMutex.RLock()
+	defer fake.explainSchedulingMutex.RUnlock()
+	return len(fake.explainSchedulingArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) ExplainSchedulingArgsForCall(i int) (lager.Logger, db.Job) {
+	fake.explainSchedulingMutex.RLock()
+	defer fake.explainSchedulingMutex.RUnlock()
+	return fake.explainSchedulingArgsForCall[i].logger, fake.explainSchedulingArgsForCall[i].job
+}
+
+func (fake *FakeBuildScheduler) ExplainSchedulingReturns(result1 atc.JobSchedulingExplanation, result2 error) {
+	fake.ExplainSchedulingStub = nil
+	fake.explainSchedulingReturns = struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildScheduler) ExplainSchedulingReturnsOnCall(i int, result1 atc.JobSchedulingExplanation, result2 error) {
+	fake.ExplainSchedulingStub = nil
+	if fake.explainSchedulingReturnsOnCall == nil {
+		fake.explainSchedulingReturnsOnCall = make(map[int]struct {
+			result1 atc.JobSchedulingExplanation
+			result2 error
+		})
+	}
+	fake.explainSchedulingReturnsOnCall[i] = struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -239,6 +305,8 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainSchedulingMutex.RLock()
+	defer fake.explainSchedulingMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -34,6 +34,55 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Limits on the resources available to the task's container.
+	Limits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+const (
+	// Bounds on CPU shares accepted by the kernel.
+	MinContainerCPUShares = 2
+	MaxContainerCPUShares = 262144
+
+	// Anything less and a container is unlikely to even start.
+	MinContainerMemoryBytes = 4 * 1024 * 1024
+)
+
+// ContainerLimits constrains the resources available to a container. Unset
+// or zero values leave the resource unlimited.
+type ContainerLimits struct {
+	// CPU shares, weighted against other containers on the same worker.
+	CPU *uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Memory limit in bytes.
+	Memory *uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// Merge returns the limits with any limits set in other taking precedence.
+func (limits ContainerLimits) Merge(other ContainerLimits) ContainerLimits {
+	if other.CPU != nil {
+		limits.CPU = other.CPU
+	}
+
+	if other.Memory != nil {
+		limits.Memory = other.Memory
+	}
+
+	return limits
+}
+
+func (limits ContainerLimits) validate() []string {
+	messages := []string{}
+
+	if limits.CPU != nil && *limits.CPU != 0 && (*limits.CPU < MinContainerCPUShares || *limits.CPU > MaxContainerCPUShares) {
+		messages = append(messages, fmt.Sprintf("  container_limits.cpu must be between %d and %d shares", MinContainerCPUShares, MaxContainerCPUShares))
+	}
+
+	if limits.Memory != nil && *limits.Memory != 0 && *limits.Memory < MinContainerMemoryBytes {
+		messages = append(messages, fmt.Sprintf("  container_limits.memory must be at least %d bytes", MinContainerMemoryBytes))
+	}
+
+	return messages
 }
 
 type ImageResource struct {
@@ -116,6 +165,16 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if other.Limits != nil {
+		var limits ContainerLimits
+		if config.Limits != nil {
+			limits = *config.Limits
+		}
+
+		limits = limits.Merge(*other.Limits)
+		config.Limits = &limits
+	}
+
 	return config
 }
 
@@ -132,6 +191,10 @@ func (config TaskConfig) Validate() error {
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
 
+	if config.Limits != nil {
+		messages = append(messages, config.Limits.validate()...)
+	}
+
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
 	}
@@ -101,6 +101,25 @@ run: {path: a/file}
 				})
 			})
 
+			Context("given a valid task config with container limits", func() {
+				It("works", func() {
+					data := []byte(`
+platform: beos
+
+container_limits:
+  cpu: 512
+  memory: 1073741824
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.Limits).ToNot(BeNil())
+					Expect(*task.Limits.CPU).To(Equal(uint64(512)))
+					Expect(*task.Limits.Memory).To(Equal(uint64(1073741824)))
+				})
+			})
+
 			Context("given a valid task config with numeric params", func() {
 				It("works", func() {
 					data := []byte(`
@@ -244,6 +263,54 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has container limits", func() {
+			var cpu, memory uint64
+
+			BeforeEach(func() {
+				cpu = 512
+				memory = 1024 * 1024 * 1024
+			})
+
+			JustBeforeEach(func() {
+				validConfig.Limits = &ContainerLimits{CPU: &cpu, Memory: &memory}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when they are zero", func() {
+				BeforeEach(func() {
+					cpu = 0
+					memory = 0
+				})
+
+				It("is valid", func() {
+					Expect(validConfig.Validate()).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when the cpu shares are out of range", func() {
+				BeforeEach(func() {
+					cpu = 1
+				})
+
+				It("returns an error", func() {
+					Expect(validConfig.Validate()).To(MatchError(ContainSubstring("  container_limits.cpu must be between 2 and 262144 shares")))
+				})
+			})
+
+			Context("when the memory is too small", func() {
+				BeforeEach(func() {
+					memory = 1024
+				})
+
+				It("returns an error", func() {
+					Expect(validConfig.Validate()).To(MatchError(ContainSubstring("  container_limits.memory must be at least 4194304 bytes")))
+				})
+			})
+		})
+
 		Describe("input overlapping checks", func() {
 			Context("when two inputs have the same name", func() {
 				BeforeEach(func() {
@@ -723,5 +790,29 @@ run: {path: a/file}
 				}))
 
 		})
+
+		It("merges container limits, preferring the other's", func() {
+			cpu := uint64(512)
+			memory := uint64(1024 * 1024 * 1024)
+			otherMemory := uint64(2 * 1024 * 1024 * 1024)
+
+			Expect(TaskConfig{
+				Limits: &ContainerLimits{CPU: &cpu, Memory: &memory},
+			}.Merge(TaskConfig{
+				Limits: &ContainerLimits{Memory: &otherMemory},
+			})).To(Equal(TaskConfig{
+				Limits: &ContainerLimits{CPU: &cpu, Memory: &otherMemory},
+			}))
+		})
+
+		It("takes the other's container limits if it has none", func() {
+			memory := uint64(1024 * 1024 * 1024)
+
+			Expect(TaskConfig{}.Merge(TaskConfig{
+				Limits: &ContainerLimits{Memory: &memory},
+			})).To(Equal(TaskConfig{
+				Limits: &ContainerLimits{Memory: &memory},
+			}))
+		})
 	})
 })
@@ -7,4 +7,6 @@ type Team struct {
 	Name string `json:"name,omitempty"`
 
 	Auth map[string]*json.RawMessage `json:"auth,omitempty"`
+
+	MaxContainers *int `json:"max_containers,omitempty"`
 }
@@ -92,6 +92,13 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for i, p := range plan.InParallel.Steps {
+			plan.InParallel.Steps[i], subIDs = stripIDs(p)
+			ids = append(ids, subIDs...)
+		}
+	}
+
 	if plan.Do != nil {
 		for i, p := range *plan.Do {
 			(*plan.Do)[i], subIDs = stripIDs(p)
@@ -133,6 +140,19 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 		ids = append(ids, subIDs...)
 	}
 
+	if plan.Conditional != nil {
+		plan.Conditional.Step, subIDs = stripIDs(plan.Conditional.Step)
+		ids = append(ids, subIDs...)
+
+		for i, version := range plan.Conditional.Condition.Versions {
+			plan.Conditional.Condition.Versions[i].Plans = stripPlanIDs(version.Plans)
+		}
+
+		for i, step := range plan.Conditional.Condition.Steps {
+			plan.Conditional.Condition.Steps[i].Plans = stripPlanIDs(step.Plans)
+		}
+	}
+
 	if plan.Get != nil {
 		if plan.Get.VersionFrom != nil {
 			planID := atc.PlanID("<stripped>")
@@ -142,3 +162,12 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 
 	return plan, ids
 }
+
+func stripPlanIDs(ids []atc.PlanID) []atc.PlanID {
+	stripped := make([]atc.PlanID, len(ids))
+	for i := range ids {
+		stripped[i] = "<stripped>"
+	}
+
+	return stripped
+}
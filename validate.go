@@ -3,6 +3,7 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -120,6 +121,12 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.VersionHistoryLimit < 0 {
+			errorMessages = append(errorMessages, identifier+" has a negative version_history_limit")
+		}
+
+		errorMessages = append(errorMessages, validateLabelSelector(identifier, resource.LabelSelector)...)
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -156,6 +163,8 @@ func validateResourceTypes(c Config) error {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		errorMessages = append(errorMessages, validateLabelSelector(identifier, resourceType.LabelSelector)...)
 	}
 
 	return compositeErr(errorMessages)
@@ -224,6 +233,42 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
+		if job.BuildLogRetention != nil {
+			if job.BuildLogsToRetain != 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+" has both build_logs_to_retain and build_log_retention",
+				)
+			}
+
+			if job.BuildLogRetention.Builds < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_log_retention.builds: %d", job.BuildLogRetention.Builds),
+				)
+			}
+
+			if job.BuildLogRetention.Days < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_log_retention.days: %d", job.BuildLogRetention.Days),
+				)
+			}
+
+			if job.BuildLogRetention.MinimumSucceededBuilds < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_log_retention.minimum_succeeded_builds: %d", job.BuildLogRetention.MinimumSucceededBuilds),
+				)
+			}
+		}
+
+		if job.ContainerLimits != nil {
+			for _, message := range job.ContainerLimits.validate() {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s %s", identifier, strings.TrimSpace(message)))
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -256,6 +301,8 @@ func validateJobs(c Config) ([]Warning, error) {
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+		errorMessages = append(errorMessages, validateConditionReferences(identifier, job)...)
+
 		encountered := map[string]int{}
 		for _, input := range job.Inputs() {
 			encountered[input.Name]++
@@ -319,6 +366,10 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		foundTypes.Find("task")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -327,6 +378,10 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
@@ -338,6 +393,8 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 	errorMessages := []string{}
 	warnings := []Warning{}
 
+	errorMessages = append(errorMessages, validateLabelSelector(identifier, plan.LabelSelector)...)
+
 	switch {
 	case plan.Do != nil:
 		for i, plan := range *plan.Do {
@@ -355,6 +412,21 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		if plan.InParallel.Limit < 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s.in_parallel.limit must not be negative", identifier),
+			)
+		}
+
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
@@ -390,6 +462,25 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
+
+			passedJob := ParsePassedJob(job)
+			if !found && passedJob.IsCrossPipeline() {
+				if passedJob.JobName == "" {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed references a job in pipeline '%s' without naming the job",
+							identifier,
+							passedJob.PipelineName,
+						),
+					)
+				}
+
+				// jobs in other pipelines are resolved when scheduling, as they
+				// may be configured after this pipeline
+				continue
+			}
+
 			if !found {
 				errorMessages = append(
 					errorMessages,
@@ -463,6 +554,18 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			}
 		}
 
+	case plan.Task != "" && plan.Matrix != nil:
+		identifier = fmt.Sprintf("%s.task.%s", identifier, plan.Task)
+
+		errorMessages = append(errorMessages, validateMatrix(identifier+".matrix", plan.Matrix)...)
+
+		for i, instance := range plan.MatrixInstances() {
+			subIdentifier := fmt.Sprintf("%s.matrix[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, instance)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
 	case plan.Task != "":
 		identifier = fmt.Sprintf("%s.task.%s", identifier, plan.Task)
 
@@ -495,6 +598,18 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			plan, identifier)...,
 		)
 
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a config file")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -543,9 +658,163 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if plan.If != nil {
+		errorMessages = append(errorMessages, validateCondition(identifier+".if", *plan.If)...)
+	}
+
+	if plan.Matrix != nil && plan.Task == "" {
+		errorMessages = append(errorMessages, identifier+".matrix is only supported on task steps")
+	}
+
 	return warnings, errorMessages
 }
 
+func validateCondition(identifier string, condition ConditionConfig) []string {
+	errorMessages := []string{}
+
+	switch condition.Trigger {
+	case "", ConditionTriggerManual, ConditionTriggerAutomatic:
+	default:
+		errorMessages = append(
+			errorMessages,
+			fmt.Sprintf("%s.trigger has an invalid value ('%s')", identifier, condition.Trigger),
+		)
+	}
+
+	for _, name := range condition.VersionNames() {
+		fields := condition.Versions[name]
+
+		for _, field := range condition.VersionFields(name) {
+			_, err := regexp.Compile(fields[field])
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s.versions.%s.%s is not a valid regular expression ('%s')", identifier, name, field, fields[field]),
+				)
+			}
+		}
+	}
+
+	for _, name := range condition.StepNames() {
+		status := condition.Steps[name]
+
+		if status != StatusSucceeded && status != StatusFailed {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s.steps.%s has an invalid status ('%s')", identifier, name, status),
+			)
+		}
+	}
+
+	return errorMessages
+}
+
+func validateMatrix(identifier string, matrix MatrixConfig) []string {
+	errorMessages := []string{}
+
+	if len(matrix) == 0 {
+		errorMessages = append(errorMessages, identifier+" has no params")
+	}
+
+	names := []string{}
+	for name := range matrix {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		values := matrix[name]
+		if len(values) == 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.%s has no values", identifier, name))
+		}
+
+		seen := map[string]bool{}
+		for _, value := range values {
+			if seen[value] {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.%s has a duplicate value ('%s')", identifier, name, value))
+			}
+
+			seen[value] = true
+		}
+	}
+
+	return errorMessages
+}
+
+func validateConditionReferences(identifier string, job JobConfig) []string {
+	errorMessages := []string{}
+
+	steps := map[string]bool{}
+	versioned := map[string]bool{}
+	matrixTasks := map[string]bool{}
+
+	for _, plan := range job.Plans() {
+		switch {
+		case plan.Get != "":
+			steps[plan.Get] = true
+			versioned[plan.Get] = true
+		case plan.Put != "":
+			steps[plan.Put] = true
+			versioned[plan.Put] = true
+		case plan.Task != "" && plan.Matrix != nil:
+			// matrix instances run, and so are referred to, by their suffixed
+			// names
+			for _, instance := range plan.MatrixInstances() {
+				steps[instance.Task] = true
+			}
+
+			matrixTasks[plan.Task] = true
+		case plan.Task != "":
+			steps[plan.Task] = true
+		}
+	}
+
+	for _, plan := range job.Plans() {
+		if plan.If == nil {
+			continue
+		}
+
+		for _, name := range plan.If.VersionNames() {
+			if !versioned[name] {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has a version condition on an unknown get or put step ('%s')", identifier, name),
+				)
+			}
+		}
+
+		for _, name := range plan.If.StepNames() {
+			if matrixTasks[name] {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has a condition on matrix task '%s'; refer to one of its instances instead", identifier, name),
+				)
+			} else if !steps[name] {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has a condition on an unknown step ('%s')", identifier, name),
+				)
+			}
+		}
+	}
+
+	return errorMessages
+}
+
+func validateLabelSelector(identifier string, selector LabelSelector) []string {
+	errorMessages := []string{}
+
+	for _, expression := range selector {
+		_, err := ParseLabelRequirement(expression)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has an %s", identifier, err))
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -174,6 +174,30 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has an invalid label selector", func() {
+			BeforeEach(func() {
+				config.Resources[0].LabelSelector = LabelSelector{"arch in (arm64)", "zone in ()"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has an invalid label selector 'zone in ()'"))
+			})
+		})
+
+		Context("when a resource has a negative version history limit", func() {
+			BeforeEach(func() {
+				config.Resources[0].VersionHistoryLimit = -1
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a negative version_history_limit"))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, ResourceConfig{
@@ -446,6 +470,22 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has invalid container_limits", func() {
+			BeforeEach(func() {
+				cpu := uint64(1)
+				memory := uint64(1024)
+				job.ContainerLimits = &ContainerLimits{CPU: &cpu, Memory: &memory}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job container_limits.cpu must be between 2 and 262144 shares"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job container_limits.memory must be at least 4194304 bytes"))
+			})
+		})
+
 		Context("when a job has a negative build_logs_to_retain", func() {
 			BeforeEach(func() {
 				job.BuildLogsToRetain = -1
@@ -459,6 +499,39 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a negative build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &BuildLogRetention{
+					Builds:                 -1,
+					Days:                   -2,
+					MinimumSucceededBuilds: -3,
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.builds: -1"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.days: -2"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.minimum_succeeded_builds: -3"))
+			})
+		})
+
+		Context("when a job has both build_logs_to_retain and build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogsToRetain = 10
+				job.BuildLogRetention = &BuildLogRetention{Days: 7}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has both build_logs_to_retain and build_log_retention"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -696,6 +769,55 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline: "some-pipeline",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify a config file"))
+				})
+			})
+
+			Context("when a set_pipeline plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-input/pipeline.yml",
+						Privileged:     true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (privileged)"))
+				})
+			})
+
+			Context("when a set_pipeline plan is valid", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-input/pipeline.yml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
 			Context("when a put plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1082,6 +1204,237 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has a valid condition", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+					})
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						If: &ConditionConfig{
+							Trigger:  ConditionTriggerManual,
+							Versions: map[string]Version{"some-resource": {"ref": "^v[0-9]+"}},
+							Steps:    map[string]BuildStatus{"some-resource": StatusSucceeded},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a plan has an invalid condition", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+						If: &ConditionConfig{
+							Trigger:  "sometimes",
+							Versions: map[string]Version{"some-resource": {"ref": "(nope"}},
+							Steps:    map[string]BuildStatus{"some-resource": StatusErrored},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.if.trigger has an invalid value ('sometimes')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.if.versions.some-resource.ref is not a valid regular expression ('(nope')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.if.steps.some-resource has an invalid status ('errored')"))
+				})
+			})
+
+			Context("when a plan has a condition on unknown steps", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+					})
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						If: &ConditionConfig{
+							Versions: map[string]Version{"some-task": {"ref": "^v"}},
+							Steps:    map[string]BuildStatus{"bogus-step": StatusFailed},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has a version condition on an unknown get or put step ('some-task')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has a condition on an unknown step ('bogus-step')"))
+				})
+			})
+
+			Context("when a plan has a condition on an instance of a matrix task", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Matrix: MatrixConfig{
+							"go_version": {"1.9", "1.10"},
+						},
+					})
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						If: &ConditionConfig{
+							Steps: map[string]BuildStatus{"some-task-1.10": StatusSucceeded},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a plan has a condition on a matrix task as a whole", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Matrix: MatrixConfig{
+							"go_version": {"1.9", "1.10"},
+						},
+					})
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						If: &ConditionConfig{
+							Steps: map[string]BuildStatus{"some-task": StatusSucceeded},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has a condition on matrix task 'some-task'; refer to one of its instances instead"))
+				})
+			})
+
+			Context("when an in_parallel plan has a negative limit and an invalid step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						InParallel: &InParallelConfig{
+							Limit: -1,
+							Steps: PlanSequence{
+								{
+									Get: "some-nonexistent-resource",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit must not be negative"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[0].get.some-nonexistent-resource refers to a resource that does not exist"))
+				})
+			})
+
+			Context("when a step has an invalid label selector", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:           "some-resource",
+						LabelSelector: LabelSelector{"gpu notin (true)", "zone = us-east-1a"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0] has an invalid label selector 'zone = us-east-1a'"))
+				})
+			})
+
+			Context("when a task plan has a matrix", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task: "some-task",
+						Matrix: MatrixConfig{
+							"go_version": {"1.9", "1.10"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("validates every expanded step", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.matrix[0].task.some-task-1.9 does not specify any task configuration"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.matrix[1].task.some-task-1.10 does not specify any task configuration"))
+				})
+			})
+
+			Context("when a matrix has missing or duplicate values", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Matrix: MatrixConfig{
+							"go_version": {"1.9", "1.9"},
+							"os":         {},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.matrix.go_version has a duplicate value ('1.9')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.matrix.os has no values"))
+				})
+			})
+
+			Context("when a matrix is empty", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Matrix:         MatrixConfig{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.matrix has no params"))
+				})
+			})
+
+			Context("when a non-task plan has a matrix", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+						Matrix: MatrixConfig{
+							"go_version": {"1.9"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.matrix is only supported on task steps"))
+				})
+			})
+
 			Context("when a plan has an invalid step within a try", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1148,6 +1501,37 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/other-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference another pipeline without naming a job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job in pipeline 'other-pipeline' without naming the job"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, PlanConfig{
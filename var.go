@@ -0,0 +1,11 @@
+package atc
+
+// Var is a variable held in a team's variable store. Only its name is ever
+// returned by the API.
+type Var struct {
+	Name string `json:"name"`
+}
+
+type SetVarRequest struct {
+	Value string `json:"value"`
+}
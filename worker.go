@@ -2,6 +2,7 @@ package atc
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 )
 
@@ -20,15 +21,33 @@ type Worker struct {
 	ActiveContainers int `json:"active_containers"`
 	ActiveVolumes    int `json:"active_volumes"`
 
+	MaxBuildContainers int `json:"max_build_containers,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
-	Platform  string   `json:"platform"`
-	Tags      []string `json:"tags"`
-	Team      string   `json:"team"`
-	Name      string   `json:"name"`
-	Version   string   `json:"version"`
-	StartTime int64    `json:"start_time"`
-	State     string   `json:"state"`
+	Platform  string            `json:"platform"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Team      string            `json:"team"`
+	Name      string            `json:"name"`
+	Version   string            `json:"version"`
+	StartTime int64             `json:"start_time"`
+	State     string            `json:"state"`
+}
+
+// WorkerDrainStatus describes what keeps a landing or retiring worker from
+// finishing.
+type WorkerDrainStatus struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+
+	// StartedAt is when the worker began landing or retiring, and Deadline
+	// when the builds still running on it will be aborted, if ever.
+	StartedAt int64 `json:"started_at,omitempty"`
+	Deadline  int64 `json:"deadline,omitempty"`
+
+	Builds     []Build     `json:"builds"`
+	Containers []Container `json:"containers"`
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
@@ -43,6 +62,12 @@ func (w Worker) Validate() error {
 		return ErrMissingWorkerGardenAddress
 	}
 
+	for key, value := range w.Labels {
+		if !ValidLabel(key) || !ValidLabel(value) {
+			return fmt.Errorf("invalid worker label '%s=%s', only alphanumeric characters, '_', '.', '/' and '-' are allowed", key, value)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,79 @@
+package worker
+
+import "sync"
+
+// capacityWaiters tracks the placements which are waiting for worker
+// capacity, so that capacity freed up on a worker goes to the highest priority
+// placement waiting for it rather than whichever happens to look first.
+//
+// The waiters are only known to the pool of this ATC, so with several ATCs a
+// lower priority placement on one can still take capacity claimed by a
+// higher priority placement waiting on another.
+type capacityWaiters struct {
+	lock    sync.Mutex
+	waiters map[*capacityWaiter]struct{}
+}
+
+type capacityWaiter struct {
+	priority int
+	workers  map[string]bool
+}
+
+func newCapacityWaiters() *capacityWaiters {
+	return &capacityWaiters{
+		waiters: map[*capacityWaiter]struct{}{},
+	}
+}
+
+func (ws *capacityWaiters) wait(priority int) *capacityWaiter {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+
+	waiter := &capacityWaiter{priority: priority}
+	ws.waiters[waiter] = struct{}{}
+
+	return waiter
+}
+
+func (ws *capacityWaiters) done(waiter *capacityWaiter) {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+
+	delete(ws.waiters, waiter)
+}
+
+// claim records the workers the waiter could be placed on once they have
+// capacity.
+func (ws *capacityWaiters) claim(waiter *capacityWaiter, workers []Worker) {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+
+	waiter.workers = map[string]bool{}
+	for _, worker := range workers {
+		waiter.workers[worker.Name()] = true
+	}
+}
+
+// unclaimed returns the workers which are not claimed by any waiter with a
+// higher priority.
+func (ws *capacityWaiters) unclaimed(priority int, workers []Worker) []Worker {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+
+	unclaimed := []Worker{}
+	for _, worker := range workers {
+		claimed := false
+		for waiter := range ws.waiters {
+			if waiter.priority > priority && waiter.workers[worker.Name()] {
+				claimed = true
+				break
+			}
+		}
+
+		if !claimed {
+			unclaimed = append(unclaimed, worker)
+		}
+	}
+
+	return unclaimed
+}
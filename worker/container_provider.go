@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
@@ -166,6 +167,10 @@ func (p *containerProvider) FindOrCreateContainer(
 			if creatingContainer == nil {
 				logger.Debug("creating-container-in-db")
 
+				if spec.Limits.Memory != nil {
+					metadata.MemoryLimit = *spec.Limits.Memory
+				}
+
 				creatingContainer, err = p.dbTeamFactory.GetByID(spec.TeamID).CreateContainer(
 					p.worker.Name(),
 					owner,
@@ -496,9 +501,24 @@ func (p *containerProvider) createGardenContainer(
 		BindMounts: bindMounts,
 		Env:        env,
 		Properties: gardenProperties,
+		Limits:     gardenLimits(spec.Limits),
 	})
 }
 
+func gardenLimits(limits atc.ContainerLimits) garden.Limits {
+	gardenLimits := garden.Limits{}
+
+	if limits.CPU != nil {
+		gardenLimits.CPU = garden.CPULimits{LimitInShares: *limits.CPU}
+	}
+
+	if limits.Memory != nil {
+		gardenLimits.Memory = garden.MemoryLimits{LimitInBytes: *limits.Memory}
+	}
+
+	return gardenLimits
+}
+
 func (p *containerProvider) anyMountTo(path string, inputs []InputSource) bool {
 	for _, input := range inputs {
 		if input.DestinationPath() == path {
@@ -459,6 +459,31 @@ var _ = Describe("ContainerProvider", func() {
 			}))
 		})
 
+		Context("when the spec has container limits", func() {
+			BeforeEach(func() {
+				cpu := uint64(512)
+				memory := uint64(1024 * 1024 * 1024)
+				containerSpec.Limits = atc.ContainerLimits{CPU: &cpu, Memory: &memory}
+			})
+
+			It("creates the container in garden with the limits", func() {
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+				actualSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualSpec.Limits).To(Equal(garden.Limits{
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Memory: garden.MemoryLimits{LimitInBytes: 1024 * 1024 * 1024},
+				}))
+			})
+
+			It("records the memory limit in the container's metadata", func() {
+				Expect(fakeDBTeam.CreateContainerCallCount()).To(Equal(1))
+
+				_, _, metadata := fakeDBTeam.CreateContainerArgsForCall(0)
+				Expect(metadata.MemoryLimit).To(Equal(uint64(1024 * 1024 * 1024)))
+			})
+		})
+
 		It("creates each volume unprivileged", func() {
 			Expect(volumeSpecs).To(Equal(map[string]VolumeSpec{
 				"/scratch":                    VolumeSpec{Strategy: baggageclaim.EmptyStrategy{}},
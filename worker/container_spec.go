@@ -13,14 +13,18 @@ type WorkerSpec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// LabelSelector restricts the workers to those whose labels it matches.
+	LabelSelector atc.LabelSelector
 }
 
 type ContainerSpec struct {
-	Platform  string
-	Tags      []string
-	TeamID    int
-	ImageSpec ImageSpec
-	Env       []string
+	Platform      string
+	Tags          []string
+	LabelSelector atc.LabelSelector
+	TeamID        int
+	ImageSpec     ImageSpec
+	Env           []string
 
 	// Working directory for processes run in the container.
 	Dir string
@@ -38,6 +42,9 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional limits on the container's CPU and memory.
+	Limits atc.ContainerLimits
 }
 
 // OutputPaths is a mapping from output name to its path in the container.
@@ -61,10 +68,11 @@ type ImageResource struct {
 
 func (spec ContainerSpec) WorkerSpec() WorkerSpec {
 	return WorkerSpec{
-		ResourceType: spec.ImageSpec.ResourceType,
-		Platform:     spec.Platform,
-		Tags:         spec.Tags,
-		TeamID:       spec.TeamID,
+		ResourceType:  spec.ImageSpec.ResourceType,
+		Platform:      spec.Platform,
+		Tags:          spec.Tags,
+		TeamID:        spec.TeamID,
+		LabelSelector: spec.LabelSelector,
 	}
 }
 
@@ -83,5 +91,9 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	for _, expression := range spec.LabelSelector {
+		attrs = append(attrs, fmt.Sprintf("label selector '%s'", expression))
+	}
+
 	return strings.Join(attrs, ", ")
 }
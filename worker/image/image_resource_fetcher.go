@@ -178,6 +178,7 @@ func (i *imageResourceFetcher) Fetch(
 		logger.Session("init-image"),
 		getSess,
 		i.worker.Tags(),
+		atc.LabelSelectorFor(i.worker.Labels()),
 		i.teamID,
 		i.customTypes,
 		resourceInstance,
@@ -89,6 +89,7 @@ var _ = Describe("Image", func() {
 		fakeImageFetchingDelegate.StderrReturns(stderrBuf)
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeWorker.TagsReturns(atc.Tags{"worker", "tags"})
+		fakeWorker.LabelsReturns(map[string]string{"arch": "arm64"})
 		teamID = 123
 
 		customTypes = creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
@@ -352,7 +353,7 @@ var _ = Describe("Image", func() {
 
 							It("fetches resource with correct session", func() {
 								Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
-								_, _, session, tags, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
+								_, _, session, tags, labelSelector, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
 								Expect(metadata).To(Equal(resource.EmptyMetadata{}))
 								Expect(session).To(Equal(resource.Session{
 									Metadata: db.ContainerMetadata{
@@ -360,6 +361,7 @@ var _ = Describe("Image", func() {
 									},
 								}))
 								Expect(tags).To(Equal(atc.Tags{"worker", "tags"}))
+								Expect(labelSelector).To(Equal(atc.LabelSelector{"arch in (arm64)"}))
 								Expect(actualTeamID).To(Equal(teamID))
 								Expect(resourceInstance).To(Equal(resource.NewResourceInstance(
 									"docker",
@@ -566,7 +568,7 @@ var _ = Describe("Image", func() {
 
 					It("fetches resource with correct session", func() {
 						Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
-						_, _, session, tags, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
+						_, _, session, tags, labelSelector, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
 						Expect(metadata).To(Equal(resource.EmptyMetadata{}))
 						Expect(session).To(Equal(resource.Session{
 							Metadata: db.ContainerMetadata{
@@ -574,6 +576,7 @@ var _ = Describe("Image", func() {
 							},
 						}))
 						Expect(tags).To(Equal(atc.Tags{"worker", "tags"}))
+						Expect(labelSelector).To(Equal(atc.LabelSelector{"arch in (arm64)"}))
 						Expect(actualTeamID).To(Equal(teamID))
 						Expect(resourceInstance).To(Equal(resource.NewResourceInstance(
 							"docker",
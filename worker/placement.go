@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"math"
 	"math/rand"
 	"time"
 )
@@ -9,17 +10,37 @@ type ContainerPlacementStrategy interface {
 	Choose([]Worker, ContainerSpec) (Worker, error)
 }
 
+// ChainablePlacementStrategy is a ContainerPlacementStrategy that can narrow
+// the workers down to those it considers equally suitable, so that it can be
+// followed by another strategy acting as a tie-breaker.
+type ChainablePlacementStrategy interface {
+	ContainerPlacementStrategy
+
+	Candidates([]Worker, ContainerSpec) ([]Worker, error)
+}
+
 type VolumeLocalityPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+func NewVolumeLocalityPlacementStrategy() ChainablePlacementStrategy {
 	return &VolumeLocalityPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+// Candidates returns the workers which have the most of the spec's inputs
+// available locally.
+func (strategy *VolumeLocalityPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
 	workersByCount := map[int][]Worker{}
 	var highestCount int
 	for _, w := range workers {
@@ -43,16 +64,14 @@ func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec C
 		}
 	}
 
-	highestLocalityWorkers := workersByCount[highestCount]
-
-	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+	return workersByCount[highestCount], nil
 }
 
 type RandomPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+func NewRandomPlacementStrategy() ChainablePlacementStrategy {
 	return &RandomPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
@@ -61,3 +80,140 @@ func NewRandomPlacementStrategy() ContainerPlacementStrategy {
 func (strategy *RandomPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
 	return workers[strategy.rand.Intn(len(workers))], nil
 }
+
+// Candidates returns all of the workers, as any of them is as good as any
+// other.
+func (strategy *RandomPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return workers, nil
+}
+
+type FewestBuildContainersPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewFewestBuildContainersPlacementStrategy() ChainablePlacementStrategy {
+	return &FewestBuildContainersPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+// Candidates returns the workers with the fewest active containers.
+func (strategy *FewestBuildContainersPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	var candidates []Worker
+	var lowestCount int
+	for _, w := range workers {
+		count := w.ActiveContainers()
+
+		if len(candidates) == 0 || count < lowestCount {
+			candidates = []Worker{w}
+			lowestCount = count
+		} else if count == lowestCount {
+			candidates = append(candidates, w)
+		}
+	}
+
+	return candidates, nil
+}
+
+type LeastLoadedPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewLeastLoadedPlacementStrategy() ChainablePlacementStrategy {
+	return &LeastLoadedPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *LeastLoadedPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+// Candidates returns the workers with the lowest load, where a worker's load
+// is its active containers, active volumes, and memory reserved by declared
+// container limits, each relative to the highest among the workers.
+func (strategy *LeastLoadedPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	var maxContainers, maxVolumes, maxMemory float64
+	for _, w := range workers {
+		maxContainers = math.Max(maxContainers, float64(w.ActiveContainers()))
+		maxVolumes = math.Max(maxVolumes, float64(w.ActiveVolumes()))
+		maxMemory = math.Max(maxMemory, float64(w.ReservedMemory()))
+	}
+
+	var candidates []Worker
+	var lowestLoad float64
+	for _, w := range workers {
+		load := ratio(float64(w.ActiveContainers()), maxContainers) +
+			ratio(float64(w.ActiveVolumes()), maxVolumes) +
+			ratio(float64(w.ReservedMemory()), maxMemory)
+
+		if len(candidates) == 0 || load < lowestLoad {
+			candidates = []Worker{w}
+			lowestLoad = load
+		} else if load == lowestLoad {
+			candidates = append(candidates, w)
+		}
+	}
+
+	return candidates, nil
+}
+
+type ChainedPlacementStrategy struct {
+	strategies []ChainablePlacementStrategy
+
+	rand *rand.Rand
+}
+
+// NewChainedPlacementStrategy applies each strategy in turn to the candidates
+// left by the previous one, choosing randomly among whichever remain.
+func NewChainedPlacementStrategy(strategies ...ChainablePlacementStrategy) ChainablePlacementStrategy {
+	return &ChainedPlacementStrategy{
+		strategies: strategies,
+
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *ChainedPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+func (strategy *ChainedPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	candidates := workers
+	for _, s := range strategy.strategies {
+		var err error
+		candidates, err = s.Candidates(candidates, spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return candidates, nil
+}
+
+func ratio(value float64, max float64) float64 {
+	if max == 0 {
+		return 0
+	}
+
+	return value / max
+}
@@ -1,6 +1,8 @@
 package worker_test
 
 import (
+	"errors"
+
 	. "github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 
@@ -9,6 +11,7 @@ import (
 )
 
 //go:generate counterfeiter . ContainerPlacementStrategy
+//go:generate counterfeiter . ChainablePlacementStrategy
 
 var (
 	strategy ContainerPlacementStrategy
@@ -208,3 +211,245 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("FewestBuildContainersPlacementStrategy", func() {
+	var (
+		busyWorker  *workerfakes.FakeWorker
+		idleWorker1 *workerfakes.FakeWorker
+		idleWorker2 *workerfakes.FakeWorker
+	)
+
+	BeforeEach(func() {
+		strategy = NewFewestBuildContainersPlacementStrategy()
+
+		busyWorker = new(workerfakes.FakeWorker)
+		busyWorker.ActiveContainersReturns(20)
+
+		idleWorker1 = new(workerfakes.FakeWorker)
+		idleWorker1.ActiveContainersReturns(5)
+
+		idleWorker2 = new(workerfakes.FakeWorker)
+		idleWorker2.ActiveContainersReturns(5)
+
+		workers = []Worker{busyWorker, idleWorker1, idleWorker2}
+	})
+
+	Describe("Choose", func() {
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(workers, spec)
+		})
+
+		It("creates it on a random one of the workers with the fewest containers", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(SatisfyAny(Equal(idleWorker1), Equal(idleWorker2)))
+
+			workerChoiceCounts := map[Worker]int{}
+
+			for i := 0; i < 100; i++ {
+				worker, err := strategy.Choose(workers, spec)
+				Expect(err).ToNot(HaveOccurred())
+				workerChoiceCounts[worker]++
+			}
+
+			Expect(workerChoiceCounts[idleWorker1]).ToNot(BeZero())
+			Expect(workerChoiceCounts[idleWorker2]).ToNot(BeZero())
+			Expect(workerChoiceCounts[busyWorker]).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("LeastLoadedPlacementStrategy", func() {
+	var (
+		candidates    []Worker
+		candidatesErr error
+
+		worker1 *workerfakes.FakeWorker
+		worker2 *workerfakes.FakeWorker
+		worker3 *workerfakes.FakeWorker
+	)
+
+	BeforeEach(func() {
+		strategy = NewLeastLoadedPlacementStrategy()
+
+		worker1 = new(workerfakes.FakeWorker)
+		worker2 = new(workerfakes.FakeWorker)
+		worker3 = new(workerfakes.FakeWorker)
+
+		workers = []Worker{worker1, worker2, worker3}
+	})
+
+	Describe("Candidates", func() {
+		JustBeforeEach(func() {
+			candidates, candidatesErr = strategy.(ChainablePlacementStrategy).Candidates(workers, spec)
+		})
+
+		Context("when the workers are equally loaded", func() {
+			BeforeEach(func() {
+				for _, w := range []*workerfakes.FakeWorker{worker1, worker2, worker3} {
+					w.ActiveContainersReturns(10)
+					w.ActiveVolumesReturns(50)
+					w.ReservedMemoryReturns(1024)
+				}
+			})
+
+			It("returns all of them", func() {
+				Expect(candidatesErr).ToNot(HaveOccurred())
+				Expect(candidates).To(ConsistOf(worker1, worker2, worker3))
+			})
+		})
+
+		Context("when a worker has fewer containers", func() {
+			BeforeEach(func() {
+				worker1.ActiveContainersReturns(10)
+				worker2.ActiveContainersReturns(5)
+				worker3.ActiveContainersReturns(10)
+			})
+
+			It("returns it", func() {
+				Expect(candidatesErr).ToNot(HaveOccurred())
+				Expect(candidates).To(ConsistOf(worker2))
+			})
+		})
+
+		Context("when a worker with fewer containers has many more volumes and reserved memory", func() {
+			BeforeEach(func() {
+				worker1.ActiveContainersReturns(10)
+				worker1.ActiveVolumesReturns(10)
+				worker1.ReservedMemoryReturns(1024)
+
+				worker2.ActiveContainersReturns(9)
+				worker2.ActiveVolumesReturns(100)
+				worker2.ReservedMemoryReturns(8 * 1024)
+
+				worker3.ActiveContainersReturns(10)
+				worker3.ActiveVolumesReturns(100)
+				worker3.ReservedMemoryReturns(8 * 1024)
+			})
+
+			It("returns the worker with the lowest load overall", func() {
+				Expect(candidatesErr).ToNot(HaveOccurred())
+				Expect(candidates).To(ConsistOf(worker1))
+			})
+		})
+
+		Context("when none of the workers have any load", func() {
+			It("returns all of them", func() {
+				Expect(candidatesErr).ToNot(HaveOccurred())
+				Expect(candidates).To(ConsistOf(worker1, worker2, worker3))
+			})
+		})
+	})
+
+	Describe("Choose", func() {
+		BeforeEach(func() {
+			worker1.ReservedMemoryReturns(2048)
+			worker2.ReservedMemoryReturns(1024)
+			worker3.ReservedMemoryReturns(4096)
+		})
+
+		It("creates it on the least loaded worker", func() {
+			chosenWorker, chooseErr = strategy.Choose(workers, spec)
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(Equal(worker2))
+		})
+	})
+})
+
+var _ = Describe("ChainedPlacementStrategy", func() {
+	var (
+		fakeFirst  *workerfakes.FakeChainablePlacementStrategy
+		fakeSecond *workerfakes.FakeChainablePlacementStrategy
+
+		worker1 *workerfakes.FakeWorker
+		worker2 *workerfakes.FakeWorker
+		worker3 *workerfakes.FakeWorker
+	)
+
+	BeforeEach(func() {
+		fakeFirst = new(workerfakes.FakeChainablePlacementStrategy)
+		fakeSecond = new(workerfakes.FakeChainablePlacementStrategy)
+
+		strategy = NewChainedPlacementStrategy(fakeFirst, fakeSecond)
+
+		worker1 = new(workerfakes.FakeWorker)
+		worker2 = new(workerfakes.FakeWorker)
+		worker3 = new(workerfakes.FakeWorker)
+
+		workers = []Worker{worker1, worker2, worker3}
+
+		fakeFirst.CandidatesReturns([]Worker{worker1, worker2}, nil)
+		fakeSecond.CandidatesReturns([]Worker{worker2}, nil)
+	})
+
+	Describe("Choose", func() {
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(workers, spec)
+		})
+
+		It("narrows the workers with each strategy in turn", func() {
+			Expect(fakeFirst.CandidatesCallCount()).To(Equal(1))
+			firstWorkers, _ := fakeFirst.CandidatesArgsForCall(0)
+			Expect(firstWorkers).To(Equal(workers))
+
+			Expect(fakeSecond.CandidatesCallCount()).To(Equal(1))
+			secondWorkers, _ := fakeSecond.CandidatesArgsForCall(0)
+			Expect(secondWorkers).To(Equal([]Worker{worker1, worker2}))
+		})
+
+		It("chooses from the remaining workers", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(Equal(worker2))
+		})
+
+		Context("when a strategy fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeFirst.CandidatesReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(chooseErr).To(Equal(disaster))
+			})
+
+			It("does not apply the remaining strategies", func() {
+				Expect(fakeSecond.CandidatesCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when volume locality is followed by least loaded", func() {
+		BeforeEach(func() {
+			fakeInput := new(workerfakes.FakeInputSource)
+			fakeInputAS := new(workerfakes.FakeArtifactSource)
+			fakeInputAS.VolumeOnStub = func(worker Worker) (Volume, bool, error) {
+				switch worker {
+				case worker1, worker2:
+					return new(workerfakes.FakeVolume), true, nil
+				default:
+					return nil, false, nil
+				}
+			}
+			fakeInput.SourceReturns(fakeInputAS)
+
+			spec = ContainerSpec{
+				Inputs: []InputSource{fakeInput},
+			}
+
+			worker1.ActiveContainersReturns(10)
+			worker2.ActiveContainersReturns(20)
+			worker3.ActiveContainersReturns(1)
+
+			strategy = NewChainedPlacementStrategy(
+				NewVolumeLocalityPlacementStrategy(),
+				NewLeastLoadedPlacementStrategy(),
+			)
+		})
+
+		It("breaks locality ties by load", func() {
+			chosenWorker, chooseErr = strategy.Choose(workers, spec)
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(Equal(worker1))
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -64,18 +65,33 @@ func (err NoCompatibleWorkersError) Error() string {
 	)
 }
 
+// WorkerCapacityRetryInterval is how often placement is retried while every
+// compatible worker, or the team, is at its container quota.
+const WorkerCapacityRetryInterval = 5 * time.Second
+
 type pool struct {
-	provider WorkerProvider
+	provider    WorkerProvider
+	teamFactory db.TeamFactory
+	clock       clock.Clock
+	waiters     *capacityWaiters
 
 	rand     *rand.Rand
 	strategy ContainerPlacementStrategy
 }
 
-func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
+func NewPool(
+	provider WorkerProvider,
+	teamFactory db.TeamFactory,
+	strategy ContainerPlacementStrategy,
+	clock clock.Clock,
+) Client {
 	return &pool{
-		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
-		strategy: strategy,
+		provider:    provider,
+		teamFactory: teamFactory,
+		clock:       clock,
+		waiters:     newCapacityWaiters(),
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategy:    strategy,
 	}
 }
 
@@ -148,12 +164,7 @@ func (pool *pool) FindOrCreateContainer(
 	}
 
 	if !found {
-		compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
-		if err != nil {
-			return nil, err
-		}
-
-		worker, err = pool.strategy.Choose(compatibleWorkers, spec)
+		worker, err = pool.chooseWorkerWithCapacity(ctx, logger, delegate, metadata, spec, resourceTypes)
 		if err != nil {
 			return nil, err
 		}
@@ -170,6 +181,114 @@ func (pool *pool) FindOrCreateContainer(
 	)
 }
 
+// chooseWorkerWithCapacity chooses among the compatible workers which are
+// below their build container quota, provided the team is below its container
+// quota. Until then it waits, retrying every WorkerCapacityRetryInterval.
+//
+// While waiting, the compatible workers are claimed for the container, so that
+// capacity freed up on them is not taken by containers of a lower priority.
+//
+// Only build step containers are subject to the quotas; other containers,
+// e.g. for resource checks, are placed on any compatible worker right away.
+func (pool *pool) chooseWorkerWithCapacity(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate ImageFetchingDelegate,
+	metadata db.ContainerMetadata,
+	spec ContainerSpec,
+	resourceTypes creds.VersionedResourceTypes,
+) (Worker, error) {
+	var (
+		waiter       *capacityWaiter
+		waitingSince time.Time
+	)
+
+	if metadata.BuildID == 0 {
+		compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		return pool.strategy.Choose(compatibleWorkers, spec)
+	}
+
+	defer func() {
+		if waiter != nil {
+			pool.waiters.done(waiter)
+		}
+	}()
+
+	for {
+		availableWorkers, claimableWorkers, err := pool.workersWithCapacity(logger, spec, resourceTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		availableWorkers = pool.waiters.unclaimed(metadata.Priority, availableWorkers)
+
+		if len(availableWorkers) > 0 {
+			if waiter != nil {
+				metric.WorkerCapacityWaitDuration{
+					TeamID:   spec.TeamID,
+					Duration: pool.clock.Since(waitingSince),
+				}.Emit(logger)
+			}
+
+			return pool.strategy.Choose(availableWorkers, spec)
+		}
+
+		if waiter == nil {
+			logger.Info("waiting-for-worker-capacity", lager.Data{"priority": metadata.Priority})
+			fmt.Fprintln(delegate.Stdout(), "waiting for worker capacity...")
+
+			waiter = pool.waiters.wait(metadata.Priority)
+			waitingSince = pool.clock.Now()
+		}
+
+		pool.waiters.claim(waiter, claimableWorkers)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-pool.clock.After(WorkerCapacityRetryInterval):
+		}
+	}
+}
+
+// workersWithCapacity returns the compatible workers which are below their
+// build container quota, along with the compatible workers which are worth
+// waiting for. Neither are returned if the team is at its container quota.
+func (pool *pool) workersWithCapacity(
+	logger lager.Logger,
+	spec ContainerSpec,
+	resourceTypes creds.VersionedResourceTypes,
+) ([]Worker, []Worker, error) {
+	compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quotaReached, err := pool.teamFactory.GetByID(spec.TeamID).ContainerQuotaReached()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if quotaReached {
+		return nil, nil, nil
+	}
+
+	availableWorkers := []Worker{}
+	for _, worker := range compatibleWorkers {
+		if worker.MaxBuildContainers() > 0 && worker.BuildContainers() >= worker.MaxBuildContainers() {
+			continue
+		}
+
+		availableWorkers = append(availableWorkers, worker)
+	}
+
+	return availableWorkers, compatibleWorkers, nil
+}
+
 func (pool *pool) FindContainerByHandle(logger lager.Logger, teamID int, handle string) (Container, bool, error) {
 	worker, found, err := pool.provider.FindWorkerForContainer(
 		logger.Session("find-worker"),
@@ -3,7 +3,9 @@ package worker_test
 import (
 	"context"
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
@@ -15,6 +17,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Pool", func() {
@@ -22,6 +25,8 @@ var _ = Describe("Pool", func() {
 		logger       *lagertest.TestLogger
 		fakeProvider *workerfakes.FakeWorkerProvider
 		fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+		fakeTeam     *dbfakes.FakeTeam
+		fakeClock    *fakeclock.FakeClock
 		pool         Client
 	)
 
@@ -30,7 +35,13 @@ var _ = Describe("Pool", func() {
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
-		pool = NewPool(fakeProvider, fakeStrategy)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory := new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		pool = NewPool(fakeProvider, fakeTeamFactory, fakeStrategy, fakeClock)
 	})
 
 	Describe("Satisfying", func() {
@@ -541,6 +552,14 @@ var _ = Describe("Pool", func() {
 						incompatibleWorker,
 						compatibleWorker,
 					}, nil)
+
+					fakeStrategy.ChooseReturns(compatibleWorker, nil)
+
+					metadata = db.ContainerMetadata{BuildID: 42}
+				})
+
+				AfterEach(func() {
+					metadata = db.ContainerMetadata{}
 				})
 
 				Context("when strategy returns a worker", func() {
@@ -556,6 +575,240 @@ var _ = Describe("Pool", func() {
 					})
 				})
 
+				It("chooses among the compatible workers", func() {
+					Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+					workers, _ := fakeStrategy.ChooseArgsForCall(0)
+					Expect(workers).To(Equal([]Worker{compatibleWorker}))
+				})
+
+				It("checks the team's container quota", func() {
+					Expect(fakeTeam.ContainerQuotaReachedCallCount()).To(Equal(1))
+				})
+
+				Context("when checking the team's container quota fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeTeam.ContainerQuotaReachedReturns(false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(createErr).To(Equal(disaster))
+					})
+				})
+
+				Context("when a compatible worker is at its build container quota", func() {
+					var otherCompatibleWorker *workerfakes.FakeWorker
+
+					BeforeEach(func() {
+						compatibleWorker.MaxBuildContainersReturns(10)
+						compatibleWorker.BuildContainersReturns(10)
+
+						otherCompatibleWorker = new(workerfakes.FakeWorker)
+						otherCompatibleWorker.SatisfyingReturns(otherCompatibleWorker, nil)
+						otherCompatibleWorker.MaxBuildContainersReturns(10)
+						otherCompatibleWorker.BuildContainersReturns(9)
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							incompatibleWorker,
+							compatibleWorker,
+							otherCompatibleWorker,
+						}, nil)
+
+						fakeStrategy.ChooseReturns(otherCompatibleWorker, nil)
+						otherCompatibleWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+					})
+
+					It("chooses among the workers below their quota", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+
+						workers, _ := fakeStrategy.ChooseArgsForCall(0)
+						Expect(workers).To(Equal([]Worker{otherCompatibleWorker}))
+					})
+				})
+
+				Context("when every compatible worker is at its build container quota", func() {
+					BeforeEach(func() {
+						compatibleWorker.MaxBuildContainersReturns(10)
+						compatibleWorker.BuildContainersReturns(10)
+
+						fakeImageFetchingDelegate.StdoutReturns(gbytes.NewBuffer())
+					})
+
+					Context("until the context is canceled", func() {
+						BeforeEach(func() {
+							var cancel func()
+							ctx, cancel = context.WithCancel(ctx)
+
+							go func() {
+								fakeClock.WaitForWatcherAndIncrement(WorkerCapacityRetryInterval)
+								fakeClock.WaitForWatcherAndIncrement(WorkerCapacityRetryInterval)
+								cancel()
+							}()
+						})
+
+						It("keeps waiting and then returns the context's error", func() {
+							Expect(createErr).To(Equal(context.Canceled))
+							Expect(fakeProvider.RunningWorkersCallCount()).To(BeNumerically(">=", 2))
+							Expect(fakeStrategy.ChooseCallCount()).To(BeZero())
+						})
+
+						It("says it is waiting for worker capacity", func() {
+							Expect(fakeImageFetchingDelegate.Stdout()).To(gbytes.Say("waiting for worker capacity"))
+						})
+					})
+
+					Context("until a worker has capacity", func() {
+						BeforeEach(func() {
+							compatibleWorker.BuildContainersReturnsOnCall(0, 10)
+							compatibleWorker.BuildContainersReturnsOnCall(1, 9)
+
+							go fakeClock.WaitForWatcherAndIncrement(WorkerCapacityRetryInterval)
+						})
+
+						It("places the container on it", func() {
+							Expect(createErr).ToNot(HaveOccurred())
+							Expect(fakeProvider.RunningWorkersCallCount()).To(Equal(2))
+							Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when a higher priority container is waiting for capacity on the worker", func() {
+					var (
+						cancel      func()
+						waitingErrs chan error
+					)
+
+					BeforeEach(func() {
+						compatibleWorker.NameReturns("some-worker")
+						compatibleWorker.MaxBuildContainersReturns(10)
+						compatibleWorker.BuildContainersReturns(10)
+
+						fakeImageFetchingDelegate.StdoutReturns(gbytes.NewBuffer())
+
+						waitingErrs = make(chan error, 1)
+						go func() {
+							_, err := pool.FindOrCreateContainer(
+								context.Background(),
+								logger,
+								fakeImageFetchingDelegate,
+								fakeOwner,
+								db.ContainerMetadata{BuildID: 43, Priority: 10},
+								spec,
+								resourceTypes,
+							)
+							waitingErrs <- err
+						}()
+
+						Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+						compatibleWorker.BuildContainersReturns(9)
+
+						ctx, cancel = context.WithCancel(ctx)
+						go func() {
+							defer GinkgoRecover()
+
+							Eventually(fakeClock.WatcherCount).Should(Equal(2))
+							cancel()
+						}()
+					})
+
+					It("leaves the freed capacity to it", func() {
+						Expect(createErr).To(Equal(context.Canceled))
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(BeZero())
+
+						fakeClock.Increment(WorkerCapacityRetryInterval)
+
+						Eventually(waitingErrs).Should(Receive(BeNil()))
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when a lower priority container is waiting for capacity on the worker", func() {
+					var cancelWaiting func()
+
+					BeforeEach(func() {
+						compatibleWorker.NameReturns("some-worker")
+						compatibleWorker.MaxBuildContainersReturns(10)
+						compatibleWorker.BuildContainersReturns(10)
+
+						fakeImageFetchingDelegate.StdoutReturns(gbytes.NewBuffer())
+
+						var waitingCtx context.Context
+						waitingCtx, cancelWaiting = context.WithCancel(context.Background())
+
+						go pool.FindOrCreateContainer(
+							waitingCtx,
+							logger,
+							fakeImageFetchingDelegate,
+							fakeOwner,
+							db.ContainerMetadata{BuildID: 43, Priority: 1},
+							spec,
+							resourceTypes,
+						)
+
+						Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+						compatibleWorker.BuildContainersReturns(9)
+
+						metadata = db.ContainerMetadata{BuildID: 42, Priority: 10}
+					})
+
+					AfterEach(func() {
+						cancelWaiting()
+					})
+
+					It("takes the freed capacity", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(createdContainer).To(Equal(fakeContainer))
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the team is at its container quota", func() {
+					BeforeEach(func() {
+						fakeTeam.ContainerQuotaReachedReturnsOnCall(0, true, nil)
+						fakeTeam.ContainerQuotaReachedReturnsOnCall(1, false, nil)
+
+						fakeImageFetchingDelegate.StdoutReturns(gbytes.NewBuffer())
+
+						go fakeClock.WaitForWatcherAndIncrement(WorkerCapacityRetryInterval)
+					})
+
+					It("waits for worker capacity", func() {
+						Expect(fakeImageFetchingDelegate.Stdout()).To(gbytes.Say("waiting for worker capacity"))
+					})
+
+					It("places the container once the team is below its quota", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(fakeTeam.ContainerQuotaReachedCallCount()).To(Equal(2))
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the container is not for a build step", func() {
+					BeforeEach(func() {
+						metadata = db.ContainerMetadata{Type: db.ContainerTypeCheck}
+
+						fakeTeam.ContainerQuotaReachedReturns(true, nil)
+						compatibleWorker.MaxBuildContainersReturns(10)
+						compatibleWorker.BuildContainersReturns(10)
+					})
+
+					It("places the container without waiting for capacity", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(createdContainer).To(Equal(fakeContainer))
+
+						workers, _ := fakeStrategy.ChooseArgsForCall(0)
+						Expect(workers).To(Equal([]Worker{compatibleWorker}))
+					})
+
+					It("does not check the team's container quota", func() {
+						Expect(fakeTeam.ContainerQuotaReachedCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when strategy errors", func() {
 					var (
 						strategyError error
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 var ErrUnsupportedResourceType = errors.New("unsupported resource type")
 var ErrIncompatiblePlatform = errors.New("incompatible platform")
 var ErrMismatchedTags = errors.New("mismatched tags")
+var ErrMismatchedLabels = errors.New("mismatched labels")
 var ErrNoVolumeManager = errors.New("worker does not support volume management")
 var ErrTeamMismatch = errors.New("mismatched team")
 var ErrNotImplemented = errors.New("Not implemented")
@@ -48,11 +50,16 @@ type Worker interface {
 	Client
 
 	ActiveContainers() int
+	ActiveVolumes() int
+	ReservedMemory() uint64
+	BuildContainers() int
+	MaxBuildContainers() int
 
 	Description() string
 	Name() string
 	ResourceTypes() []atc.WorkerResourceType
 	Tags() atc.Tags
+	Labels() map[string]string
 	Uptime() time.Duration
 	IsOwnedByTeam() bool
 	IsVersionCompatible(lager.Logger, *version.Version) bool
@@ -75,14 +82,19 @@ type gardenWorker struct {
 
 	clock clock.Clock
 
-	activeContainers int
-	resourceTypes    []atc.WorkerResourceType
-	platform         string
-	tags             atc.Tags
-	teamID           int
-	name             string
-	startTime        int64
-	version          *string
+	activeContainers   int
+	activeVolumes      int
+	reservedMemory     uint64
+	buildContainers    int
+	maxBuildContainers int
+	resourceTypes      []atc.WorkerResourceType
+	platform           string
+	tags               atc.Tags
+	labels             map[string]string
+	teamID             int
+	name               string
+	startTime          int64
+	version            *string
 }
 
 func NewGardenWorker(
@@ -100,15 +112,20 @@ func NewGardenWorker(
 		volumeClient:       volumeClient,
 		containerProvider:  containerProvider,
 
-		clock:            clock,
-		activeContainers: dbWorker.ActiveContainers(),
-		resourceTypes:    dbWorker.ResourceTypes(),
-		platform:         dbWorker.Platform(),
-		tags:             dbWorker.Tags(),
-		teamID:           dbWorker.TeamID(),
-		name:             dbWorker.Name(),
-		startTime:        dbWorker.StartTime(),
-		version:          dbWorker.Version(),
+		clock:              clock,
+		activeContainers:   dbWorker.ActiveContainers(),
+		activeVolumes:      dbWorker.ActiveVolumes(),
+		reservedMemory:     dbWorker.ReservedMemory(),
+		buildContainers:    dbWorker.BuildContainers(),
+		maxBuildContainers: dbWorker.MaxBuildContainers(),
+		resourceTypes:      dbWorker.ResourceTypes(),
+		platform:           dbWorker.Platform(),
+		tags:               dbWorker.Tags(),
+		labels:             dbWorker.Labels(),
+		teamID:             dbWorker.TeamID(),
+		name:               dbWorker.Name(),
+		startTime:          dbWorker.StartTime(),
+		version:            dbWorker.Version(),
 	}
 }
 
@@ -210,6 +227,22 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) ActiveVolumes() int {
+	return worker.activeVolumes
+}
+
+func (worker *gardenWorker) ReservedMemory() uint64 {
+	return worker.reservedMemory
+}
+
+func (worker *gardenWorker) BuildContainers() int {
+	return worker.buildContainers
+}
+
+func (worker *gardenWorker) MaxBuildContainers() int {
+	return worker.maxBuildContainers
+}
+
 func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes creds.VersionedResourceTypes) (Worker, error) {
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
@@ -241,6 +274,10 @@ func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, res
 		return nil, ErrMismatchedTags
 	}
 
+	if !spec.LabelSelector.Matches(worker.labels) {
+		return nil, ErrMismatchedLabels
+	}
+
 	return worker, nil
 }
 
@@ -280,6 +317,10 @@ func (worker *gardenWorker) Description() string {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	for _, key := range worker.labelKeys() {
+		messages = append(messages, fmt.Sprintf("label '%s=%s'", key, worker.labels[key]))
+	}
+
 	return strings.Join(messages, ", ")
 }
 
@@ -295,6 +336,10 @@ func (worker *gardenWorker) Tags() atc.Tags {
 	return worker.tags
 }
 
+func (worker *gardenWorker) Labels() map[string]string {
+	return worker.labels
+}
+
 func (worker *gardenWorker) IsOwnedByTeam() bool {
 	return worker.teamID != 0
 }
@@ -322,6 +367,17 @@ insert_coin:
 	return true
 }
 
+func (worker *gardenWorker) labelKeys() []string {
+	keys := []string{}
+	for key := range worker.labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
 type artifactDestination struct {
 	destination Volume
 }
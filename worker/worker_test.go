@@ -33,6 +33,7 @@ var _ = Describe("Worker", func() {
 		resourceTypes              []atc.WorkerResourceType
 		platform                   string
 		tags                       atc.Tags
+		labels                     map[string]string
 		teamID                     int
 		workerName                 string
 		workerStartTime            int64
@@ -58,6 +59,7 @@ var _ = Describe("Worker", func() {
 		}
 		platform = "some-platform"
 		tags = atc.Tags{"some", "tags"}
+		labels = map[string]string{"arch": "arm64", "gpu": "false"}
 		teamID = 17
 		workerName = "some-worker"
 		workerStartTime = fakeClock.Now().Unix()
@@ -77,6 +79,7 @@ var _ = Describe("Worker", func() {
 		dbWorker.ResourceTypesReturns(resourceTypes)
 		dbWorker.PlatformReturns(platform)
 		dbWorker.TagsReturns(tags)
+		dbWorker.LabelsReturns(labels)
 		dbWorker.TeamIDReturns(teamID)
 		dbWorker.NameReturns(workerName)
 		dbWorker.StartTimeReturns(workerStartTime)
@@ -390,6 +393,30 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the label selector matches the worker's labels", func() {
+			BeforeEach(func() {
+				spec.LabelSelector = atc.LabelSelector{"arch in (amd64, arm64)", "gpu notin (true)"}
+			})
+
+			It("returns the worker", func() {
+				Expect(satisfyingWorker).To(Equal(gardenWorker))
+			})
+
+			It("returns no error", func() {
+				Expect(satisfyingErr).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the label selector does not match the worker's labels", func() {
+			BeforeEach(func() {
+				spec.LabelSelector = atc.LabelSelector{"arch in (arm64)", "zone"}
+			})
+
+			It("returns ErrMismatchedLabels", func() {
+				Expect(satisfyingErr).To(Equal(ErrMismatchedLabels))
+			})
+		})
+
 		Context("when the resource type is a custom type supported by the worker", func() {
 			BeforeEach(func() {
 				spec.ResourceType = "custom-type-c"
@@ -134,6 +134,42 @@ type FakeWorker struct {
 	activeContainersReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ActiveVolumesStub        func() int
+	activeVolumesMutex       sync.RWMutex
+	activeVolumesArgsForCall []struct{}
+	activeVolumesReturns     struct {
+		result1 int
+	}
+	activeVolumesReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ReservedMemoryStub        func() uint64
+	reservedMemoryMutex       sync.RWMutex
+	reservedMemoryArgsForCall []struct{}
+	reservedMemoryReturns     struct {
+		result1 uint64
+	}
+	reservedMemoryReturnsOnCall map[int]struct {
+		result1 uint64
+	}
+	BuildContainersStub        func() int
+	buildContainersMutex       sync.RWMutex
+	buildContainersArgsForCall []struct{}
+	buildContainersReturns     struct {
+		result1 int
+	}
+	buildContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
+	MaxBuildContainersStub        func() int
+	maxBuildContainersMutex       sync.RWMutex
+	maxBuildContainersArgsForCall []struct{}
+	maxBuildContainersReturns     struct {
+		result1 int
+	}
+	maxBuildContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
 	DescriptionStub        func() string
 	descriptionMutex       sync.RWMutex
 	descriptionArgsForCall []struct{}
@@ -170,6 +206,15 @@ type FakeWorker struct {
 	tagsReturnsOnCall map[int]struct {
 		result1 atc.Tags
 	}
+	LabelsStub        func() map[string]string
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct{}
+	labelsReturns     struct {
+		result1 map[string]string
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
 	UptimeStub        func() time.Duration
 	uptimeMutex       sync.RWMutex
 	uptimeArgsForCall []struct{}
@@ -688,6 +733,166 @@ func (fake *FakeWorker) ActiveContainersReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ActiveVolumes() int {
+	fake.activeVolumesMutex.Lock()
+	ret, specificReturn := fake.activeVolumesReturnsOnCall[len(fake.activeVolumesArgsForCall)]
+	fake.activeVolumesArgsForCall = append(fake.activeVolumesArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveVolumes", []interface{}{})
+	fake.activeVolumesMutex.Unlock()
+	if fake.ActiveVolumesStub != nil {
+		return fake.ActiveVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.activeVolumesReturns.result1
+}
+
+func (fake *FakeWorker) ActiveVolumesCallCount() int {
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	return len(fake.activeVolumesArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveVolumesReturns(result1 int) {
+	fake.ActiveVolumesStub = nil
+	fake.activeVolumesReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
+	fake.ActiveVolumesStub = nil
+	if fake.activeVolumesReturnsOnCall == nil {
+		fake.activeVolumesReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.activeVolumesReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ReservedMemory() uint64 {
+	fake.reservedMemoryMutex.Lock()
+	ret, specificReturn := fake.reservedMemoryReturnsOnCall[len(fake.reservedMemoryArgsForCall)]
+	fake.reservedMemoryArgsForCall = append(fake.reservedMemoryArgsForCall, struct{}{})
+	fake.recordInvocation("ReservedMemory", []interface{}{})
+	fake.reservedMemoryMutex.Unlock()
+	if fake.ReservedMemoryStub != nil {
+		return fake.ReservedMemoryStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.reservedMemoryReturns.result1
+}
+
+func (fake *FakeWorker) ReservedMemoryCallCount() int {
+	fake.reservedMemoryMutex.RLock()
+	defer fake.reservedMemoryMutex.RUnlock()
+	return len(fake.reservedMemoryArgsForCall)
+}
+
+func (fake *FakeWorker) ReservedMemoryReturns(result1 uint64) {
+	fake.ReservedMemoryStub = nil
+	fake.reservedMemoryReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) ReservedMemoryReturnsOnCall(i int, result1 uint64) {
+	fake.ReservedMemoryStub = nil
+	if fake.reservedMemoryReturnsOnCall == nil {
+		fake.reservedMemoryReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.reservedMemoryReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *FakeWorker) BuildContainers() int {
+	fake.buildContainersMutex.Lock()
+	ret, specificReturn := fake.buildContainersReturnsOnCall[len(fake.buildContainersArgsForCall)]
+	fake.buildContainersArgsForCall = append(fake.buildContainersArgsForCall, struct{}{})
+	fake.recordInvocation("BuildContainers", []interface{}{})
+	fake.buildContainersMutex.Unlock()
+	if fake.BuildContainersStub != nil {
+		return fake.BuildContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.buildContainersReturns.result1
+}
+
+func (fake *FakeWorker) BuildContainersCallCount() int {
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	return len(fake.buildContainersArgsForCall)
+}
+
+func (fake *FakeWorker) BuildContainersReturns(result1 int) {
+	fake.BuildContainersStub = nil
+	fake.buildContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) BuildContainersReturnsOnCall(i int, result1 int) {
+	fake.BuildContainersStub = nil
+	if fake.buildContainersReturnsOnCall == nil {
+		fake.buildContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.buildContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxBuildContainers() int {
+	fake.maxBuildContainersMutex.Lock()
+	ret, specificReturn := fake.maxBuildContainersReturnsOnCall[len(fake.maxBuildContainersArgsForCall)]
+	fake.maxBuildContainersArgsForCall = append(fake.maxBuildContainersArgsForCall, struct{}{})
+	fake.recordInvocation("MaxBuildContainers", []interface{}{})
+	fake.maxBuildContainersMutex.Unlock()
+	if fake.MaxBuildContainersStub != nil {
+		return fake.MaxBuildContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.maxBuildContainersReturns.result1
+}
+
+func (fake *FakeWorker) MaxBuildContainersCallCount() int {
+	fake.maxBuildContainersMutex.RLock()
+	defer fake.maxBuildContainersMutex.RUnlock()
+	return len(fake.maxBuildContainersArgsForCall)
+}
+
+func (fake *FakeWorker) MaxBuildContainersReturns(result1 int) {
+	fake.MaxBuildContainersStub = nil
+	fake.maxBuildContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxBuildContainersReturnsOnCall(i int, result1 int) {
+	fake.MaxBuildContainersStub = nil
+	if fake.maxBuildContainersReturnsOnCall == nil {
+		fake.maxBuildContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxBuildContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) Description() string {
 	fake.descriptionMutex.Lock()
 	ret, specificReturn := fake.descriptionReturnsOnCall[len(fake.descriptionArgsForCall)]
@@ -848,6 +1053,46 @@ func (fake *FakeWorker) TagsReturnsOnCall(i int, result1 atc.Tags) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Labels() map[string]string {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct{}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.labelsReturns.result1
+}
+
+func (fake *FakeWorker) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakeWorker) LabelsReturns(result1 map[string]string) {
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeWorker) LabelsReturnsOnCall(i int, result1 map[string]string) {
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
 func (fake *FakeWorker) Uptime() time.Duration {
 	fake.uptimeMutex.Lock()
 	ret, specificReturn := fake.uptimeReturnsOnCall[len(fake.uptimeArgsForCall)]
@@ -1243,6 +1488,14 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.runningWorkersMutex.RUnlock()
 	fake.activeContainersMutex.RLock()
 	defer fake.activeContainersMutex.RUnlock()
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	fake.reservedMemoryMutex.RLock()
+	defer fake.reservedMemoryMutex.RUnlock()
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	fake.maxBuildContainersMutex.RLock()
+	defer fake.maxBuildContainersMutex.RUnlock()
 	fake.descriptionMutex.RLock()
 	defer fake.descriptionMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -1251,6 +1504,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
 	fake.uptimeMutex.RLock()
 	defer fake.uptimeMutex.RUnlock()
 	fake.isOwnedByTeamMutex.RLock()
@@ -49,6 +49,33 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when labels are valid", func() {
+			BeforeEach(func() {
+				worker.Labels = map[string]string{
+					"arch":             "arm64",
+					"example.com/zone": "us-east-1a",
+				}
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+		})
+
+		Context("when a label contains invalid characters", func() {
+			BeforeEach(func() {
+				worker.Labels = map[string]string{
+					"arch": "arm 64",
+				}
+			})
+
+			It("returns errors", func() {
+				err := worker.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid worker label 'arch=arm 64'"))
+			})
+		})
+
 		Context("when garden address is missing", func() {
 			BeforeEach(func() {
 				worker.GardenAddr = ""
@@ -2,10 +2,89 @@ package wrappa
 
 import (
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/auth"
 	"github.com/tedsuo/rata"
 )
 
+// minimumRoles maps each route concerning a single team to the least role a
+// member of that team needs in order to use it.
+var minimumRoles = map[string]accessor.Role{
+	atc.GetBuild:                      accessor.ViewerRole,
+	atc.BuildResources:                accessor.ViewerRole,
+	atc.GetBuildPlan:                  accessor.ViewerRole,
+	atc.GetBuildPreparation:           accessor.ViewerRole,
+	atc.BuildEvents:                   accessor.ViewerRole,
+	atc.GetPipeline:                   accessor.ViewerRole,
+	atc.GetJobBuild:                   accessor.ViewerRole,
+	atc.PipelineBadge:                 accessor.ViewerRole,
+	atc.JobBadge:                      accessor.ViewerRole,
+	atc.ListJobs:                      accessor.ViewerRole,
+	atc.GetJob:                        accessor.ViewerRole,
+	atc.ListJobBuilds:                 accessor.ViewerRole,
+	atc.ListPipelineBuilds:            accessor.ViewerRole,
+	atc.GetResource:                   accessor.ViewerRole,
+	atc.ListBuildsWithVersionAsInput:  accessor.ViewerRole,
+	atc.ListBuildsWithVersionAsOutput: accessor.ViewerRole,
+	atc.GetResourceCausality:          accessor.ViewerRole,
+	atc.GetResourceVersion:            accessor.ViewerRole,
+	atc.ListResources:                 accessor.ViewerRole,
+	atc.ListResourceTypes:             accessor.ViewerRole,
+	atc.ListResourceVersions:          accessor.ViewerRole,
+	atc.GetContainer:                  accessor.ViewerRole,
+	atc.ListContainers:                accessor.ViewerRole,
+	atc.ListVolumes:                   accessor.ViewerRole,
+	atc.ListTeamBuilds:                accessor.ViewerRole,
+	atc.SearchTeamBuildLogs:           accessor.ViewerRole,
+	atc.SearchPipelineBuildLogs:       accessor.ViewerRole,
+	atc.SearchJobBuildLogs:            accessor.ViewerRole,
+	atc.GetConfig:                     accessor.ViewerRole,
+	atc.ListConfigVersions:            accessor.ViewerRole,
+	atc.GetConfigVersion:              accessor.ViewerRole,
+	atc.DiffConfigVersions:            accessor.ViewerRole,
+	atc.GetVersionsDB:                 accessor.ViewerRole,
+	atc.ListJobInputs:                 accessor.ViewerRole,
+	atc.ExplainJobScheduling:          accessor.ViewerRole,
+	atc.ListTeamVars:                  accessor.ViewerRole,
+
+	atc.AbortBuild:             accessor.PipelineOperatorRole,
+	atc.CheckResource:          accessor.PipelineOperatorRole,
+	atc.CreateJobBuild:         accessor.PipelineOperatorRole,
+	atc.DisableResourceVersion: accessor.PipelineOperatorRole,
+	atc.EnableResourceVersion:  accessor.PipelineOperatorRole,
+	atc.PinResourceVersion:     accessor.PipelineOperatorRole,
+	atc.UnpinResource:          accessor.PipelineOperatorRole,
+	atc.PauseJob:               accessor.PipelineOperatorRole,
+	atc.PausePipeline:          accessor.PipelineOperatorRole,
+	atc.PauseResource:          accessor.PipelineOperatorRole,
+	atc.UnpauseJob:             accessor.PipelineOperatorRole,
+	atc.UnpausePipeline:        accessor.PipelineOperatorRole,
+	atc.UnpauseResource:        accessor.PipelineOperatorRole,
+
+	atc.SendInputToBuildPlan:    accessor.MemberRole,
+	atc.ReadOutputFromBuildPlan: accessor.MemberRole,
+	atc.PruneWorker:             accessor.MemberRole,
+	atc.LandWorker:              accessor.MemberRole,
+	atc.RetireWorker:            accessor.MemberRole,
+	atc.GetWorkerDrainStatus:    accessor.ViewerRole,
+	atc.CreateBuild:             accessor.MemberRole,
+	atc.CreatePipelineBuild:     accessor.MemberRole,
+	atc.HijackContainer:         accessor.MemberRole,
+	atc.DeletePipeline:          accessor.MemberRole,
+	atc.OrderPipelines:          accessor.MemberRole,
+	atc.RenamePipeline:          accessor.MemberRole,
+	atc.ExposePipeline:          accessor.MemberRole,
+	atc.HidePipeline:            accessor.MemberRole,
+	atc.SaveConfig:              accessor.MemberRole,
+	atc.RestoreConfigVersion:    accessor.MemberRole,
+	atc.SetTeamVar:              accessor.MemberRole,
+	atc.DeleteTeamVar:           accessor.MemberRole,
+
+	atc.SetTeam:     accessor.OwnerRole,
+	atc.RenameTeam:  accessor.OwnerRole,
+	atc.DestroyTeam: accessor.OwnerRole,
+}
+
 type APIAuthWrappa struct {
 	checkPipelineAccessHandlerFactory   auth.CheckPipelineAccessHandlerFactory
 	checkBuildReadAccessHandlerFactory  auth.CheckBuildReadAccessHandlerFactory
@@ -33,6 +112,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 	rejector := auth.UnauthorizedRejector{}
 
 	for name, handler := range handlers {
+		if role, found := minimumRoles[name]; found {
+			handler = auth.CheckRoleHandler(handler, role, rejector)
+		}
+
 		newHandler := handler
 
 		switch name {
@@ -70,7 +153,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// requester is system, admin team, or worker owning team
 		case atc.PruneWorker,
 			atc.LandWorker,
-			atc.RetireWorker:
+			atc.RetireWorker,
+			atc.GetWorkerDrainStatus:
 			newHandler = wrappa.checkWorkerTeamAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
@@ -82,6 +166,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetJob,
 			atc.ListJobBuilds,
 			atc.ListPipelineBuilds,
+			atc.SearchPipelineBuildLogs,
+			atc.SearchJobBuildLogs,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
@@ -103,13 +189,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeleteWorker,
 			atc.SetTeam,
 			atc.ListTeamBuilds,
+			atc.SearchTeamBuildLogs,
 			atc.RenameTeam,
 			atc.DestroyTeam,
 			atc.ListVolumes:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ListAuditEvents:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
@@ -119,9 +207,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.PinResourceVersion,
+			atc.UnpinResource,
 			atc.GetConfig,
+			atc.ListConfigVersions,
+			atc.GetConfigVersion,
+			atc.DiffConfigVersions,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ExplainJobScheduling,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
@@ -132,7 +226,11 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpauseResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
-			atc.SaveConfig:
+			atc.SaveConfig,
+			atc.RestoreConfigVersion,
+			atc.ListTeamVars,
+			atc.SetTeamVar,
+			atc.DeleteTeamVar:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
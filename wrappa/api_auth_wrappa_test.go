@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/wrappa"
@@ -64,6 +65,14 @@ var _ = Describe("APIAuthWrappa", func() {
 		)
 	}
 
+	requiresRole := func(role accessor.Role, handler http.Handler) http.Handler {
+		return auth.CheckRoleHandler(
+			handler,
+			role,
+			rejector,
+		)
+	}
+
 	authorized := func(handler http.Handler) http.Handler {
 		return auth.CSRFValidationHandler(
 			auth.CheckAuthorizationHandler(
@@ -155,82 +164,97 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.LegacyGetUser:         unauthenticated(inputHandlers[atc.LegacyGetUser]),
 
 				// authorized or public pipeline
-				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
-				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
-				atc.GetBuildPlan:   doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.GetBuild:       doesNotCheckIfPrivateJob(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetBuild])),
+				atc.BuildResources: doesNotCheckIfPrivateJob(requiresRole(accessor.ViewerRole, inputHandlers[atc.BuildResources])),
+				atc.GetBuildPlan:   doesNotCheckIfPrivateJob(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetBuildPlan])),
 
 				// authorized or public pipeline and public job
-				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
-				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.BuildEvents:         checksIfPrivateJob(requiresRole(accessor.ViewerRole, inputHandlers[atc.BuildEvents])),
+				atc.GetBuildPreparation: checksIfPrivateJob(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetBuildPreparation])),
 
 				// resource belongs to authorized team
-				atc.AbortBuild:              checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
-				atc.SendInputToBuildPlan:    checkWritePermissionForBuild(inputHandlers[atc.SendInputToBuildPlan]),
-				atc.ReadOutputFromBuildPlan: checkWritePermissionForBuild(inputHandlers[atc.ReadOutputFromBuildPlan]),
+				atc.AbortBuild:              checkWritePermissionForBuild(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.AbortBuild])),
+				atc.SendInputToBuildPlan:    checkWritePermissionForBuild(requiresRole(accessor.MemberRole, inputHandlers[atc.SendInputToBuildPlan])),
+				atc.ReadOutputFromBuildPlan: checkWritePermissionForBuild(requiresRole(accessor.MemberRole, inputHandlers[atc.ReadOutputFromBuildPlan])),
 
 				// resource belongs to authorized team
-				atc.PruneWorker:  checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
-				atc.LandWorker:   checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
-				atc.RetireWorker: checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.PruneWorker:          checkTeamAccessForWorker(requiresRole(accessor.MemberRole, inputHandlers[atc.PruneWorker])),
+				atc.LandWorker:           checkTeamAccessForWorker(requiresRole(accessor.MemberRole, inputHandlers[atc.LandWorker])),
+				atc.RetireWorker:         checkTeamAccessForWorker(requiresRole(accessor.MemberRole, inputHandlers[atc.RetireWorker])),
+				atc.GetWorkerDrainStatus: checkTeamAccessForWorker(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetWorkerDrainStatus])),
 
 				// belongs to public pipeline or authorized
-				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
-				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobBuild]),
-				atc.PipelineBadge:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.PipelineBadge]),
-				atc.JobBadge:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.JobBadge]),
-				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
-				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
-				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
-				atc.ListPipelineBuilds:            openForPublicPipelineOrAuthorized(inputHandlers[atc.ListPipelineBuilds]),
-				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
-				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
-				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
-				atc.ListResources:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResources]),
-				atc.ListResourceTypes:             openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceTypes]),
-				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
-				atc.GetResourceCausality:          openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceCausality]),
-				atc.GetResourceVersion:            openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceVersion]),
+				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetPipeline])),
+				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetJobBuild])),
+				atc.PipelineBadge:                 openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.PipelineBadge])),
+				atc.JobBadge:                      openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.JobBadge])),
+				atc.ListJobs:                      openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListJobs])),
+				atc.GetJob:                        openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetJob])),
+				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListJobBuilds])),
+				atc.ListPipelineBuilds:            openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListPipelineBuilds])),
+				atc.SearchPipelineBuildLogs:       openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.SearchPipelineBuildLogs])),
+				atc.SearchJobBuildLogs:            openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.SearchJobBuildLogs])),
+				atc.GetResource:                   openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetResource])),
+				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListBuildsWithVersionAsInput])),
+				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListBuildsWithVersionAsOutput])),
+				atc.ListResources:                 openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListResources])),
+				atc.ListResourceTypes:             openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListResourceTypes])),
+				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListResourceVersions])),
+				atc.GetResourceCausality:          openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetResourceCausality])),
+				atc.GetResourceVersion:            openForPublicPipelineOrAuthorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetResourceVersion])),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
-				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
-				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
-				atc.ListTeamBuilds:  authenticated(inputHandlers[atc.ListTeamBuilds]),
-				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
-				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
-				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
-				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
-				atc.RenameTeam:      authenticated(inputHandlers[atc.RenameTeam]),
-				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
+				atc.CreateBuild:         authenticated(requiresRole(accessor.MemberRole, inputHandlers[atc.CreateBuild])),
+				atc.GetContainer:        authenticated(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetContainer])),
+				atc.HijackContainer:     authenticated(requiresRole(accessor.MemberRole, inputHandlers[atc.HijackContainer])),
+				atc.ListContainers:      authenticated(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListContainers])),
+				atc.ListVolumes:         authenticated(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListVolumes])),
+				atc.ListTeamBuilds:      authenticated(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListTeamBuilds])),
+				atc.SearchTeamBuildLogs: authenticated(requiresRole(accessor.ViewerRole, inputHandlers[atc.SearchTeamBuildLogs])),
+				atc.ListWorkers:         authenticated(inputHandlers[atc.ListWorkers]),
+				atc.RegisterWorker:      authenticated(inputHandlers[atc.RegisterWorker]),
+				atc.HeartbeatWorker:     authenticated(inputHandlers[atc.HeartbeatWorker]),
+				atc.DeleteWorker:        authenticated(inputHandlers[atc.DeleteWorker]),
+				atc.SetTeam:             authenticated(requiresRole(accessor.OwnerRole, inputHandlers[atc.SetTeam])),
+				atc.RenameTeam:          authenticated(requiresRole(accessor.OwnerRole, inputHandlers[atc.RenameTeam])),
+				atc.DestroyTeam:         authenticated(requiresRole(accessor.OwnerRole, inputHandlers[atc.DestroyTeam])),
 
 				// authenticated and is admin
-				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetLogLevel:     authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:     authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.ListAuditEvents: authenticatedAndAdmin(inputHandlers[atc.ListAuditEvents]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
-				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:    authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.CheckResource:          authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.CheckResource])),
+				atc.CreateJobBuild:         authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.CreateJobBuild])),
+				atc.DeletePipeline:         authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.DeletePipeline])),
+				atc.DisableResourceVersion: authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.DisableResourceVersion])),
+				atc.EnableResourceVersion:  authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.EnableResourceVersion])),
+				atc.PinResourceVersion:     authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.PinResourceVersion])),
+				atc.UnpinResource:          authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.UnpinResource])),
+				atc.GetConfig:              authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetConfig])),
+				atc.ListConfigVersions:     authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListConfigVersions])),
+				atc.GetConfigVersion:       authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetConfigVersion])),
+				atc.DiffConfigVersions:     authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.DiffConfigVersions])),
+				atc.GetVersionsDB:          authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.GetVersionsDB])),
+				atc.ListJobInputs:          authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListJobInputs])),
+				atc.ExplainJobScheduling:   authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ExplainJobScheduling])),
+				atc.OrderPipelines:         authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.OrderPipelines])),
+				atc.PauseJob:               authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.PauseJob])),
+				atc.PausePipeline:          authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.PausePipeline])),
+				atc.PauseResource:          authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.PauseResource])),
+				atc.RenamePipeline:         authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.RenamePipeline])),
+				atc.SaveConfig:             authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.SaveConfig])),
+				atc.RestoreConfigVersion:   authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.RestoreConfigVersion])),
+				atc.UnpauseJob:             authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.UnpauseJob])),
+				atc.UnpausePipeline:        authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.UnpausePipeline])),
+				atc.UnpauseResource:        authorized(requiresRole(accessor.PipelineOperatorRole, inputHandlers[atc.UnpauseResource])),
+				atc.ExposePipeline:         authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.ExposePipeline])),
+				atc.HidePipeline:           authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.HidePipeline])),
+				atc.CreatePipelineBuild:    authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.CreatePipelineBuild])),
+				atc.ListTeamVars:           authorized(requiresRole(accessor.ViewerRole, inputHandlers[atc.ListTeamVars])),
+				atc.SetTeamVar:             authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.SetTeamVar])),
+				atc.DeleteTeamVar:          authorized(requiresRole(accessor.MemberRole, inputHandlers[atc.DeleteTeamVar])),
 			}
 		})
 
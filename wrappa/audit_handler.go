@@ -0,0 +1,164 @@
+package wrappa
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/db"
+)
+
+// AuditHandler records an audit event for every request it serves, once the
+// wrapped handler has responded. It must be wrapped by accessor.NewHandler.
+//
+// Routes without a team in their URL are attributed to the team of the build
+// or worker they target, looked up before the wrapped handler runs so that
+// the team is known even if the handler deletes the target.
+type AuditHandler struct {
+	Logger            lager.Logger
+	Route             string
+	AuditEventFactory db.AuditEventFactory
+	BuildFactory      db.BuildFactory
+	WorkerFactory     db.WorkerFactory
+	Clock             clock.Clock
+	Handler           http.Handler
+}
+
+func (handler AuditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	startTime := handler.Clock.Now()
+
+	target := map[string]string{}
+	for key, values := range r.URL.Query() {
+		if strings.HasPrefix(key, ":") && len(values) > 0 {
+			target[strings.TrimPrefix(key, ":")] = values[0]
+		}
+	}
+
+	teamName := handler.teamName(target)
+
+	recorder := &statusRecorder{ResponseWriter: w}
+
+	handler.Handler.ServeHTTP(recorder, r)
+
+	acc := accessor.GetAccessor(r)
+
+	err := handler.AuditEventFactory.SaveAuditEvent(db.AuditEvent{
+		Time:     startTime,
+		UserName: acc.UserName(),
+		TeamName: teamName,
+		Route:    handler.Route,
+		Target:   target,
+		Status:   recorder.Status(),
+	})
+	if err != nil {
+		handler.Logger.Error("failed-to-save-audit-event", err, lager.Data{
+			"route":  handler.Route,
+			"target": target,
+		})
+	}
+}
+
+func (handler AuditHandler) teamName(target map[string]string) string {
+	if teamName, found := target["team_name"]; found {
+		return teamName
+	}
+
+	logger := handler.Logger.Session("find-team", lager.Data{
+		"route":  handler.Route,
+		"target": target,
+	})
+
+	if buildID, found := target["build_id"]; found {
+		id, err := strconv.Atoi(buildID)
+		if err != nil {
+			return ""
+		}
+
+		build, found, err := handler.BuildFactory.Build(id)
+		if err != nil {
+			logger.Error("failed-to-get-build", err)
+			return ""
+		}
+
+		if !found {
+			return ""
+		}
+
+		return build.TeamName()
+	}
+
+	if workerName, found := target["worker_name"]; found {
+		worker, found, err := handler.WorkerFactory.GetWorker(workerName)
+		if err != nil {
+			logger.Error("failed-to-get-worker", err)
+			return ""
+		}
+
+		if !found {
+			return ""
+		}
+
+		return worker.TeamName()
+	}
+
+	return ""
+}
+
+// statusRecorder remembers the status written through it while passing
+// everything on, including hijacking the connection for intercepted
+// containers.
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(data []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+
+	return recorder.ResponseWriter.Write(data)
+}
+
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err == nil && recorder.status == 0 {
+		recorder.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
+
+func (recorder *statusRecorder) Status() int {
+	if recorder.status == 0 {
+		return http.StatusOK
+	}
+
+	return recorder.status
+}
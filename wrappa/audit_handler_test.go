@@ -0,0 +1,225 @@
+package wrappa_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/wrappa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditHandler", func() {
+	var (
+		logger                *lagertest.TestLogger
+		fakeAuditEventFactory *dbfakes.FakeAuditEventFactory
+		fakeBuildFactory      *dbfakes.FakeBuildFactory
+		fakeWorkerFactory     *dbfakes.FakeWorkerFactory
+		fakeAccessFactory     *accessorfakes.FakeAccessFactory
+		fakeAccess            *accessorfakes.FakeAccess
+		fakeClock             *fakeclock.FakeClock
+
+		route        string
+		innerHandler http.HandlerFunc
+
+		recorder *httptest.ResponseRecorder
+		request  *http.Request
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+
+		fakeAccess = new(accessorfakes.FakeAccess)
+		fakeAccess.UserNameReturns("some-user")
+
+		fakeAccessFactory = new(accessorfakes.FakeAccessFactory)
+		fakeAccessFactory.CreateReturns(fakeAccess)
+
+		route = atc.PausePipeline
+
+		innerHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		recorder = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("PUT", "http://example.com/api/v1/teams/some-team/pipelines/some-pipeline/pause", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		request.URL.RawQuery = url.Values{
+			":team_name":     []string{"some-team"},
+			":pipeline_name": []string{"some-pipeline"},
+		}.Encode()
+	})
+
+	JustBeforeEach(func() {
+		handler := accessor.NewHandler(wrappa.AuditHandler{
+			Logger:            logger,
+			Route:             route,
+			AuditEventFactory: fakeAuditEventFactory,
+			BuildFactory:      fakeBuildFactory,
+			WorkerFactory:     fakeWorkerFactory,
+			Clock:             fakeClock,
+			Handler:           innerHandler,
+		}, fakeAccessFactory)
+
+		handler.ServeHTTP(recorder, request)
+	})
+
+	It("calls the wrapped handler", func() {
+		Expect(recorder.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("saves an audit event describing the call", func() {
+		Expect(fakeAuditEventFactory.SaveAuditEventCallCount()).To(Equal(1))
+		Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+			Time:     time.Unix(123, 0),
+			UserName: "some-user",
+			TeamName: "some-team",
+			Route:    atc.PausePipeline,
+			Target: map[string]string{
+				"team_name":     "some-team",
+				"pipeline_name": "some-pipeline",
+			},
+			Status: http.StatusNoContent,
+		}))
+	})
+
+	Context("when the wrapped handler rejects the call", func() {
+		BeforeEach(func() {
+			innerHandler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		})
+
+		It("records the rejection", func() {
+			Expect(fakeAuditEventFactory.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0).Status).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("when the wrapped handler only writes a body", func() {
+		BeforeEach(func() {
+			innerHandler = func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("hello"))
+			}
+		})
+
+		It("records a 200", func() {
+			Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0).Status).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when the route targets a build", func() {
+		BeforeEach(func() {
+			route = atc.AbortBuild
+
+			request.URL.RawQuery = url.Values{
+				":build_id": []string{"42"},
+			}.Encode()
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				fakeBuild := new(dbfakes.FakeBuild)
+				fakeBuild.TeamNameReturns("some-team")
+				fakeBuildFactory.BuildReturns(fakeBuild, true, nil)
+			})
+
+			It("records the team of the build", func() {
+				Expect(fakeBuildFactory.BuildArgsForCall(0)).To(Equal(42))
+				Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0).TeamName).To(Equal("some-team"))
+				Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0).Target).To(Equal(map[string]string{
+					"build_id": "42",
+				}))
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				fakeBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("records the event without a team", func() {
+				Expect(fakeAuditEventFactory.SaveAuditEventCallCount()).To(Equal(1))
+				Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0).TeamName).To(BeEmpty())
+			})
+		})
+
+		Context("when finding the build fails", func() {
+			BeforeEach(func() {
+				fakeBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+			})
+
+			It("still records the event", func() {
+				Expect(fakeAuditEventFactory.SaveAuditEventCallCount()).To(Equal(1))
+				Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0).TeamName).To(BeEmpty())
+			})
+
+			It("logs the failure", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.find-team.failed-to-get-build"))
+			})
+		})
+	})
+
+	Context("when the route targets a worker", func() {
+		BeforeEach(func() {
+			route = atc.PruneWorker
+
+			request.URL.RawQuery = url.Values{
+				":worker_name": []string{"some-worker"},
+			}.Encode()
+
+			fakeWorker := new(dbfakes.FakeWorker)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+
+			innerHandler = func(w http.ResponseWriter, r *http.Request) {
+				fakeWorkerFactory.GetWorkerReturns(nil, false, nil)
+				w.WriteHeader(http.StatusOK)
+			}
+		})
+
+		It("records the team the worker had before the call", func() {
+			Expect(fakeWorkerFactory.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+			Expect(fakeAuditEventFactory.SaveAuditEventArgsForCall(0).TeamName).To(Equal("some-team"))
+		})
+	})
+
+	Context("when the route has a team in its URL", func() {
+		It("does not look up the build or worker", func() {
+			Expect(fakeBuildFactory.BuildCallCount()).To(BeZero())
+			Expect(fakeWorkerFactory.GetWorkerCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when saving the audit event fails", func() {
+		BeforeEach(func() {
+			fakeAuditEventFactory.SaveAuditEventReturns(errors.New("nope"))
+		})
+
+		It("still responds", func() {
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("logs the failure", func() {
+			Expect(logger.LogMessages()).To(ContainElement("test.failed-to-save-audit-event"))
+		})
+	})
+})
@@ -0,0 +1,81 @@
+package wrappa
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+type AuditWrappa struct {
+	logger            lager.Logger
+	auditEventFactory db.AuditEventFactory
+	buildFactory      db.BuildFactory
+	workerFactory     db.WorkerFactory
+	clock             clock.Clock
+}
+
+func NewAuditWrappa(
+	logger lager.Logger,
+	auditEventFactory db.AuditEventFactory,
+	buildFactory db.BuildFactory,
+	workerFactory db.WorkerFactory,
+	clock clock.Clock,
+) Wrappa {
+	return AuditWrappa{
+		logger:            logger,
+		auditEventFactory: auditEventFactory,
+		buildFactory:      buildFactory,
+		workerFactory:     workerFactory,
+		clock:             clock,
+	}
+}
+
+func (wrappa AuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		switch name {
+		case atc.SaveConfig,
+			atc.RestoreConfigVersion,
+			atc.DeletePipeline,
+			atc.PausePipeline,
+			atc.UnpausePipeline,
+			atc.ExposePipeline,
+			atc.HidePipeline,
+			atc.RenamePipeline,
+			atc.PauseJob,
+			atc.UnpauseJob,
+			atc.PauseResource,
+			atc.UnpauseResource,
+			atc.EnableResourceVersion,
+			atc.DisableResourceVersion,
+			atc.PinResourceVersion,
+			atc.UnpinResource,
+			atc.AbortBuild,
+			atc.HijackContainer,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker,
+			atc.SetTeam,
+			atc.RenameTeam,
+			atc.DestroyTeam,
+			atc.SetTeamVar,
+			atc.DeleteTeamVar:
+			wrapped[name] = AuditHandler{
+				Logger:            wrappa.logger,
+				Route:             name,
+				AuditEventFactory: wrappa.auditEventFactory,
+				BuildFactory:      wrappa.buildFactory,
+				WorkerFactory:     wrappa.workerFactory,
+				Clock:             wrappa.clock,
+				Handler:           handler,
+			}
+		default:
+			wrapped[name] = handler
+		}
+	}
+
+	return wrapped
+}
@@ -0,0 +1,117 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditWrappa", func() {
+	var (
+		logger                *lagertest.TestLogger
+		fakeAuditEventFactory *dbfakes.FakeAuditEventFactory
+		fakeBuildFactory      *dbfakes.FakeBuildFactory
+		fakeWorkerFactory     *dbfakes.FakeWorkerFactory
+		fakeClock             *fakeclock.FakeClock
+
+		inputHandlers    rata.Handlers
+		expectedHandlers rata.Handlers
+		wrappedHandlers  rata.Handlers
+	)
+
+	audited := func(route string) http.Handler {
+		return wrappa.AuditHandler{
+			Logger:            logger,
+			Route:             route,
+			AuditEventFactory: fakeAuditEventFactory,
+			BuildFactory:      fakeBuildFactory,
+			WorkerFactory:     fakeWorkerFactory,
+			Clock:             fakeClock,
+			Handler:           inputHandlers[route],
+		}
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+
+		inputHandlers = rata.Handlers{}
+
+		for _, route := range atc.Routes {
+			inputHandlers[route.Name] = &stupidHandler{}
+		}
+
+		expectedHandlers = rata.Handlers{}
+
+		for route, handler := range inputHandlers {
+			expectedHandlers[route] = handler
+		}
+
+		for _, route := range []string{
+			atc.SaveConfig,
+			atc.RestoreConfigVersion,
+			atc.DeletePipeline,
+			atc.PausePipeline,
+			atc.UnpausePipeline,
+			atc.ExposePipeline,
+			atc.HidePipeline,
+			atc.RenamePipeline,
+			atc.PauseJob,
+			atc.UnpauseJob,
+			atc.PauseResource,
+			atc.UnpauseResource,
+			atc.EnableResourceVersion,
+			atc.DisableResourceVersion,
+			atc.PinResourceVersion,
+			atc.UnpinResource,
+			atc.AbortBuild,
+			atc.HijackContainer,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker,
+			atc.SetTeam,
+			atc.RenameTeam,
+			atc.DestroyTeam,
+			atc.SetTeamVar,
+			atc.DeleteTeamVar,
+		} {
+			expectedHandlers[route] = audited(route)
+		}
+	})
+
+	JustBeforeEach(func() {
+		wrappedHandlers = wrappa.NewAuditWrappa(
+			logger,
+			fakeAuditEventFactory,
+			fakeBuildFactory,
+			fakeWorkerFactory,
+			fakeClock,
+		).Wrap(inputHandlers)
+	})
+
+	It("audits only the mutating routes", func() {
+		Expect(wrappedHandlers).To(HaveLen(len(expectedHandlers)))
+
+		for name, handler := range wrappedHandlers {
+			Expect(descriptiveRoute{
+				route:   name,
+				handler: handler,
+			}).To(Equal(descriptiveRoute{
+				route:   name,
+				handler: expectedHandlers[name],
+			}))
+		}
+	})
+})